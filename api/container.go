@@ -0,0 +1,100 @@
+package api
+
+import (
+	"time"
+
+	"github.com/ekinertac/dtop/docker"
+	"github.com/ekinertac/dtop/model"
+)
+
+// Container is a container as the API returns it: what the daemon lists, with the
+// project dtop groups it under and its latest stats sample
+type Container struct {
+	ID       string            `json:"id"` // Short ID
+	Name     string            `json:"name"`
+	Project  string            `json:"project"`
+	Image    string            `json:"image"`
+	State    string            `json:"state"`
+	Status   string            `json:"status"`
+	Created  time.Time         `json:"created"`
+	Started  *time.Time        `json:"started,omitempty"`
+	Uptime   string            `json:"uptime"` // As dtop shows it, e.g. "2d 4h" or "exited 5m ago"
+	ExitCode *int              `json:"exit_code,omitempty"`
+	Networks []string          `json:"networks"`
+	Ports    []string          `json:"ports"`
+	Node     string            `json:"node,omitempty"`
+	Labels   map[string]string `json:"labels"`
+	Stats    *Stats            `json:"stats,omitempty"` // Running containers only
+}
+
+// Stats is a container's resource usage at one point in time
+type Stats struct {
+	Time           time.Time `json:"time"`
+	CPUPercent     float64   `json:"cpu_percent"` // 100 per core
+	MemoryPercent  float64   `json:"memory_percent"`
+	MemoryUsage    uint64    `json:"memory_usage_bytes"`
+	MemoryLimit    uint64    `json:"memory_limit_bytes"` // Host memory when unlimited
+	NetRx          uint64    `json:"net_rx_bytes"`
+	NetTx          uint64    `json:"net_tx_bytes"`
+	BlockRead      uint64    `json:"block_read_bytes"`
+	BlockWrite     uint64    `json:"block_write_bytes"`
+	BlockReadRate  float64   `json:"block_read_bytes_per_second"`
+	BlockWriteRate float64   `json:"block_write_bytes_per_second"`
+	PIDs           uint64    `json:"pids"`
+	PIDsLimit      uint64    `json:"pids_limit,omitempty"`
+}
+
+// newContainer converts a listed container; stats is nil for containers not running
+func newContainer(c *docker.ContainerInfo, opts model.TreeOptions, stats *Stats) Container {
+	result := Container{
+		ID:       c.ID,
+		Name:     c.Name,
+		Project:  model.ProjectName(c, opts),
+		Image:    c.Image,
+		State:    c.State,
+		Status:   c.Status,
+		Created:  c.CreatedAt,
+		Uptime:   model.ContainerUptime(c),
+		Networks: c.Networks,
+		Ports:    c.Ports,
+		Node:     c.Node,
+		Labels:   c.Labels,
+		Stats:    stats,
+	}
+	// Clients get empty lists rather than null
+	if result.Networks == nil {
+		result.Networks = []string{}
+	}
+	if result.Ports == nil {
+		result.Ports = []string{}
+	}
+	if result.Labels == nil {
+		result.Labels = map[string]string{}
+	}
+	if !c.StartedAt.IsZero() {
+		result.Started = &c.StartedAt
+	}
+	if c.State == "exited" || c.State == "dead" {
+		result.ExitCode = &c.ExitCode
+	}
+	return result
+}
+
+// newStats converts a stats sample taken at t
+func newStats(s docker.ContainerStats, t time.Time) *Stats {
+	return &Stats{
+		Time:           t,
+		CPUPercent:     s.CPUPerc,
+		MemoryPercent:  s.MemPerc,
+		MemoryUsage:    s.Memory.Usage,
+		MemoryLimit:    s.Memory.Limit,
+		NetRx:          s.NetRx,
+		NetTx:          s.NetTx,
+		BlockRead:      s.Block.Read,
+		BlockWrite:     s.Block.Write,
+		BlockReadRate:  s.Block.ReadRate,
+		BlockWriteRate: s.Block.WriteRate,
+		PIDs:           s.PIDs,
+		PIDsLimit:      s.PIDsLimit,
+	}
+}
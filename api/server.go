@@ -0,0 +1,381 @@
+// Package api serves dtop's view of the containers as JSON over HTTP, for dashboards
+// and scripts
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ekinertac/dtop/audit"
+	"github.com/ekinertac/dtop/config"
+	"github.com/ekinertac/dtop/debuglog"
+	"github.com/ekinertac/dtop/docker"
+	"github.com/ekinertac/dtop/model"
+)
+
+// collectInterval is how often the container list and stats are refreshed, as in the TUI
+const collectInterval = 2 * time.Second
+
+// Server answers requests from a snapshot of the containers that it refreshes in the
+// background, so clients polling it don't each hit the daemon
+type Server struct {
+	client *docker.Client
+	cfg    *config.Config
+	opts   model.TreeOptions
+	audit  *audit.Log
+
+	// insecure allows container actions without api_token
+	insecure bool
+	// addr is the listen address, which requests must name as their Host unless
+	// they name a loopback one
+	addr string
+
+	mu         sync.RWMutex
+	containers []docker.ContainerInfo
+	stats      map[string]*Stats // Latest sample per container ID
+}
+
+// New creates a server grouping containers into projects like the TUI does with opts
+func New(client *docker.Client, cfg *config.Config, opts model.TreeOptions) *Server {
+	return &Server{
+		client: client,
+		cfg:    cfg,
+		opts:   opts,
+		audit:  audit.New(cfg.AuditLogFile),
+		stats:  make(map[string]*Stats),
+	}
+}
+
+// SetInsecure allows container actions when no api_token is set. Requests from
+// other sites' pages are still refused, so a browser can't be made to send them.
+func (s *Server) SetInsecure(insecure bool) {
+	s.insecure = insecure
+}
+
+// Run collects once, then serves the API on addr until ctx is canceled. Without
+// api_token, it only listens on loopback addresses.
+func (s *Server) Run(ctx context.Context, addr string) error {
+	if s.cfg.APIToken == "" && !loopbackAddr(addr) {
+		return fmt.Errorf("refusing to serve the API on %s without api_token; set it, or listen on 127.0.0.1", addr)
+	}
+	s.addr = addr
+	if err := s.collect(); err != nil {
+		return fmt.Errorf("failed to list containers: %w", err)
+	}
+	go s.collectLoop(ctx)
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdown)
+	}()
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Handler routes the web UI and the API endpoints, the latter behind the token check
+// when api_token is set. The page itself holds no data, so it's served to anyone and
+// asks for the token when the API refuses it. Without a token, container actions are
+// refused unless the server is insecure. Requests for another host are refused, so
+// pages can't reach the API by pointing their own domain at it (DNS rebinding).
+func (s *Server) Handler() http.Handler {
+	return s.checkHost(s.routes())
+}
+
+// checkHost refuses requests whose Host is neither a loopback name nor the listen
+// address. With a token and a listen address for every interface, any host is
+// allowed: pages of other hosts can't know the token.
+func (s *Server) checkHost(next http.Handler) http.Handler {
+	listen, _, _ := net.SplitHostPort(s.addr)
+	anyHost := s.cfg.APIToken != "" && (listen == "" || net.ParseIP(listen).IsUnspecified())
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if !anyHost && !loopbackHost(host) && !strings.EqualFold(host, listen) {
+			writeError(w, http.StatusForbidden, fmt.Errorf("unknown host %q", r.Host))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// routes routes the endpoints, behind the token check when api_token is set
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.serveIndex)
+	mux.HandleFunc("GET /containers", s.listContainers)
+	mux.HandleFunc("GET /containers/{id}", s.getContainer)
+	mux.HandleFunc("GET /containers/{id}/stats", s.getStats)
+	mux.HandleFunc("GET /containers/{id}/logs", s.getLogs)
+	mux.HandleFunc("POST /containers/{id}/{action}", s.postAction)
+	if s.cfg.APIToken == "" {
+		return mux
+	}
+	want := []byte("Bearer " + s.cfg.APIToken)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			mux.ServeHTTP(w, r)
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), want) != 1 {
+			writeError(w, http.StatusUnauthorized, errors.New("missing or wrong API token"))
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// collectLoop refreshes the snapshot until ctx is canceled; a failed refresh keeps
+// the previous one
+func (s *Server) collectLoop(ctx context.Context) {
+	ticker := time.NewTicker(collectInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.collect(); err != nil {
+				debuglog.Error("api collect", err)
+			}
+		}
+	}
+}
+
+// collect lists the containers and samples the running ones
+func (s *Server) collect() error {
+	containers, err := s.client.ListAllContainers()
+	if err != nil {
+		return err
+	}
+
+	stats := make(map[string]*Stats, len(containers))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, c := range containers {
+		if c.State != "running" || c.Remote {
+			continue
+		}
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			sample := newStats(s.client.GetContainerStats(id), time.Now())
+			mu.Lock()
+			stats[id] = sample
+			mu.Unlock()
+		}(c.ID)
+	}
+	wg.Wait()
+
+	s.mu.Lock()
+	s.containers, s.stats = containers, stats
+	s.mu.Unlock()
+	return nil
+}
+
+// find looks up a container in the snapshot by name, short ID, or full ID or a
+// longer prefix of it
+func (s *Server) find(ref string) (Container, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for i := range s.containers {
+		c := &s.containers[i]
+		if c.Name == ref || c.ID == ref || (len(ref) > len(c.ID) && isHex(ref) && strings.HasPrefix(c.FullID, ref)) {
+			return newContainer(c, s.opts, s.stats[c.ID]), true
+		}
+	}
+	return Container{}, false
+}
+
+// isHex reports whether ref only has the characters of a container ID
+func isHex(ref string) bool {
+	for _, r := range ref {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) listContainers(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	result := make([]Container, len(s.containers))
+	for i := range s.containers {
+		result[i] = newContainer(&s.containers[i], s.opts, s.stats[s.containers[i].ID])
+	}
+	s.mu.RUnlock()
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) getContainer(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.find(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no such container: %s", r.PathValue("id")))
+		return
+	}
+	writeJSON(w, http.StatusOK, c)
+}
+
+func (s *Server) getStats(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.find(r.PathValue("id"))
+	switch {
+	case !ok:
+		writeError(w, http.StatusNotFound, fmt.Errorf("no such container: %s", r.PathValue("id")))
+	case c.Stats == nil:
+		writeError(w, http.StatusConflict, fmt.Errorf("container %s is not running", c.Name))
+	default:
+		writeJSON(w, http.StatusOK, c.Stats)
+	}
+}
+
+// getLogs writes the container's logs as plain text, stdout and stderr interleaved.
+// ?tail=N limits them to the last N lines (100 by default, 0 for all) and ?follow=true
+// keeps streaming until the client disconnects.
+func (s *Server) getLogs(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.find(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no such container: %s", r.PathValue("id")))
+		return
+	}
+	opts := docker.LogOptions{Tail: 100}
+	if tail := r.URL.Query().Get("tail"); tail != "" {
+		n, err := strconv.Atoi(tail)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid tail %q", tail))
+			return
+		}
+		opts.Tail = n
+	}
+	if follow := r.URL.Query().Get("follow"); follow != "" {
+		var err error
+		if opts.Follow, err = strconv.ParseBool(follow); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid follow %q", follow))
+			return
+		}
+	}
+
+	out := &logWriter{w: w, rc: http.NewResponseController(w)}
+	err := s.client.StreamLogsContext(r.Context(), c.ID, opts, out, out)
+	if err != nil && !out.started && r.Context().Err() == nil {
+		writeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+// logWriter sends log output as it arrives, setting the content type on first write
+type logWriter struct {
+	mu      sync.Mutex
+	w       http.ResponseWriter
+	rc      *http.ResponseController
+	started bool
+}
+
+func (l *logWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.started {
+		l.w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		l.started = true
+	}
+	n, err := l.w.Write(p)
+	if err == nil {
+		err = l.rc.Flush()
+	}
+	return n, err
+}
+
+// postAction starts, stops or restarts a container and records it in the audit log
+func (s *Server) postAction(w http.ResponseWriter, r *http.Request) {
+	action := r.PathValue("action")
+	apply := map[string]func(string) error{
+		"start":   s.client.StartContainer,
+		"stop":    s.client.StopContainer,
+		"restart": s.client.RestartContainer,
+	}[action]
+	if apply == nil {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown action %q (start, stop or restart)", action))
+		return
+	}
+	if s.cfg.ReadOnly {
+		writeError(w, http.StatusForbidden, fmt.Errorf("cannot %s: dtop is read-only", action))
+		return
+	}
+	if s.cfg.APIToken == "" && !s.insecure {
+		writeError(w, http.StatusForbidden, fmt.Errorf("cannot %s: set api_token, or start dtop with --api-insecure", action))
+		return
+	}
+	if crossOrigin(r) {
+		writeError(w, http.StatusForbidden, fmt.Errorf("cannot %s: request from another site", action))
+		return
+	}
+	c, ok := s.find(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no such container: %s", r.PathValue("id")))
+		return
+	}
+
+	err := apply(c.ID)
+	s.audit.Record(action, c.Name, err)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"id": c.ID, "name": c.Name, "action": action})
+}
+
+// loopbackHost reports whether host names this machine's loopback interface
+func loopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(strings.Trim(host, "[]"))
+	return ip != nil && ip.IsLoopback()
+}
+
+// loopbackAddr reports whether a listen address only accepts local connections
+func loopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	return err == nil && loopbackHost(host)
+}
+
+// crossOrigin reports whether a browser sent the request from a page of another
+// site. Clients other than browsers send no Origin.
+func crossOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return false
+	}
+	u, err := url.Parse(origin)
+	return err != nil || u.Host != r.Host
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	// The client may be gone; there's no one left to tell
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
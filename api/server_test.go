@@ -0,0 +1,84 @@
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ekinertac/dtop/docker"
+)
+
+func TestFind(t *testing.T) {
+	s := &Server{containers: []docker.ContainerInfo{
+		{ID: "0123456789ab", FullID: "0123456789abcdef0123456789abcdef", Name: "web-1"},
+		{ID: "fedcba987654", FullID: "fedcba9876543210fedcba9876543210", Name: "db-1"},
+	}}
+
+	tests := []struct {
+		ref    string
+		want   string
+		wantOK bool
+	}{
+		{"web-1", "web-1", true},
+		{"fedcba987654", "db-1", true},
+		{"0123456789abcdef0123456789abcdef", "web-1", true},
+		{"0123456789abcd", "web-1", true},
+		{"0123", "", false}, // Shorter than the short ID
+		{"web", "", false},  // Names must match exactly
+		{"0123456789abXY", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		c, ok := s.find(tt.ref)
+		if ok != tt.wantOK || c.Name != tt.want {
+			t.Errorf("find(%q) = %q, %v; want %q, %v", tt.ref, c.Name, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestCrossOrigin(t *testing.T) {
+	tests := []struct {
+		host, origin string
+		want         bool
+	}{
+		{"localhost:7070", "", false},
+		{"localhost:7070", "http://localhost:7070", false},
+		{"127.0.0.1:7070", "http://127.0.0.1:7070", false},
+		{"localhost:7070", "http://localhost:8080", true},
+		{"localhost:7070", "https://evil.example", true},
+		{"localhost:7070", "null", true},
+		{"localhost:7070", "://bad", true},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/containers", nil)
+		r.Host = tt.host
+		if tt.origin != "" {
+			r.Header.Set("Origin", tt.origin)
+		}
+		if got := crossOrigin(r); got != tt.want {
+			t.Errorf("crossOrigin(Host %q, Origin %q) = %v, want %v", tt.host, tt.origin, got, tt.want)
+		}
+	}
+}
+
+func TestLoopbackHost(t *testing.T) {
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{"localhost", true},
+		{"LOCALHOST", true},
+		{"127.0.0.1", true},
+		{"127.1.2.3", true},
+		{"[::1]", true},
+		{"::1", true},
+		{"0.0.0.0", false},
+		{"192.168.1.10", false},
+		{"localhost.evil.example", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := loopbackHost(tt.host); got != tt.want {
+			t.Errorf("loopbackHost(%q) = %v, want %v", tt.host, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package api
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// indexHTML is the web UI: a single page that polls /containers and draws the
+// project tree with stats, for those who'd rather not use a terminal
+//
+//go:embed web/index.html
+var indexHTML []byte
+
+func (s *Server) serveIndex(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(indexHTML)
+}
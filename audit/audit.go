@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// maxEntries bounds the in-memory log; the file (if any) keeps everything
+const maxEntries = 1000
+
+// Entry is a single action dtop performed
+type Entry struct {
+	Time   time.Time
+	Action string // e.g. "restart", "remove"
+	Target string // Container or project name
+	Err    error
+}
+
+func (e Entry) String() string {
+	s := fmt.Sprintf("%s %s", e.Action, e.Target)
+	if e.Err != nil {
+		s += fmt.Sprintf(" (failed: %v)", e.Err)
+	}
+	return s
+}
+
+// Log records actions in memory and optionally appends them to a file
+type Log struct {
+	mu      sync.Mutex
+	entries []Entry
+	file    string
+}
+
+// New creates an audit log; file may be empty for an in-memory only log
+func New(file string) *Log {
+	return &Log{file: file}
+}
+
+// Record adds an entry for an action and its result
+func (l *Log) Record(action, target string, err error) {
+	entry := Entry{
+		Time:   time.Now(),
+		Action: action,
+		Target: target,
+		Err:    err,
+	}
+
+	l.mu.Lock()
+	l.entries = append(l.entries, entry)
+	if len(l.entries) > maxEntries {
+		l.entries = l.entries[len(l.entries)-maxEntries:]
+	}
+	l.mu.Unlock()
+
+	if l.file != "" {
+		l.appendToFile(entry)
+	}
+}
+
+// Entries returns a copy of the recorded entries, oldest first
+func (l *Log) Entries() []Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entries := make([]Entry, len(l.entries))
+	copy(entries, l.entries)
+	return entries
+}
+
+func (l *Log) appendToFile(entry Entry) {
+	f, err := os.OpenFile(l.file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	fmt.Fprintf(f, "%s %s\n", entry.Time.Format(time.RFC3339), entry)
+}
@@ -0,0 +1,483 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/api"
+	"github.com/ekinertac/dtop/config"
+	"github.com/ekinertac/dtop/debuglog"
+	"github.com/ekinertac/dtop/docker"
+	"github.com/ekinertac/dtop/model"
+	"github.com/ekinertac/dtop/record"
+	"github.com/ekinertac/dtop/ui"
+)
+
+// Version is the dtop release version
+const Version = "0.3.0"
+
+// Exit codes of the subcommands, documented for scripts
+const (
+	exitOK       = 0
+	exitError    = 1 // The daemon is unreachable, or any other failure
+	exitNotFound = 2 // No container or project matches the name
+	exitPartial  = 3 // An action failed for some of a project's containers only
+)
+
+// errNotFound is wrapped by lookups that match no container
+var errNotFound = errors.New("no such container")
+
+// codedError carries an exit code other than exitError up to Run
+type codedError struct {
+	code int
+	err  error
+}
+
+func (e codedError) Error() string { return e.err.Error() }
+func (e codedError) Unwrap() error { return e.err }
+
+// exitCode is the exit code for a subcommand's error
+func exitCode(err error) int {
+	var coded codedError
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.As(err, &coded):
+		return coded.code
+	case errors.Is(err, errNotFound):
+		return exitNotFound
+	}
+	return exitError
+}
+
+// command is a dtop subcommand
+type command struct {
+	name        string
+	usage       string // Arguments, shown in help
+	description string
+	containers  bool // Arguments complete to container names
+	projects    bool // Arguments also complete to project names
+	run         func(args []string) error
+}
+
+// commands returns all subcommands in help order
+func commands() []command {
+	return []command{
+		{name: "list", usage: "[--quiet]", description: "List containers and exit, or only their IDs", run: runList},
+		{name: "stats", usage: "[<container>] [--no-tui]", description: "Print a one-shot stats table, or follow one container live", containers: true, run: runStats},
+		{name: "logs", usage: "<container> [--follow] [--tail N]", description: "Print container logs", containers: true, run: runLogs},
+		{name: "restart", usage: "<container|project> [--quiet]", description: "Restart a container or every running container of a project", containers: true, projects: true, run: runRestart},
+		{name: "stop", usage: "<container|project> [--quiet]", description: "Stop a container or every running container of a project", containers: true, projects: true, run: runStop},
+		{name: "start", usage: "<container|project> [--quiet]", description: "Start a container or every stopped container of a project", containers: true, projects: true, run: runStart},
+		{name: "completion", usage: "<bash|zsh|fish>", description: "Print a shell completion script", run: runCompletion},
+		{name: "self-update", usage: "[--check]", description: "Replace dtop with its latest release, or only check for one", run: runSelfUpdate},
+		{name: "version", description: "Print version and exit", run: runVersion},
+		{name: "help", description: "Show this help", run: runHelp},
+	}
+}
+
+// Run dispatches a subcommand, or starts the TUI when none is given, and returns the exit code
+func Run(args []string) int {
+	// Global flags come before the command; the Go flag package stops at the first non-flag
+	fs := flag.NewFlagSet("dtop", flag.ContinueOnError)
+	fs.Usage = printUsage
+	addTLSFlags(fs)
+	fs.StringVar(&profileFlag, "profile", "", "Use the named profile from the config file")
+	fs.BoolVar(&accessibleFlag, "accessible", false, "Render for screen readers (see the accessible config setting)")
+	debug := fs.Bool("debug", false, "Log API calls, refresh timings and errors to the --log-file")
+	logFile := fs.String("log-file", "", "Write the debug log to `file` (default dtop.log); implies --debug")
+	flags := addInteractiveFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	args = fs.Args()
+
+	if *debug || *logFile != "" {
+		file, err := debuglog.Open(firstNonEmpty(*logFile, "dtop.log"))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: debug log: %v\n", err)
+			return 1
+		}
+		defer file.Close()
+	}
+
+	if len(args) > 0 {
+		name := args[0]
+
+		// Hidden helper used by completion scripts
+		if name == "__complete" {
+			runComplete(args[1:])
+			return 0
+		}
+
+		for _, cmd := range commands() {
+			if cmd.name == name {
+				err := cmd.run(args[1:])
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				}
+				return exitCode(err)
+			}
+		}
+
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", name)
+		printUsage()
+		return 1
+	}
+
+	if err := runInteractive(flags); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// interactiveFlags are the flags of the monitor itself; --list/-l and --version are
+// kept for compatibility
+type interactiveFlags struct {
+	list        *bool
+	listShort   *bool
+	version     *bool
+	recordFile  *string
+	replayFile  *string
+	apiAddr     *string
+	apiInsecure *bool
+}
+
+func addInteractiveFlags(fs *flag.FlagSet) interactiveFlags {
+	return interactiveFlags{
+		list:        fs.Bool("list", false, "List containers and exit (non-interactive)"),
+		listShort:   fs.Bool("l", false, "List containers and exit (shorthand)"),
+		version:     fs.Bool("version", false, "Print version and exit"),
+		recordFile:  fs.String("record", "", "Record every container list and stats sample to `file`"),
+		replayFile:  fs.String("replay", "", "Play back a recording made with --record"),
+		apiAddr:     fs.String("api", "", "Serve the HTTP JSON API on `address` (e.g. 127.0.0.1:8080) instead of the TUI"),
+		apiInsecure: fs.Bool("api-insecure", false, "Allow container actions over the API without api_token"),
+	}
+}
+
+// tlsFlags override the TLS settings from the config file
+var tlsFlags struct {
+	tls    bool
+	verify bool
+	caCert string
+	cert   string
+	key    string
+}
+
+// addTLSFlags registers the daemon TLS flags, named like the docker CLI's
+func addTLSFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&tlsFlags.tls, "tls", false, "Use TLS; implied by --tlsverify")
+	fs.BoolVar(&tlsFlags.verify, "tlsverify", false, "Use TLS and verify the daemon's certificate")
+	fs.StringVar(&tlsFlags.caCert, "tlscacert", "", "Trust certs signed only by this CA")
+	fs.StringVar(&tlsFlags.cert, "tlscert", "", "Path to TLS certificate file")
+	fs.StringVar(&tlsFlags.key, "tlskey", "", "Path to TLS key file")
+}
+
+// runInteractive starts the TUI
+func runInteractive(flags interactiveFlags) error {
+	// Version flag
+	if *flags.version {
+		return runVersion(nil)
+	}
+
+	// List mode - print once and exit
+	if *flags.list || *flags.listShort {
+		return runList(nil)
+	}
+
+	// Replay mode - play a recording back without a daemon
+	if *flags.replayFile != "" {
+		return runReplay(*flags.replayFile)
+	}
+
+	dockerClient, cfg, err := connect()
+	if err != nil {
+		return err
+	}
+	defer dockerClient.Close()
+	// The monitor and the API sample every refresh, so streams pay off; one-off
+	// subcommands poll
+	dockerClient.SetStatsStreams(cfg.StatsStreams)
+
+	// API mode - serve JSON until interrupted
+	if *flags.apiAddr != "" {
+		return runAPI(dockerClient, cfg, *flags.apiAddr, *flags.apiInsecure)
+	}
+
+	// Interactive mode - start TUI
+	m := ui.NewModel(dockerClient, cfg)
+	m.SetProfileSwitcher(connectProfile)
+	if cfg.UpdateCheck {
+		m.SetUpdateCheck(Version)
+	}
+	if *flags.recordFile != "" {
+		recorder, err := record.Create(*flags.recordFile)
+		if err != nil {
+			return fmt.Errorf("failed to start recording: %w", err)
+		}
+		defer recorder.Close()
+		m.SetRecorder(recorder)
+	}
+	return runProgram(m)
+}
+
+// runReplay plays back a recording in the TUI
+func runReplay(file string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	cfg.Accessible = cfg.Accessible || accessibleFlag
+	frames, err := record.Load(file)
+	if err != nil {
+		return fmt.Errorf("failed to load recording: %w", err)
+	}
+	return runProgram(ui.NewReplayModel(cfg, frames))
+}
+
+// runAPI serves the HTTP API until interrupted
+func runAPI(dockerClient *docker.Client, cfg *config.Config, addr string, insecure bool) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	switch {
+	case cfg.APIToken == "" && insecure:
+		fmt.Fprintln(os.Stderr, "Warning: api_token is not set; any program on this machine can stop containers")
+	case cfg.APIToken == "":
+		fmt.Fprintln(os.Stderr, "api_token is not set; container actions are refused (set it, or use --api-insecure)")
+	}
+	fmt.Fprintf(os.Stderr, "Serving the dtop API on %s\n", addr)
+	server := api.New(dockerClient, cfg, ui.TreeOptions(cfg))
+	server.SetInsecure(insecure)
+	return server.Run(ctx, addr)
+}
+
+// terminalReset leaves the alternate screen and turns off the modes dtop enables
+// (focus reporting, bracketed paste), showing the cursor again
+const terminalReset = "\x1b[?1004l\x1b[?2004l\x1b[?25h\x1b[?1049l"
+
+// runProgram runs the TUI until the user quits
+func runProgram(m ui.Model) error {
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithReportFocus())
+	final, err := p.Run()
+	if errors.Is(err, tea.ErrProgramPanic) {
+		// Panics in commands kill the program before every mode is reset
+		fmt.Print(terminalReset)
+	}
+	if err != nil {
+		return fmt.Errorf("running program: %w", err)
+	}
+	// Quit from the error screen; the report is still useful once the screen is gone
+	if final, ok := final.(ui.Model); ok {
+		if report := final.PanicReport(); report != "" {
+			fmt.Fprint(os.Stderr, report)
+		}
+	}
+	return nil
+}
+
+// profileFlag selects a config profile; the config's default profile is used when empty
+var profileFlag string
+
+// accessibleFlag turns on accessible rendering whatever the config says
+var accessibleFlag bool
+
+// connect loads the config, applies the selected profile and creates a Docker client from it
+func connect() (*docker.Client, *config.Config, error) {
+	return connectProfile(profileFlag)
+}
+
+// connectProfile connects using the named profile, or the config's default profile
+// when name is empty
+func connectProfile(name string) (*docker.Client, *config.Config, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	cfg, err = cfg.WithProfile(firstNonEmpty(name, cfg.Profile))
+	if err != nil {
+		return nil, nil, err
+	}
+	cfg.Accessible = cfg.Accessible || accessibleFlag
+
+	dockerClient, err := docker.NewClientWithOptions(context.Background(), docker.ClientOptions{
+		Host:      cfg.DockerHost,
+		TLS:       cfg.TLS || tlsFlags.tls,
+		TLSVerify: cfg.TLSVerify || tlsFlags.verify,
+		CACert:    firstNonEmpty(tlsFlags.caCert, cfg.TLSCACert),
+		Cert:      firstNonEmpty(tlsFlags.cert, cfg.TLSCert),
+		Key:       firstNonEmpty(tlsFlags.key, cfg.TLSKey),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	dockerClient.SetStatsConcurrency(cfg.StatsConcurrency)
+	if err := dockerClient.SetFilters(cfg.Filters); err != nil {
+		dockerClient.Close()
+		return nil, nil, err
+	}
+
+	return dockerClient, cfg, nil
+}
+
+// firstNonEmpty returns the first non-empty string
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// findContainer resolves a running container by name or ID
+func findContainer(dockerClient *docker.Client, name string) (*docker.ContainerInfo, error) {
+	containers, err := dockerClient.ListContainersWithStats(false)
+	if err != nil {
+		return nil, err
+	}
+	return matchContainer(containers, name)
+}
+
+// matchContainer picks a container by name or ID. Exact matches win, then a unique
+// name/ID prefix, then a unique substring of the name.
+func matchContainer(containers []docker.ContainerInfo, name string) (*docker.ContainerInfo, error) {
+	for i := range containers {
+		if containers[i].Name == name || containers[i].ID == name {
+			return &containers[i], nil
+		}
+	}
+
+	matchers := []func(c docker.ContainerInfo) bool{
+		func(c docker.ContainerInfo) bool {
+			return strings.HasPrefix(c.Name, name) || strings.HasPrefix(c.ID, name)
+		},
+		func(c docker.ContainerInfo) bool {
+			return strings.Contains(c.Name, name)
+		},
+	}
+	for _, matches := range matchers {
+		found := []*docker.ContainerInfo{}
+		for i := range containers {
+			if matches(containers[i]) {
+				found = append(found, &containers[i])
+			}
+		}
+		switch len(found) {
+		case 0:
+			continue
+		case 1:
+			return found[0], nil
+		default:
+			names := make([]string, len(found))
+			for i, c := range found {
+				names[i] = c.Name
+			}
+			return nil, fmt.Errorf("%q matches multiple containers: %s", name, strings.Join(names, ", "))
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", errNotFound, name)
+}
+
+// addQuietFlag adds --quiet/-q, which prints container IDs only
+func addQuietFlag(fs *flag.FlagSet) *bool {
+	quiet := fs.Bool("quiet", false, "Print container IDs only")
+	fs.BoolVar(quiet, "q", false, "Print container IDs only (shorthand)")
+	return quiet
+}
+
+// parseInterspersed parses flags that may appear before or after positional arguments
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	positional := []string{}
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "Usage: dtop [options] [command] [arguments]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Without a command, dtop starts the interactive monitor.")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Options:")
+	fmt.Fprintf(os.Stderr, "  %-40s %s\n", "--profile <name>", "Use a profile from the config file (host, filters, accent, read-only)")
+	fmt.Fprintf(os.Stderr, "  %-40s %s\n", "--accessible", "Render for screen readers: a sentence per row, no bars or borders")
+	fmt.Fprintf(os.Stderr, "  %-40s %s\n", "--record <file>", "Record every container list and stats sample to file")
+	fmt.Fprintf(os.Stderr, "  %-40s %s\n", "--replay <file>", "Play a recording back instead of monitoring the daemon")
+	fmt.Fprintf(os.Stderr, "  %-40s %s\n", "--api <address>", "Serve the HTTP JSON API and web UI on address (e.g. 127.0.0.1:8080) instead of the TUI")
+	fmt.Fprintf(os.Stderr, "  %-40s %s\n", "--api-insecure", "Allow container actions over the API without api_token")
+	fmt.Fprintf(os.Stderr, "  %-40s %s\n", "--debug", "Log API calls, refresh timings and errors to dtop.log")
+	fmt.Fprintf(os.Stderr, "  %-40s %s\n", "--log-file <file>", "Write the debug log to file instead; implies --debug")
+	fmt.Fprintf(os.Stderr, "  %-40s %s\n", "--tls", "Connect to the daemon over TLS")
+	fmt.Fprintf(os.Stderr, "  %-40s %s\n", "--tlsverify", "Use TLS and verify the daemon's certificate")
+	fmt.Fprintf(os.Stderr, "  %-40s %s\n", "--tlscacert/--tlscert/--tlskey <file>", "CA, client certificate and key (default: ~/.docker/*.pem)")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+	for _, cmd := range commands() {
+		fmt.Fprintf(os.Stderr, "  %-40s %s\n", strings.TrimSpace(cmd.name+" "+cmd.usage), cmd.description)
+	}
+}
+
+func runHelp(args []string) error {
+	printUsage()
+	return nil
+}
+
+func runVersion(args []string) error {
+	fmt.Printf("dtop v%s\n", Version)
+	fmt.Println("Docker container monitor - https://github.com/ekinertac/dtop")
+	return nil
+}
+
+func runList(args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	quiet := addQuietFlag(fs)
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return errors.New("usage: dtop list [--quiet]")
+	}
+
+	dockerClient, cfg, err := connect()
+	if err != nil {
+		return err
+	}
+	defer dockerClient.Close()
+
+	containers, err := dockerClient.ListContainers()
+	if err != nil {
+		return fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	if *quiet {
+		for _, c := range containers {
+			fmt.Println(c.ID)
+		}
+		return nil
+	}
+	tree := model.BuildTreeWithOptions(containers, ui.TreeOptions(cfg))
+	ui.PrintSnapshot(tree)
+	return nil
+}
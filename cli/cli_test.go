@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/ekinertac/dtop/docker"
+)
+
+func TestMatchContainer(t *testing.T) {
+	containers := []docker.ContainerInfo{
+		{ID: "0123456789ab", Name: "shop-web-1"},
+		{ID: "0129999999ab", Name: "shop-web-2"},
+		{ID: "abcdef012345", Name: "shop-db-1"},
+		{ID: "fedcba987654", Name: "web"},
+	}
+
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{"shop-db-1", "shop-db-1", false},
+		{"web", "web", false},          // Exact match wins over substrings
+		{"abcdef", "shop-db-1", false}, // ID prefix
+		{"shop-web-2", "shop-web-2", false},
+		{"db", "shop-db-1", false}, // Unique substring
+		{"shop-web", "", true},     // Ambiguous prefix
+		{"012", "", true},          // Ambiguous ID prefix
+		{"cache", "", true},
+	}
+	for _, tt := range tests {
+		got, err := matchContainer(containers, tt.name)
+		var gotName string
+		if got != nil {
+			gotName = got.Name
+		}
+		if (err != nil) != tt.wantErr || gotName != tt.want {
+			t.Errorf("matchContainer(%q) = %q, %v; want %q, error %v", tt.name, gotName, err, tt.want, tt.wantErr)
+		}
+	}
+}
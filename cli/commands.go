@@ -0,0 +1,293 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/audit"
+	"github.com/ekinertac/dtop/docker"
+	"github.com/ekinertac/dtop/model"
+	"github.com/ekinertac/dtop/ui"
+)
+
+// runStats prints a one-shot stats table, like `docker stats --no-stream`, or with a
+// container name follows that container's stats live
+func runStats(args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	noTUI := fs.Bool("no-tui", false, "Print one line per sample instead of the live view")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 1 {
+		return errors.New("usage: dtop stats [<container>] [--no-tui]")
+	}
+
+	dockerClient, cfg, err := connect()
+	if err != nil {
+		return err
+	}
+	defer dockerClient.Close()
+
+	if len(positional) == 1 {
+		c, err := findContainer(dockerClient, positional[0])
+		if err != nil {
+			return err
+		}
+		if *noTUI {
+			return streamStats(dockerClient, c)
+		}
+		if _, err := tea.NewProgram(ui.NewStatsModel(dockerClient, cfg, *c), tea.WithAltScreen()).Run(); err != nil {
+			return fmt.Errorf("running program: %w", err)
+		}
+		return nil
+	}
+
+	containers, err := dockerClient.ListContainers()
+	if err != nil {
+		return fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	fmt.Printf("%-12s %-30s %7s %-24s %7s %-22s %-22s %s\n",
+		"CONTAINER ID", "NAME", "CPU %", "MEM USAGE / LIMIT", "MEM %", "NET I/O", "BLOCK I/O", "PIDS")
+	for _, c := range containers {
+		netIO := fmt.Sprintf("%s / %s", docker.FormatBytes(c.NetRx), docker.FormatBytes(c.NetTx))
+		memPerc := fmt.Sprintf("%.2f%%", c.MemPerc)
+		if !c.Memory.Reported {
+			memPerc = "--"
+		}
+		fmt.Printf("%-12s %-30s %6.2f%% %-24s %7s %-22s %-22s %d\n",
+			c.ID, c.Name, c.CPUPerc, c.MemUsage, memPerc, netIO, c.BlockIO, c.PIDs)
+	}
+	return nil
+}
+
+// streamStats prints a line per stats sample of one container until its stream ends
+func streamStats(dockerClient *docker.Client, c *docker.ContainerInfo) error {
+	fmt.Printf("%-8s %7s %-24s %7s %-22s %-22s %s\n",
+		"TIME", "CPU %", "MEM USAGE / LIMIT", "MEM %", "NET I/O / s", "BLOCK I/O / s", "PIDS")
+	var prev docker.ContainerStats
+	var prevAt time.Time
+	return dockerClient.StreamContainerStats(c.ID, func(s docker.ContainerStats) {
+		now := time.Now()
+		var rx, tx uint64
+		if elapsed := now.Sub(prevAt).Seconds(); !prevAt.IsZero() && elapsed > 0 &&
+			s.NetRx >= prev.NetRx && s.NetTx >= prev.NetTx {
+			rx = uint64(float64(s.NetRx-prev.NetRx) / elapsed)
+			tx = uint64(float64(s.NetTx-prev.NetTx) / elapsed)
+		}
+		prev, prevAt = s, now
+
+		memPerc := fmt.Sprintf("%.2f%%", s.MemPerc)
+		if !s.Memory.Reported {
+			memPerc = "--"
+		}
+		fmt.Printf("%-8s %6.2f%% %-24s %7s %-22s %-22s %d\n",
+			now.Format("15:04:05"), s.CPUPerc, s.MemUsage, memPerc,
+			fmt.Sprintf("%s / %s", docker.FormatBytes(rx), docker.FormatBytes(tx)),
+			fmt.Sprintf("%s / %s", docker.FormatBytes(uint64(s.Block.ReadRate)), docker.FormatBytes(uint64(s.Block.WriteRate))),
+			s.PIDs)
+	})
+}
+
+// runLogs prints (and optionally follows) a container's logs, keeping stdout and stderr apart
+func runLogs(args []string) error {
+	fs := flag.NewFlagSet("logs", flag.ContinueOnError)
+	follow := fs.Bool("follow", false, "Follow log output")
+	followShort := fs.Bool("f", false, "Follow log output (shorthand)")
+	tail := fs.Int("tail", 100, "Number of lines to show from the end (0 for all)")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return errors.New("usage: dtop logs <container> [--follow] [--tail N]")
+	}
+
+	dockerClient, _, err := connect()
+	if err != nil {
+		return err
+	}
+	defer dockerClient.Close()
+
+	c, err := findContainer(dockerClient, positional[0])
+	if err != nil {
+		return err
+	}
+
+	opts := docker.LogOptions{
+		Tail:   *tail,
+		Follow: *follow || *followShort,
+	}
+	return dockerClient.StreamLogs(c.ID, opts, os.Stdout, os.Stderr)
+}
+
+// runRestart restarts a container or a project's running containers
+func runRestart(args []string) error {
+	return runLifecycle("restart", args, func(c *docker.Client, id string) error {
+		return c.RestartContainer(id)
+	}, func(c docker.ContainerInfo) bool {
+		return c.State == "running"
+	})
+}
+
+// runStop stops a container or a project's running containers
+func runStop(args []string) error {
+	return runLifecycle("stop", args, func(c *docker.Client, id string) error {
+		return c.StopContainer(id)
+	}, func(c docker.ContainerInfo) bool {
+		return c.State == "running"
+	})
+}
+
+// runStart starts a container or a project's stopped containers
+func runStart(args []string) error {
+	return runLifecycle("start", args, func(c *docker.Client, id string) error {
+		return c.StartContainer(id)
+	}, func(c docker.ContainerInfo) bool {
+		return c.State != "running"
+	})
+}
+
+// runLifecycle applies action to the named container, or to every container of the
+// named project that matches applies, printing each affected container. Failing for
+// only some of a project's containers exits with exitPartial.
+func runLifecycle(action string, args []string, apply func(*docker.Client, string) error, applies func(docker.ContainerInfo) bool) error {
+	fs := flag.NewFlagSet(action, flag.ContinueOnError)
+	quiet := addQuietFlag(fs)
+	args, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return fmt.Errorf("usage: dtop %s <container|project> [--quiet]", action)
+	}
+
+	dockerClient, cfg, err := connect()
+	if err != nil {
+		return err
+	}
+	defer dockerClient.Close()
+	if cfg.ReadOnly {
+		if cfg.Profile != "" {
+			return fmt.Errorf("cannot %s: profile %q is read-only", action, cfg.Profile)
+		}
+		return fmt.Errorf("cannot %s: read_only is set in the config", action)
+	}
+
+	containers, err := dockerClient.ListAllContainers()
+	if err != nil {
+		return fmt.Errorf("failed to list containers: %w", err)
+	}
+	log := audit.New(cfg.AuditLogFile)
+
+	targets, project := resolveTargets(containers, args[0], ui.TreeOptions(cfg))
+	if project == "" {
+		c, err := matchContainer(containers, args[0])
+		if err != nil {
+			return err
+		}
+		targets = []docker.ContainerInfo{*c}
+	} else {
+		log.Record(action+" all", "project "+project, nil)
+	}
+
+	var errs []error
+	done := 0
+	for _, c := range targets {
+		if project != "" && !applies(c) {
+			continue
+		}
+		err := apply(dockerClient, c.ID)
+		log.Record(action, c.Name, err)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", c.Name, err))
+			continue
+		}
+		done++
+		if *quiet {
+			fmt.Println(c.ID)
+		} else {
+			fmt.Println(c.Name)
+		}
+	}
+	if len(errs) > 0 && done > 0 {
+		return codedError{code: exitPartial, err: errors.Join(errs...)}
+	}
+	return errors.Join(errs...)
+}
+
+// resolveTargets returns the containers of the project called name, grouped the same
+// way as the TUI. An exact container name takes precedence over a project name.
+func resolveTargets(containers []docker.ContainerInfo, name string, opts model.TreeOptions) ([]docker.ContainerInfo, string) {
+	for _, c := range containers {
+		if c.Name == name || c.ID == name {
+			return nil, ""
+		}
+	}
+
+	targets := []docker.ContainerInfo{}
+	for i := range containers {
+		if model.ProjectName(&containers[i], opts) == name {
+			targets = append(targets, containers[i])
+		}
+	}
+	if len(targets) == 0 {
+		return nil, ""
+	}
+	return targets, name
+}
+
+// runComplete prints dynamic completion candidates for shell completion scripts
+func runComplete(args []string) {
+	if len(args) == 0 || (args[0] != "containers" && args[0] != "targets") {
+		return
+	}
+
+	dockerClient, cfg, err := connect()
+	if err != nil {
+		return
+	}
+	defer dockerClient.Close()
+
+	if args[0] == "targets" {
+		containers, err := dockerClient.ListAllContainers()
+		if err != nil {
+			return
+		}
+		fmt.Println(strings.Join(targetNames(containers, ui.TreeOptions(cfg)), "\n"))
+		return
+	}
+
+	containers, err := dockerClient.ListContainersWithStats(false)
+	if err != nil {
+		return
+	}
+	names := make([]string, 0, len(containers))
+	for _, c := range containers {
+		names = append(names, c.Name)
+	}
+	fmt.Println(strings.Join(names, "\n"))
+}
+
+// targetNames lists project names followed by container names
+func targetNames(containers []docker.ContainerInfo, opts model.TreeOptions) []string {
+	names := []string{}
+	seen := map[string]bool{}
+	for i := range containers {
+		project := model.ProjectName(&containers[i], opts)
+		if !model.CatchAll(project) && !seen[project] {
+			seen[project] = true
+			names = append(names, project)
+		}
+	}
+	for _, c := range containers {
+		names = append(names, c.Name)
+	}
+	return names
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// runCompletion prints a completion script for the given shell
+func runCompletion(args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: dtop completion <bash|zsh|fish>")
+	}
+
+	names := []string{}
+	containerCmds := []string{}
+	targetCmds := []string{}
+	for _, cmd := range commands() {
+		names = append(names, cmd.name)
+		switch {
+		case cmd.projects:
+			targetCmds = append(targetCmds, cmd.name)
+		case cmd.containers:
+			containerCmds = append(containerCmds, cmd.name)
+		}
+	}
+
+	switch args[0] {
+	case "bash":
+		fmt.Printf(bashCompletion, strings.Join(names, " "), strings.Join(containerCmds, "|"), strings.Join(targetCmds, "|"))
+	case "zsh":
+		fmt.Printf(zshCompletion, strings.Join(names, " "), strings.Join(containerCmds, "|"), strings.Join(targetCmds, "|"))
+	case "fish":
+		fmt.Print("complete -c dtop -f\n")
+		for _, cmd := range commands() {
+			fmt.Printf("complete -c dtop -n '__fish_use_subcommand' -a %s -d '%s'\n", cmd.name, cmd.description)
+		}
+		fmt.Printf(fishCompletion, strings.Join(containerCmds, " "), strings.Join(targetCmds, " "))
+	default:
+		return fmt.Errorf("unsupported shell %q (expected bash, zsh or fish)", args[0])
+	}
+	return nil
+}
+
+const bashCompletion = `# dtop bash completion
+# Load with: source <(dtop completion bash)
+_dtop() {
+  local cur="${COMP_WORDS[COMP_CWORD]}"
+  if [ "$COMP_CWORD" -eq 1 ]; then
+    COMPREPLY=( $(compgen -W "%s" -- "$cur") )
+    return
+  fi
+  case "${COMP_WORDS[1]}" in
+    %s)
+      local IFS=$'\n'
+      COMPREPLY=( $(compgen -W "$(dtop __complete containers 2>/dev/null)" -- "$cur") )
+      ;;
+    %s)
+      local IFS=$'\n'
+      COMPREPLY=( $(compgen -W "$(dtop __complete targets 2>/dev/null)" -- "$cur") )
+      ;;
+    completion)
+      COMPREPLY=( $(compgen -W "bash zsh fish" -- "$cur") )
+      ;;
+  esac
+}
+complete -F _dtop dtop
+`
+
+const zshCompletion = `#compdef dtop
+# dtop zsh completion
+# Load with: source <(dtop completion zsh)
+_dtop() {
+  if (( CURRENT == 2 )); then
+    compadd -- %s
+    return
+  fi
+  case "${words[2]}" in
+    %s)
+      compadd -- ${(f)"$(dtop __complete containers 2>/dev/null)"}
+      ;;
+    %s)
+      compadd -- ${(f)"$(dtop __complete targets 2>/dev/null)"}
+      ;;
+    completion)
+      compadd -- bash zsh fish
+      ;;
+  esac
+}
+compdef _dtop dtop
+`
+
+const fishCompletion = `complete -c dtop -n '__fish_seen_subcommand_from %s' -a '(dtop __complete containers 2>/dev/null)'
+complete -c dtop -n '__fish_seen_subcommand_from %s' -a '(dtop __complete targets 2>/dev/null)'
+complete -c dtop -n '__fish_seen_subcommand_from completion' -a 'bash zsh fish'
+`
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/ekinertac/dtop/update"
+)
+
+// runSelfUpdate replaces the dtop binary with the latest release, or with --check
+// only reports whether there is one
+func runSelfUpdate(args []string) error {
+	fs := flag.NewFlagSet("self-update", flag.ContinueOnError)
+	check := fs.Bool("check", false, "Only report whether a newer release exists")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return errors.New("usage: dtop self-update [--check]")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	release, err := update.Latest(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to look up the latest release: %w", err)
+	}
+	if !update.Newer(release.Version, Version) {
+		fmt.Printf("dtop v%s is up to date\n", Version)
+		return nil
+	}
+	if *check {
+		fmt.Printf("dtop v%s is available (this is v%s): %s\n", release.Version, Version, release.URL)
+		return nil
+	}
+
+	path, err := update.Install(ctx, release)
+	if err != nil {
+		return fmt.Errorf("failed to update: %w", err)
+	}
+	fmt.Printf("Updated %s from v%s to v%s\n", path, Version, release.Version)
+	return nil
+}
@@ -0,0 +1,514 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ekinertac/dtop/i18n"
+)
+
+// Config holds user settings loaded from the config file
+type Config struct {
+	// DockerHost is the daemon address; DOCKER_HOST or the platform default socket
+	// (npipe:////./pipe/docker_engine on Windows) is used when empty
+	DockerHost string `json:"docker_host"`
+
+	// TLS settings for tcp:// daemons; see docker.ClientOptions. TLSVerify implies TLS.
+	TLS       bool   `json:"tls"`
+	TLSVerify bool   `json:"tls_verify"`
+	TLSCACert string `json:"tls_ca_cert"`
+	TLSCert   string `json:"tls_cert"`
+	TLSKey    string `json:"tls_key"`
+
+	// StandaloneGroup puts containers that aren't part of a compose project under a
+	// single "(standalone)" node instead of one project per container
+	StandaloneGroup bool `json:"standalone_group"`
+
+	// GroupByLabel groups the tree by the value of this label (e.g. "com.example.team")
+	// instead of by compose project
+	GroupByLabel string `json:"group_by_label"`
+
+	// GroupBy is what the tree groups containers by at startup: project (the default),
+	// image, network, status or none; g in the UI cycles through them
+	GroupBy string `json:"group_by"`
+
+	// SortBy orders the containers within each group at startup: name (the default),
+	// cpu, memory or uptime; o in the UI cycles through them
+	SortBy string `json:"sort_by"`
+
+	// ProjectRules move containers whose name matches a pattern to another project,
+	// e.g. everything matching "^nginx-proxy" under "infra". The first matching rule
+	// wins over compose projects and GroupByLabel.
+	ProjectRules []ProjectRule `json:"project_rules"`
+
+	// StatsConcurrency limits how many stats requests are sent to the daemon at once
+	StatsConcurrency int `json:"stats_concurrency"`
+
+	// StatsStreams is how many running containers the monitor follows over long-lived
+	// stats streams instead of polling each refresh; 0 polls them all
+	StatsStreams int `json:"stats_streams"`
+
+	// UnfocusedInterval is how many seconds pass between refreshes while the terminal
+	// doesn't have focus; 0 keeps refreshing at the normal rate
+	UnfocusedInterval int `json:"unfocused_interval"`
+
+	// RollingDelay is how many seconds a rolling restart waits after restarting a
+	// container without a healthcheck before moving on to the next
+	RollingDelay int `json:"rolling_delay"`
+
+	// AuditLogFile, if set, receives a line for every action dtop performs
+	AuditLogFile string `json:"audit_log_file"`
+
+	// StateFile is where the monitor keeps its layout across restarts; empty means
+	// state.json in Dir. Not read from the config file.
+	StateFile string `json:"-"`
+
+	// LogColors renders ANSI colors in container logs; when false they are stripped
+	LogColors bool `json:"log_colors"`
+
+	// LogHighlights color the text matching a regular expression in every log view,
+	// e.g. ERROR in red and request IDs in cyan
+	LogHighlights []LogHighlight `json:"log_highlights"`
+
+	// LogTimestamps is what the logs view shows before each line at first: off (the
+	// default), time, or delta for the time and the gap since the line before
+	LogTimestamps string `json:"log_timestamps"`
+
+	// LogTimezone is the IANA time zone log times are shown in, e.g. "UTC" or
+	// "Europe/Berlin"; empty for the local zone
+	LogTimezone string `json:"log_timezone"`
+
+	// CrashBell rings the terminal bell when a container crashes or turns unhealthy
+	CrashBell bool `json:"crash_bell"`
+
+	// Hooks run a command or POST to a URL when dtop observes an event
+	Hooks []Hook `json:"hooks"`
+
+	// Filters limit the listed containers, in `docker ps --filter` syntax (e.g. "label=env=prod")
+	Filters []string `json:"filters"`
+
+	// Ignore hides containers whose name matches one of these glob patterns, e.g.
+	// "buildx_buildkit_*"; X in the UI reveals them
+	Ignore []string `json:"ignore"`
+
+	// Cleanup flags containers that have been exited for too long, for review and removal
+	Cleanup CleanupPolicy `json:"cleanup"`
+
+	// Thresholds are the usage percentages at which CPU/MEM cells turn yellow/red
+	Thresholds Thresholds `json:"thresholds"`
+
+	// Accent is the highlight color for titles, project names and menus, e.g. "#FF5555"
+	Accent string `json:"accent"`
+
+	// APIToken, when set, is required as "Authorization: Bearer <token>" by the HTTP
+	// API that dtop --api serves; without it, container actions need --api-insecure
+	APIToken string `json:"api_token"`
+
+	// Graphs is how history graphs are drawn: blocks (the default), braille, kitty,
+	// iterm2 or sixel, or auto to use a graphics protocol the terminal is known to
+	// support and braille otherwise
+	Graphs string `json:"graphs"`
+
+	// Language selects the language of help, menus and headers and how numbers and
+	// dates are written: en, de or es. Empty follows LANG.
+	Language string `json:"language"`
+
+	// Accessible renders for screen readers: a sentence per row instead of columns,
+	// no bars, graphs or borders, and words for what colors signal
+	Accessible bool `json:"accessible"`
+
+	// ReadOnly hides actions that change containers or images
+	ReadOnly bool `json:"read_only"`
+
+	// UpdateCheck lets the monitor ask GitHub, at most once a day, whether a newer
+	// dtop has been released, and note it in the status bar. Off by default.
+	UpdateCheck bool `json:"update_check"`
+
+	// Profile names the profile to use when --profile isn't given; after WithProfile
+	// it is the profile in effect
+	Profile string `json:"profile"`
+
+	// Profiles are named sets of connection and display settings, e.g. dev, staging and prod
+	Profiles map[string]Profile `json:"profiles"`
+
+	logLocation *time.Location
+}
+
+// Profile overrides the top-level settings when selected. Unset fields keep the
+// top-level value.
+type Profile struct {
+	DockerHost string   `json:"docker_host"`
+	TLS        *bool    `json:"tls"`
+	TLSVerify  *bool    `json:"tls_verify"`
+	TLSCACert  string   `json:"tls_ca_cert"`
+	TLSCert    string   `json:"tls_cert"`
+	TLSKey     string   `json:"tls_key"`
+	Filters    []string `json:"filters"`
+	Accent     string   `json:"accent"`
+	ReadOnly   *bool    `json:"read_only"`
+}
+
+// ProfileNames returns the configured profile names, sorted
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WithProfile returns a copy of the config with the named profile applied. An empty
+// name returns the config unchanged.
+func (c *Config) WithProfile(name string) (*Config, error) {
+	if name == "" {
+		return c, nil
+	}
+	p, ok := c.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown profile %q (configured: %s)", name, strings.Join(c.ProfileNames(), ", "))
+	}
+
+	cfg := *c
+	cfg.Profile = name
+	if p.DockerHost != "" {
+		cfg.DockerHost = p.DockerHost
+	}
+	if p.TLS != nil {
+		cfg.TLS = *p.TLS
+	}
+	if p.TLSVerify != nil {
+		cfg.TLSVerify = *p.TLSVerify
+	}
+	if p.TLSCACert != "" {
+		cfg.TLSCACert = p.TLSCACert
+	}
+	if p.TLSCert != "" {
+		cfg.TLSCert = p.TLSCert
+	}
+	if p.TLSKey != "" {
+		cfg.TLSKey = p.TLSKey
+	}
+	if p.Filters != nil {
+		cfg.Filters = p.Filters
+	}
+	if p.Accent != "" {
+		cfg.Accent = p.Accent
+	}
+	if p.ReadOnly != nil {
+		cfg.ReadOnly = *p.ReadOnly
+	}
+	return &cfg, nil
+}
+
+// ProjectRule shows containers whose name matches Pattern under Project
+type ProjectRule struct {
+	Pattern string `json:"pattern"` // Regular expression matched against the container name
+	Project string `json:"project"`
+
+	re *regexp.Regexp
+}
+
+// compile checks the rule and prepares its pattern
+func (r *ProjectRule) compile() error {
+	if r.Project == "" {
+		return errors.New("project must not be empty")
+	}
+	re, err := regexp.Compile(r.Pattern)
+	if err != nil {
+		return fmt.Errorf("invalid pattern %q: %w", r.Pattern, err)
+	}
+	r.re = re
+	return nil
+}
+
+// ProjectFor returns the project the first matching project rule assigns a
+// container to, or "" when no rule matches
+func (c *Config) ProjectFor(containerName string) string {
+	for _, rule := range c.ProjectRules {
+		if rule.re != nil && rule.re.MatchString(containerName) {
+			return rule.Project
+		}
+	}
+	return ""
+}
+
+// LogLocation returns the time zone to show log times in
+func (c *Config) LogLocation() *time.Location {
+	if c.logLocation == nil {
+		return time.Local
+	}
+	return c.logLocation
+}
+
+// LogHighlight colors the parts of log lines matching Pattern
+type LogHighlight struct {
+	Pattern string `json:"pattern"` // Regular expression
+	Color   string `json:"color"`   // A color name such as red or cyan, an ANSI number or "#RRGGBB"
+	Bold    bool   `json:"bold"`
+
+	re    *regexp.Regexp
+	color string
+}
+
+// logHighlightColors are the color names a highlight can use, as ANSI color numbers
+// so they follow the terminal's palette
+var logHighlightColors = map[string]string{
+	"black": "0", "red": "1", "green": "2", "yellow": "3",
+	"blue": "4", "magenta": "5", "cyan": "6", "white": "7", "gray": "8",
+	"bright-red": "9", "bright-green": "10", "bright-yellow": "11",
+	"bright-blue": "12", "bright-magenta": "13", "bright-cyan": "14", "bright-white": "15",
+}
+
+// hexColor matches "#RGB" and "#RRGGBB"
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// compile checks the highlight and prepares its pattern and color
+func (h *LogHighlight) compile() error {
+	if h.Pattern == "" {
+		return errors.New("pattern must not be empty")
+	}
+	re, err := regexp.Compile(h.Pattern)
+	if err != nil {
+		return fmt.Errorf("invalid pattern %q: %w", h.Pattern, err)
+	}
+	color, ok := logHighlightColors[strings.ToLower(h.Color)]
+	if !ok {
+		n, err := strconv.Atoi(h.Color)
+		switch {
+		case hexColor.MatchString(h.Color):
+			color = h.Color
+		case err == nil && n >= 0 && n <= 255:
+			color = h.Color
+		default:
+			return fmt.Errorf("invalid color %q: use a name such as red or cyan, 0-255 or #RRGGBB", h.Color)
+		}
+	}
+	h.re, h.color = re, color
+	return nil
+}
+
+// Regexp returns the compiled pattern; nil before the config is loaded
+func (h LogHighlight) Regexp() *regexp.Regexp {
+	return h.re
+}
+
+// TermColor returns the color as an ANSI number or hex code
+func (h LogHighlight) TermColor() string {
+	return h.color
+}
+
+// CleanupPolicy selects exited containers to remove. It is off while ExitedDays is 0.
+type CleanupPolicy struct {
+	ExitedDays int  `json:"exited_days"` // Flag containers exited longer than this many days
+	AutoRemove bool `json:"auto_remove"` // Remove flagged containers without review
+}
+
+// Thresholds are usage percentages for coloring the CPU and MEM columns. Omitted
+// keys keep their defaults.
+type Thresholds struct {
+	CPUWarn      float64 `json:"cpu_warn"`
+	CPUDanger    float64 `json:"cpu_danger"`
+	MemoryWarn   float64 `json:"memory_warn"`
+	MemoryDanger float64 `json:"memory_danger"`
+	Row          bool    `json:"row"` // Color the whole row instead of just the cells
+}
+
+// validate checks that each warn threshold is positive and below its danger threshold
+func (t Thresholds) validate() error {
+	if t.CPUWarn <= 0 || t.CPUDanger < t.CPUWarn {
+		return errors.New("cpu_warn must be positive and at most cpu_danger")
+	}
+	if t.MemoryWarn <= 0 || t.MemoryDanger < t.MemoryWarn {
+		return errors.New("memory_warn must be positive and at most memory_danger")
+	}
+	return nil
+}
+
+// Hook events
+const (
+	EventExited     = "exited"     // A running container exited with an error or was OOM-killed
+	EventRestarting = "restarting" // A running container started restarting
+	EventUnhealthy  = "unhealthy"  // A container's healthcheck started failing
+	EventCPU        = "cpu"        // CPU usage rose past the hook's threshold
+	EventMemory     = "memory"     // Memory usage rose past the hook's threshold
+)
+
+// DefaultHookThreshold is the cpu/memory threshold (percent) when a hook doesn't set one
+const DefaultHookThreshold = 90
+
+// Hook is an action to run when an event is observed. Exactly one target is set:
+// Command runs through the shell, URL receives the event as a JSON POST, and Slack
+// and Discord are incoming webhook URLs that get a formatted message.
+type Hook struct {
+	Event     string  `json:"event"`
+	Command   string  `json:"command,omitempty"`
+	URL       string  `json:"url,omitempty"`
+	Slack     string  `json:"slack,omitempty"`
+	Discord   string  `json:"discord,omitempty"`
+	Threshold float64 `json:"threshold,omitempty"` // Percent, for cpu and memory events
+}
+
+// Notifier reports whether the hook posts chat messages, which are also sent when
+// an alert resolves
+func (h Hook) Notifier() bool {
+	return h.Slack != "" || h.Discord != ""
+}
+
+// validate checks the hook's event and target
+func (h Hook) validate() error {
+	switch h.Event {
+	case EventExited, EventRestarting, EventUnhealthy, EventCPU, EventMemory:
+	default:
+		return fmt.Errorf("unknown event %q", h.Event)
+	}
+	targets := 0
+	for _, target := range []string{h.Command, h.URL, h.Slack, h.Discord} {
+		if target != "" {
+			targets++
+		}
+	}
+	if targets != 1 {
+		return fmt.Errorf("%s hook needs exactly one of command, url, slack or discord", h.Event)
+	}
+	return nil
+}
+
+// Default returns the settings used when no config file exists
+func Default() *Config {
+	return &Config{
+		StandaloneGroup:   true,
+		StatsConcurrency:  8,
+		StatsStreams:      100,
+		UnfocusedInterval: 30,
+		RollingDelay:      10,
+		LogColors:         true,
+		Thresholds: Thresholds{
+			CPUWarn:      60,
+			CPUDanger:    85,
+			MemoryWarn:   60,
+			MemoryDanger: 85,
+		},
+	}
+}
+
+// Dir returns the directory holding dtop's config and state files
+func Dir() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "dtop"), nil
+}
+
+// Path returns the location of the config file
+func Path() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.json"), nil
+}
+
+// Load reads the config file, falling back to defaults for missing keys or a missing file
+func Load() (*Config, error) {
+	cfg := Default()
+
+	path, err := Path()
+	if err != nil {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal over the defaults so omitted keys keep their default values
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for i, hook := range cfg.Hooks {
+		if err := hook.validate(); err != nil {
+			return nil, fmt.Errorf("%s: hooks[%d]: %w", path, i, err)
+		}
+	}
+	if cfg.StatsStreams < 0 {
+		return nil, fmt.Errorf("%s: stats_streams must not be negative", path)
+	}
+	if cfg.UnfocusedInterval < 0 {
+		return nil, fmt.Errorf("%s: unfocused_interval must not be negative", path)
+	}
+	if cfg.RollingDelay < 0 {
+		return nil, fmt.Errorf("%s: rolling_delay must not be negative", path)
+	}
+	if cfg.Cleanup.ExitedDays < 0 {
+		return nil, fmt.Errorf("%s: cleanup: exited_days must not be negative", path)
+	}
+	if cfg.Cleanup.AutoRemove && cfg.Cleanup.ExitedDays == 0 {
+		return nil, fmt.Errorf("%s: cleanup: auto_remove needs exited_days", path)
+	}
+	if err := cfg.Thresholds.validate(); err != nil {
+		return nil, fmt.Errorf("%s: thresholds: %w", path, err)
+	}
+	switch cfg.GroupBy {
+	case "", "project", "image", "network", "status", "none":
+	default:
+		return nil, fmt.Errorf("%s: group_by must be project, image, network, status or none", path)
+	}
+	switch cfg.SortBy {
+	case "", "name", "cpu", "memory", "uptime":
+	default:
+		return nil, fmt.Errorf("%s: sort_by must be name, cpu, memory or uptime", path)
+	}
+	if cfg.Language != "" && !slices.Contains(i18n.Supported(), cfg.Language) {
+		return nil, fmt.Errorf("%s: language must be one of %s", path, strings.Join(i18n.Supported(), ", "))
+	}
+	switch cfg.Graphs {
+	case "", "blocks", "braille", "kitty", "iterm2", "sixel", "auto":
+	default:
+		return nil, fmt.Errorf("%s: graphs must be blocks, braille, kitty, iterm2, sixel or auto", path)
+	}
+	for i := range cfg.ProjectRules {
+		if err := cfg.ProjectRules[i].compile(); err != nil {
+			return nil, fmt.Errorf("%s: project_rules[%d]: %w", path, i, err)
+		}
+	}
+	switch cfg.LogTimestamps {
+	case "", "off", "time", "delta":
+	default:
+		return nil, fmt.Errorf("%s: log_timestamps must be off, time or delta", path)
+	}
+	if cfg.LogTimezone != "" {
+		loc, err := time.LoadLocation(cfg.LogTimezone)
+		if err != nil {
+			return nil, fmt.Errorf("%s: log_timezone: %w", path, err)
+		}
+		cfg.logLocation = loc
+	}
+	for i := range cfg.LogHighlights {
+		if err := cfg.LogHighlights[i].compile(); err != nil {
+			return nil, fmt.Errorf("%s: log_highlights[%d]: %w", path, i, err)
+		}
+	}
+	for i, pattern := range cfg.Ignore {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("%s: ignore[%d]: invalid pattern %q", path, i, pattern)
+		}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// State is UI layout remembered across restarts
+type State struct {
+	Expanded  map[string]bool   `json:"expanded"`  // Project name -> expanded
+	Selected  string            `json:"selected"`  // Node path of the last selection
+	Favorites []string          `json:"favorites"` // Pinned containers and projects (model.FavoriteKey)
+	Hidden    []string          `json:"hidden"`    // Names of containers hidden with x
+	Notes     map[string]string `json:"notes"`     // model.FavoriteKey -> note written with e
+}
+
+// StatePath returns the location of the state file
+func StatePath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "state.json"), nil
+}
+
+// LoadState reads the state file at path (StatePath if empty), returning an empty
+// state if there is none
+func LoadState(path string) (*State, error) {
+	state := &State{Expanded: make(map[string]bool)}
+
+	if path == "" {
+		var err error
+		if path, err = StatePath(); err != nil {
+			return state, nil
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	if state.Expanded == nil {
+		state.Expanded = make(map[string]bool)
+	}
+
+	return state, nil
+}
+
+// SaveState writes the state file at path (StatePath if empty), creating its
+// directory if needed
+func SaveState(path string, state *State) error {
+	if path == "" {
+		var err error
+		if path, err = StatePath(); err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
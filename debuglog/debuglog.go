@@ -0,0 +1,54 @@
+package debuglog
+
+import (
+	"log/slog"
+	"os"
+	"sync/atomic"
+)
+
+// logger receives debug records; it discards them until Open is called, since the
+// TUI owns the terminal and nothing may be printed to it
+var logger atomic.Pointer[slog.Logger]
+
+// enabled is set once Open succeeds, so callers can skip building costly records
+var enabled atomic.Bool
+
+func init() {
+	logger.Store(slog.New(slog.DiscardHandler))
+}
+
+// Open appends JSON records for API calls, refresh timings and errors to the file at
+// path. The returned file is closed when the program exits.
+func Open(path string) (*os.File, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	logger.Store(slog.New(slog.NewJSONHandler(file, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			// "1.5ms" reads better than nanoseconds
+			if attr.Value.Kind() == slog.KindDuration {
+				attr.Value = slog.StringValue(attr.Value.Duration().String())
+			}
+			return attr
+		},
+	})))
+	enabled.Store(true)
+	return file, nil
+}
+
+// Enabled reports whether records are being written
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// Debug records an event with key/value attributes, e.g. a timing
+func Debug(msg string, args ...any) {
+	logger.Load().Debug(msg, args...)
+}
+
+// Error records a failure that the UI swallows or only shows briefly
+func Error(msg string, err error, args ...any) {
+	logger.Load().Error(msg, append([]any{"error", err}, args...)...)
+}
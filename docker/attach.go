@@ -0,0 +1,144 @@
+package docker
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// Detach sequence (ctrl+p ctrl+q), same as the docker CLI default
+const (
+	detachKey1 = 0x10
+	detachKey2 = 0x11
+)
+
+// AttachOptions describe the local terminal an attach runs in
+type AttachOptions struct {
+	// Width and Height of the terminal, given to containers started with a TTY; zero
+	// leaves their size alone
+	Width, Height int
+
+	// RawTerminal is set when output goes to a terminal in raw mode, which doesn't
+	// return the carriage on line feeds. A container's TTY already sends CRLF, so only
+	// output of containers without one gets it.
+	RawTerminal bool
+}
+
+// AttachContainer attaches to the container's main process until it exits or the
+// user types the detach sequence on stdin. Stdin is only forwarded when the
+// container was started with an open stdin.
+func (c *Client) AttachContainer(containerID string, opts AttachOptions, stdin io.Reader, stdout, stderr io.Writer) error {
+	inspect, err := c.cli.ContainerInspect(c.ctx, containerID)
+	if err != nil {
+		return err
+	}
+	tty := inspect.Config.Tty
+	openStdin := inspect.Config.OpenStdin
+	if !tty && opts.RawTerminal {
+		stdout, stderr = &crlfWriter{w: stdout}, &crlfWriter{w: stderr}
+	}
+
+	resp, err := c.cli.ContainerAttach(c.ctx, containerID, container.AttachOptions{
+		Stream: true,
+		Stdin:  openStdin,
+		Stdout: true,
+		Stderr: true,
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+
+	// The daemon refuses to resize containers without a TTY
+	if tty && opts.Width > 0 && opts.Height > 0 {
+		c.ResizeTTY(containerID, opts.Width, opts.Height)
+	}
+
+	// Both goroutines report here; buffered so neither leaks after we return
+	done := make(chan error, 2)
+
+	go func() {
+		var err error
+		if tty {
+			_, err = io.Copy(stdout, resp.Reader)
+		} else {
+			// Non-TTY output is multiplexed into stdout/stderr frames
+			_, err = stdcopy.StdCopy(stdout, stderr, resp.Reader)
+		}
+		done <- err
+	}()
+
+	go func() {
+		// The detach reader returns EOF on ctrl+p ctrl+q
+		input := &detachReader{r: stdin}
+		if openStdin {
+			io.Copy(resp.Conn, input)
+		} else {
+			io.Copy(io.Discard, input)
+		}
+		done <- nil
+	}()
+
+	return <-done
+}
+
+// ResizeTTY resizes the container's TTY to match the local terminal
+func (c *Client) ResizeTTY(containerID string, width, height int) error {
+	return c.cli.ContainerResize(c.ctx, containerID, container.ResizeOptions{
+		Width:  uint(width),
+		Height: uint(height),
+	})
+}
+
+// crlfWriter turns line feeds into CRLF for terminals in raw mode
+type crlfWriter struct {
+	w io.Writer
+}
+
+func (c *crlfWriter) Write(p []byte) (int, error) {
+	converted := bytes.ReplaceAll(p, []byte("\n"), []byte("\r\n"))
+	converted = bytes.ReplaceAll(converted, []byte("\r\r\n"), []byte("\r\n"))
+	if _, err := c.w.Write(converted); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// detachReader passes input through until it sees the detach sequence
+type detachReader struct {
+	r       io.Reader
+	pending bool // ctrl+p seen, waiting for the next byte
+}
+
+func (d *detachReader) Read(p []byte) (int, error) {
+	// Leave room for a held ctrl+p from the previous read
+	buf := make([]byte, len(p))
+	if d.pending && len(buf) > 1 {
+		buf = buf[:len(buf)-1]
+	}
+	n, err := d.r.Read(buf)
+
+	out := 0
+	for i := 0; i < n; i++ {
+		b := buf[i]
+		if d.pending {
+			d.pending = false
+			if b == detachKey2 {
+				return out, io.EOF
+			}
+			// Not a detach sequence, forward the held ctrl+p
+			p[out] = detachKey1
+			out++
+		}
+		if b == detachKey1 {
+			d.pending = true
+			continue
+		}
+		p[out] = b
+		out++
+	}
+
+	return out, err
+}
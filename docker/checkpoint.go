@@ -0,0 +1,51 @@
+package docker
+
+import (
+	"sort"
+
+	"github.com/docker/docker/api/types/checkpoint"
+	"github.com/docker/docker/api/types/container"
+)
+
+// Experimental reports whether the daemon has experimental features enabled, which
+// checkpoints need (along with CRIU installed on the host)
+func (c *Client) Experimental() (bool, error) {
+	ping, err := c.cli.Ping(c.ctx)
+	if err != nil {
+		return false, err
+	}
+	return ping.Experimental, nil
+}
+
+// CheckpointContainer saves a running container's state with CRIU under name. The
+// container stops unless leaveRunning is set.
+func (c *Client) CheckpointContainer(containerID, name string, leaveRunning bool) error {
+	return c.cli.CheckpointCreate(c.ctx, containerID, checkpoint.CreateOptions{
+		CheckpointID: name,
+		Exit:         !leaveRunning,
+	})
+}
+
+// ListCheckpoints returns the names of a container's checkpoints, sorted
+func (c *Client) ListCheckpoints(containerID string) ([]string, error) {
+	checkpoints, err := c.cli.CheckpointList(c.ctx, containerID, checkpoint.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(checkpoints))
+	for _, cp := range checkpoints {
+		names = append(names, cp.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// RestoreCheckpoint starts a stopped container from one of its checkpoints
+func (c *Client) RestoreCheckpoint(containerID, name string) error {
+	return c.cli.ContainerStart(c.ctx, containerID, container.StartOptions{CheckpointID: name})
+}
+
+// DeleteCheckpoint removes one of a container's checkpoints
+func (c *Client) DeleteCheckpoint(containerID, name string) error {
+	return c.cli.CheckpointDelete(c.ctx, containerID, checkpoint.DeleteOptions{CheckpointID: name})
+}
@@ -1,51 +1,226 @@
 package docker
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-connections/tlsconfig"
+	"github.com/ekinertac/dtop/debuglog"
 )
 
 type Client struct {
 	cli *client.Client
 	ctx context.Context
+
+	collectGPU atomic.Bool     // GPU stats need an exec per container, so they are opt-in
+	gpuMu      sync.Mutex      // Guards gpuCapable
+	gpuCapable map[string]bool // Container ID -> has GPU device requests (cached inspect)
+
+	blockMu   sync.Mutex               // Guards blockPrev
+	blockPrev map[string]blockIOSample // Container ID -> previous block I/O sample
+
+	hostMemOnce  sync.Once
+	hostMemTotal uint64
+
+	collectLogs atomic.Bool            // Log rates keep a stream open per container, so they are opt-in
+	logMu       sync.Mutex             // Guards logStreams
+	logStreams  map[string]*logCounter // Container ID -> log stream being counted
+
+	startedMu sync.Mutex           // Guards started
+	started   map[string]time.Time // Container ID -> StartedAt of running containers (cached inspect)
+
+	exitedMu   sync.Mutex          // Guards exitedInfo
+	exitedInfo map[string]exitInfo // Container ID -> how a stopped container stopped (cached inspect)
+
+	imagesMu sync.Mutex // Guards images
+	images   imageTags  // Which images still have a tag, for dangling detection
+
+	swarmMu sync.Mutex     // Guards swarm
+	swarm   swarmPlacement // Where swarm tasks run, when connected to a manager
+
+	stats *statsPool // Bounds and coalesces stats requests
+
+	streamMu     sync.Mutex              // Guards streamLimit and statsStreams
+	streamLimit  int                     // Most containers followed over stats streams
+	statsStreams map[string]*statsStream // Container ID -> stats stream being followed
+
+	filters filters.Args // Applied to every container list
 }
 
 type ContainerInfo struct {
-	ID        string
+	ID        string // Short ID, the first 12 characters of FullID
+	FullID    string
 	Name      string
 	Image     string
+	ImageID   string
 	State     string
 	Status    string
-	CPUPerc   float64
-	MemPerc   float64
-	MemUsage  string
-	NetRx     uint64 // Network bytes received
-	NetTx     uint64 // Network bytes transmitted
-	NetIO     string
-	BlockIO   string
 	CreatedAt time.Time
+	StartedAt time.Time // Last start of a running container; zero if unknown
 	Labels    map[string]string
+	Networks  []string // Names of the attached networks, sorted
+	Ports     []string // Published ports as "8080->80/tcp", then exposed ones as "80/tcp"
+
+	// Set for exited and dead containers only
+	ExitCode   int
+	FinishedAt time.Time
+
+	// The image lost its tags (re-tagged, pulled over or deleted) since the container was created
+	ImageDangling bool
+
+	// Log output per second; nil unless log rates are enabled and a full interval was measured
+	LogRate *LogRate
+
+	// Swarm node the container runs on; empty unless connected to a swarm manager.
+	// Remote containers run on another node: stats and actions aren't available.
+	Node   string
+	Remote bool
+
+	ContainerStats
+}
+
+// ContainerStats is a single resource usage sample for a container. It is embedded in
+// ContainerInfo so stats can be fetched separately and patched onto a listed container.
+type ContainerStats struct {
+	CPUPerc    float64
+	MemPerc    float64
+	MemUsage   string
+	NetRx      uint64 // Network bytes received
+	NetTx      uint64 // Network bytes transmitted
+	NetIO      string
+	Memory     MemoryBreakdown
+	PerCPUPerc []float64     // Per-core usage; empty on cgroup v2 which doesn't report it
+	Throttling CPUThrottling // CFS throttling counters since container start
+	PIDs       uint64        // Current number of processes/threads
+	PIDsLimit  uint64        // 0 when the container has no pids limit
+	BlockIO    string        // Cumulative "read / write", like docker stats
+	Block      BlockIOStats
+	GPU        *GPUStats // nil unless GPU stats are enabled and the container has GPUs
 }
 
 func NewClient(ctx context.Context) (*Client, error) {
-	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	return NewClientWithOptions(ctx, ClientOptions{})
+}
+
+// ClientOptions configures the daemon connection; zero values fall back to the
+// DOCKER_* environment variables and the platform default socket
+type ClientOptions struct {
+	// Host is the daemon address, e.g. unix:///var/run/docker.sock,
+	// npipe:////./pipe/docker_engine or tcp://host:2376
+	Host string
+
+	// TLS connects over TLS, verifying the daemon's certificate against CACert
+	// when TLSVerify is set. Empty paths default to ca.pem, cert.pem and key.pem in
+	// DOCKER_CERT_PATH or ~/.docker, when those files exist.
+	TLS       bool
+	TLSVerify bool
+	CACert    string
+	Cert      string
+	Key       string
+}
+
+// NewClientWithOptions connects to the daemon described by opts
+func NewClientWithOptions(ctx context.Context, opts ClientOptions) (*Client, error) {
+	clientOpts := []client.Opt{client.FromEnv, client.WithAPIVersionNegotiation(), withDebugLog()}
+	if opts.Host != "" {
+		clientOpts = append(clientOpts, client.WithHost(opts.Host))
+	}
+	if opts.TLS || opts.TLSVerify {
+		clientOpts = append(clientOpts, withTLS(opts))
+	}
+
+	cli, err := client.NewClientWithOpts(clientOpts...)
 	if err != nil {
 		return nil, err
 	}
 
 	return &Client{
-		cli: cli,
-		ctx: ctx,
+		cli:        cli,
+		ctx:        ctx,
+		gpuCapable: make(map[string]bool),
+		blockPrev:  make(map[string]blockIOSample),
+		started:    make(map[string]time.Time),
+		exitedInfo: make(map[string]exitInfo),
+		logStreams: make(map[string]*logCounter),
+		stats:      newStatsPool(DefaultStatsConcurrency),
+
+		statsStreams: make(map[string]*statsStream),
 	}, nil
 }
 
+// withTLS applies the TLS settings from opts to the client's transport
+func withTLS(opts ClientOptions) client.Opt {
+	return func(c *client.Client) error {
+		transport, ok := c.HTTPClient().Transport.(*http.Transport)
+		if !ok {
+			return fmt.Errorf("cannot apply TLS config to transport %T", c.HTTPClient().Transport)
+		}
+		config, err := tlsconfig.Client(tlsconfig.Options{
+			CAFile:             defaultCertFile(opts.CACert, "ca.pem"),
+			CertFile:           defaultCertFile(opts.Cert, "cert.pem"),
+			KeyFile:            defaultCertFile(opts.Key, "key.pem"),
+			InsecureSkipVerify: !opts.TLSVerify,
+			ExclusiveRootPools: true,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to load TLS certificates: %w", err)
+		}
+		transport.TLSClientConfig = config
+		return nil
+	}
+}
+
+// defaultCertFile returns path, or name in the docker cert directory if it exists
+func defaultCertFile(path, name string) string {
+	if path != "" {
+		return path
+	}
+	dir := os.Getenv(client.EnvOverrideCertPath)
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".docker")
+	}
+	file := filepath.Join(dir, name)
+	if _, err := os.Stat(file); err != nil {
+		return ""
+	}
+	return file
+}
+
+// SetFilters limits container lists to containers matching every filter, given in
+// `docker ps --filter` syntax (key=value, e.g. label=env=prod or name=api)
+func (c *Client) SetFilters(list []string) error {
+	args := filters.NewArgs()
+	for _, f := range list {
+		key, value, ok := strings.Cut(f, "=")
+		if !ok || key == "" {
+			return fmt.Errorf("invalid filter %q (expected key=value)", f)
+		}
+		args.Add(key, value)
+	}
+	c.filters = args
+	return nil
+}
+
 func (c *Client) Close() error {
 	return c.cli.Close()
 }
@@ -56,7 +231,16 @@ func (c *Client) ListContainers() ([]ContainerInfo, error) {
 
 func (c *Client) ListContainersWithStats(includeStats bool) ([]ContainerInfo, error) {
 	// Only list running containers (equivalent to `docker ps` without -a)
-	containers, err := c.cli.ContainerList(c.ctx, container.ListOptions{All: false})
+	return c.listContainers(false, includeStats)
+}
+
+// ListAllContainers lists running and stopped containers without stats
+func (c *Client) ListAllContainers() ([]ContainerInfo, error) {
+	return c.listContainers(true, false)
+}
+
+func (c *Client) listContainers(all, includeStats bool) ([]ContainerInfo, error) {
+	containers, err := c.cli.ContainerList(c.ctx, container.ListOptions{All: all, Filters: c.filters})
 	if err != nil {
 		return nil, err
 	}
@@ -64,145 +248,468 @@ func (c *Client) ListContainersWithStats(includeStats bool) ([]ContainerInfo, er
 	// Build initial result without stats
 	result := make([]ContainerInfo, len(containers))
 	type statsResult struct {
-		index    int
-		cpuPerc  float64
-		memPerc  float64
-		memUsage string
-		netRx    uint64
-		netTx    uint64
+		index int
+		stats ContainerStats
 	}
 	statsChan := make(chan statsResult, len(containers))
 
 	// Fetch stats in parallel for running containers
 	runningCount := 0
+	running := make(map[string]bool)
+	stopped := make(map[string]bool)
 	for i, ctr := range containers {
 		name := strings.TrimPrefix(ctr.Names[0], "/")
 
 		result[i] = ContainerInfo{
 			ID:        ctr.ID[:12],
+			FullID:    ctr.ID,
 			Name:      name,
 			Image:     ctr.Image,
+			ImageID:   ctr.ImageID,
 			State:     ctr.State,
 			Status:    ctr.Status,
-			CPUPerc:   0.0,
-			MemPerc:   0.0,
-			MemUsage:  "N/A",
-			NetRx:     0,
-			NetTx:     0,
 			CreatedAt: time.Unix(ctr.Created, 0),
 			Labels:    ctr.Labels,
+			ContainerStats: ContainerStats{
+				MemUsage: "N/A",
+			},
+		}
+		if ctr.NetworkSettings != nil {
+			for network := range ctr.NetworkSettings.Networks {
+				result[i].Networks = append(result[i].Networks, network)
+			}
+			sort.Strings(result[i].Networks)
+		}
+		result[i].Ports = containerPorts(ctr.Ports)
+
+		if ctr.State == "exited" || ctr.State == "dead" {
+			stopped[ctr.ID] = true
+			if info, ok := c.exited(ctr.ID, ctr.Status); ok {
+				result[i].ExitCode, result[i].FinishedAt = info.code, info.finishedAt
+			}
+		}
+
+		if ctr.State == "running" {
+			running[ctr.ID] = true
+			result[i].StartedAt = c.startedAt(ctr.ID, ctr.Status)
 		}
 
 		if ctr.State == "running" && includeStats {
 			runningCount++
 			go func(idx int, containerID string) {
-				stats := c.getContainerStats(containerID)
 				statsChan <- statsResult{
-					index:    idx,
-					cpuPerc:  stats.cpuPerc,
-					memPerc:  stats.memPerc,
-					memUsage: stats.memUsage,
-					netRx:    stats.netRx,
-					netTx:    stats.netTx,
+					index: idx,
+					stats: c.GetContainerStats(containerID),
 				}
 			}(i, ctr.ID)
 		}
 	}
 
+	c.forgetStarted(running)
+	c.forgetExited(running, stopped, all)
+	ids := withShortIDs(running)
+	c.forgetGPU(ids)
+	c.forgetBlockIO(ids)
+	c.followStats(running)
+
+	rates := c.logRates(running)
+	for i, ctr := range containers {
+		result[i].LogRate = rates[ctr.ID]
+	}
+
+	imageIDs := make([]string, len(result))
+	for i := range result {
+		imageIDs[i] = result[i].ImageID
+	}
+	dangling := c.danglingImages(imageIDs)
+	for i := range result {
+		result[i].ImageDangling = dangling[result[i].ImageID]
+	}
+
 	// Collect stats results (only if requested)
 	if includeStats {
 		for i := 0; i < runningCount; i++ {
-			stats := <-statsChan
-			result[stats.index].CPUPerc = stats.cpuPerc
-			result[stats.index].MemPerc = stats.memPerc
-			result[stats.index].MemUsage = stats.memUsage
-			result[stats.index].NetRx = stats.netRx
-			result[stats.index].NetTx = stats.netTx
+			r := <-statsChan
+			result[r.index].ContainerStats = r.stats
+		}
+	}
+
+	// On a swarm manager, show each container's node and the tasks running elsewhere
+	if placement := c.placement(); placement.manager {
+		for i := range result {
+			result[i].Node = placement.local
+		}
+		// Filters are applied by the local daemon and can't be matched against remote tasks
+		if c.filters.Len() == 0 {
+			result = append(result, placement.remote...)
 		}
 	}
 
 	return result, nil
 }
 
+// CPUThrottling holds CFS quota throttling counters
+type CPUThrottling struct {
+	Periods          uint64        // Enforcement periods elapsed
+	ThrottledPeriods uint64        // Periods in which the container was throttled
+	ThrottledTime    time.Duration // Total time spent throttled
+}
+
+// MemoryBreakdown splits container memory into its components
+type MemoryBreakdown struct {
+	Usage     uint64 // Usage minus page cache
+	Cache     uint64 // Page cache (file-backed)
+	RSS       uint64 // Anonymous memory
+	Swap      uint64 // Swap usage (cgroup v1 with swap accounting only)
+	Limit     uint64 // Effective limit; reported as host memory when unlimited, 0 if unknown
+	HostTotal uint64 // Total host memory, 0 if unknown
+	Reported  bool   // False when the daemon returned no memory stats (e.g. rootless without a delegated memory controller)
+}
+
+// Limited reports whether the container has a memory limit below host memory
+func (m MemoryBreakdown) Limited() bool {
+	return m.Limit > 0 && (m.HostTotal == 0 || m.Limit < m.HostTotal)
+}
+
+// unlimitedMemory is the threshold above which a limit is treated as "no limit";
+// cgroup v1 reports unlimited as PAGE_COUNTER_MAX (close to 2^63)
+const unlimitedMemory = 1 << 62
+
+// memoryBreakdown extracts cache/RSS/swap from the cgroup v1 or v2 counters. host is
+// total host memory (0 if unknown) and stands in for missing or meaningless limits.
+func memoryBreakdown(usage, limit, host uint64, stats map[string]uint64) MemoryBreakdown {
+	m := MemoryBreakdown{
+		Usage:     usage,
+		Limit:     limit,
+		HostTotal: host,
+		Reported:  usage > 0 || limit > 0 || len(stats) > 0,
+	}
+
+	if _, v1 := stats["total_inactive_file"]; v1 {
+		// total_* include child cgroups; older kernels may only have the plain keys
+		m.Cache = firstStat(stats, "total_cache", "cache")
+		m.RSS = firstStat(stats, "total_rss", "rss")
+		m.Swap = firstStat(stats, "total_swap", "swap")
+		if inactive := stats["total_inactive_file"]; inactive < usage {
+			m.Usage = usage - inactive
+		}
+	} else {
+		m.Cache = stats["file"]
+		m.RSS = stats["anon"]
+		if inactive := stats["inactive_file"]; inactive < usage {
+			m.Usage = usage - inactive
+		}
+	}
+
+	// Unlimited containers report 0 (rootless, some cgroup v2 setups), a sentinel near
+	// 2^63 (cgroup v1) or a value above host memory; the real ceiling is the host
+	if m.Limit == 0 || m.Limit >= unlimitedMemory || (host > 0 && m.Limit > host) {
+		m.Limit = host
+	}
+
+	return m
+}
+
+// firstStat returns the first of keys present in stats
+func firstStat(stats map[string]uint64, keys ...string) uint64 {
+	for _, key := range keys {
+		if v, ok := stats[key]; ok {
+			return v
+		}
+	}
+	return 0
+}
+
+// hostMemory returns total host memory from the daemon, queried once
+func (c *Client) hostMemory() uint64 {
+	c.hostMemOnce.Do(func() {
+		if info, err := c.cli.Info(c.ctx); err == nil && info.MemTotal > 0 {
+			c.hostMemTotal = uint64(info.MemTotal)
+		}
+	})
+	return c.hostMemTotal
+}
+
+// BlockIOStats holds cumulative block device bytes and per-second rates
+type BlockIOStats struct {
+	Read      uint64
+	Write     uint64
+	ReadRate  float64 // Bytes per second since the previous sample
+	WriteRate float64
+}
+
+// blockIOSample is the previous block I/O reading used for rate calculation
+type blockIOSample struct {
+	read  uint64
+	write uint64
+	at    time.Time
+}
+
+// forgetBlockIO drops the last block I/O samples of containers that are no longer running
+func (c *Client) forgetBlockIO(running map[string]bool) {
+	c.blockMu.Lock()
+	defer c.blockMu.Unlock()
+	for id := range c.blockPrev {
+		if !running[id] {
+			delete(c.blockPrev, id)
+		}
+	}
+}
+
+// blockIORates fills in read/write rates from the previous sample and records the current one
+func (c *Client) blockIORates(containerID string, at time.Time, stats *BlockIOStats) {
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	c.blockMu.Lock()
+	prev, ok := c.blockPrev[containerID]
+	c.blockPrev[containerID] = blockIOSample{read: stats.Read, write: stats.Write, at: at}
+	c.blockMu.Unlock()
+
+	elapsed := at.Sub(prev.at).Seconds()
+	if !ok || elapsed <= 0 {
+		return
+	}
+	// Counters reset when a container restarts
+	if stats.Read >= prev.read {
+		stats.ReadRate = float64(stats.Read-prev.read) / elapsed
+	}
+	if stats.Write >= prev.write {
+		stats.WriteRate = float64(stats.Write-prev.write) / elapsed
+	}
+}
+
+// containerPorts formats the ports of a container list entry; a port published on
+// both IPv4 and IPv6 is listed once
+func containerPorts(ports []container.Port) []string {
+	seen := map[string]bool{}
+	result := []string{}
+	for _, p := range ports {
+		port := fmt.Sprintf("%d/%s", p.PrivatePort, p.Type)
+		if p.PublicPort != 0 {
+			port = fmt.Sprintf("%d->%s", p.PublicPort, port)
+		}
+		if !seen[port] {
+			seen[port] = true
+			result = append(result, port)
+		}
+	}
+	// Published ports first
+	sort.Slice(result, func(i, j int) bool {
+		pi, pj := strings.Contains(result[i], "->"), strings.Contains(result[j], "->")
+		if pi != pj {
+			return pi
+		}
+		return result[i] < result[j]
+	})
+	return result
+}
+
 // Stats structures for parsing Docker stats JSON
 type statsResponse struct {
+	Read     time.Time `json:"read"`
+	PreRead  time.Time `json:"preread"`
+	NumProcs uint32    `json:"num_procs"` // Windows only: number of processors
 	CPUStats struct {
 		CPUUsage struct {
-			TotalUsage uint64 `json:"total_usage"`
+			TotalUsage  uint64   `json:"total_usage"`
+			PercpuUsage []uint64 `json:"percpu_usage"` // cgroup v1 only
 		} `json:"cpu_usage"`
-		SystemUsage uint64 `json:"system_cpu_usage"`
-		OnlineCPUs  uint32 `json:"online_cpus"`
+		SystemUsage    uint64 `json:"system_cpu_usage"`
+		OnlineCPUs     uint32 `json:"online_cpus"`
+		ThrottlingData struct {
+			Periods          uint64 `json:"periods"`
+			ThrottledPeriods uint64 `json:"throttled_periods"`
+			ThrottledTime    uint64 `json:"throttled_time"` // Nanoseconds
+		} `json:"throttling_data"`
 	} `json:"cpu_stats"`
 	PreCPUStats struct {
 		CPUUsage struct {
-			TotalUsage uint64 `json:"total_usage"`
+			TotalUsage  uint64   `json:"total_usage"`
+			PercpuUsage []uint64 `json:"percpu_usage"`
 		} `json:"cpu_usage"`
 		SystemUsage uint64 `json:"system_cpu_usage"`
 	} `json:"precpu_stats"`
 	MemoryStats struct {
-		Usage uint64 `json:"usage"`
-		Limit uint64 `json:"limit"`
+		Usage uint64            `json:"usage"`
+		Limit uint64            `json:"limit"`
+		Stats map[string]uint64 `json:"stats"` // Raw cgroup counters; keys differ between v1 and v2
+
+		// Windows only
+		PrivateWorkingSet uint64 `json:"privateworkingset"`
 	} `json:"memory_stats"`
+	StorageStats struct {
+		// Windows only; Linux reports block I/O in blkio_stats
+		ReadSizeBytes  uint64 `json:"read_size_bytes"`
+		WriteSizeBytes uint64 `json:"write_size_bytes"`
+	} `json:"storage_stats"`
+	PidsStats struct {
+		Current uint64 `json:"current"`
+		Limit   uint64 `json:"limit"`
+	} `json:"pids_stats"`
+	BlkioStats struct {
+		IoServiceBytesRecursive []struct {
+			Op    string `json:"op"` // "Read"/"Write" on cgroup v1, lowercase on v2
+			Value uint64 `json:"value"`
+		} `json:"io_service_bytes_recursive"`
+	} `json:"blkio_stats"`
 	Networks map[string]struct {
 		RxBytes uint64 `json:"rx_bytes"`
 		TxBytes uint64 `json:"tx_bytes"`
 	} `json:"networks"`
 }
 
-type statsData struct {
-	cpuPerc  float64
-	memPerc  float64
-	memUsage string
-	netRx    uint64
-	netTx    uint64
+// GetContainerStats returns the latest sample of a container followed over a stats
+// stream, or fetches a single sample. Requests are limited by the stats concurrency
+// and coalesced per container.
+func (c *Client) GetContainerStats(containerID string) ContainerStats {
+	stats, ok := c.streamedStats(containerID)
+	if !ok {
+		return c.stats.do(containerID, func() ContainerStats {
+			return c.fetchContainerStats(containerID)
+		})
+	}
+	if c.collectGPU.Load() {
+		stats.GPU = c.getGPUStats(containerID)
+	}
+	return stats
 }
 
-func (c *Client) getContainerStats(containerID string) statsData {
+func (c *Client) fetchContainerStats(containerID string) ContainerStats {
 	// Get a single stats snapshot (stream=false)
 	stats, err := c.cli.ContainerStats(c.ctx, containerID, false)
 	if err != nil {
-		return statsData{0.0, 0.0, "N/A", 0, 0}
+		return ContainerStats{MemUsage: "N/A"}
 	}
 	defer stats.Body.Close()
 
 	// Decode the stats
 	var v statsResponse
 	if err := json.NewDecoder(stats.Body).Decode(&v); err != nil && err != io.EOF {
-		return statsData{0.0, 0.0, "N/A", 0, 0}
+		debuglog.Error("stats", err, "container", containerID)
+		return ContainerStats{MemUsage: "N/A"}
+	}
+	result := c.convertStats(containerID, &v)
+	if c.collectGPU.Load() {
+		result.GPU = c.getGPUStats(containerID)
+	}
+	return result
+}
+
+// StreamContainerStats calls handle with every sample of the daemon's stats stream
+// for one container, about one a second, until the stream ends
+func (c *Client) StreamContainerStats(containerID string, handle func(ContainerStats)) error {
+	stats, err := c.cli.ContainerStats(c.ctx, containerID, true)
+	if err != nil {
+		return err
+	}
+	defer stats.Body.Close()
+
+	decoder := json.NewDecoder(stats.Body)
+	for {
+		var v statsResponse
+		if err := decoder.Decode(&v); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		sample := c.convertStats(containerID, &v)
+		if c.collectGPU.Load() {
+			sample.GPU = c.getGPUStats(containerID)
+		}
+		handle(sample)
+	}
+}
+
+// convertStats turns a daemon stats sample into usage figures, without GPU stats
+func (c *Client) convertStats(containerID string, v *statsResponse) ContainerStats {
+	// Windows containers report processors, CPU time, memory and disk differently
+	if v.NumProcs > 0 {
+		return c.windowsContainerStats(containerID, v)
 	}
 
-	result := statsData{}
+	result := ContainerStats{}
 
 	// Calculate CPU percentage
 	cpuDelta := float64(v.CPUStats.CPUUsage.TotalUsage - v.PreCPUStats.CPUUsage.TotalUsage)
 	systemDelta := float64(v.CPUStats.SystemUsage - v.PreCPUStats.SystemUsage)
 	onlineCPUs := float64(v.CPUStats.OnlineCPUs)
-	
+
 	if systemDelta > 0.0 && cpuDelta > 0.0 {
-		result.cpuPerc = (cpuDelta / systemDelta) * onlineCPUs * 100.0
+		result.CPUPerc = (cpuDelta / systemDelta) * onlineCPUs * 100.0
+	}
+
+	// Per-core usage relative to one core's share of the system delta
+	percpu := v.CPUStats.CPUUsage.PercpuUsage
+	prePercpu := v.PreCPUStats.CPUUsage.PercpuUsage
+	if systemDelta > 0.0 && onlineCPUs > 0 && len(percpu) == len(prePercpu) {
+		coreDelta := systemDelta / onlineCPUs
+		for i := range percpu {
+			perc := 0.0
+			if percpu[i] > prePercpu[i] {
+				perc = float64(percpu[i]-prePercpu[i]) / coreDelta * 100.0
+			}
+			result.PerCPUPerc = append(result.PerCPUPerc, perc)
+		}
 	}
 
+	result.Throttling = CPUThrottling{
+		Periods:          v.CPUStats.ThrottlingData.Periods,
+		ThrottledPeriods: v.CPUStats.ThrottlingData.ThrottledPeriods,
+		ThrottledTime:    time.Duration(v.CPUStats.ThrottlingData.ThrottledTime),
+	}
+
+	// Memory usage excludes reclaimable page cache, like `docker stats`
+	result.Memory = memoryBreakdown(v.MemoryStats.Usage, v.MemoryStats.Limit, c.hostMemory(), v.MemoryStats.Stats)
+
 	// Calculate memory percentage
-	if v.MemoryStats.Limit > 0 {
-		result.memPerc = (float64(v.MemoryStats.Usage) / float64(v.MemoryStats.Limit)) * 100.0
+	if result.Memory.Limit > 0 {
+		result.MemPerc = (float64(result.Memory.Usage) / float64(result.Memory.Limit)) * 100.0
 	}
 
 	// Format memory usage
-	result.memUsage = formatBytes(v.MemoryStats.Usage) + " / " + formatBytes(v.MemoryStats.Limit)
+	switch {
+	case !result.Memory.Reported:
+		result.MemUsage = "N/A"
+	case result.Memory.Limit == 0:
+		result.MemUsage = FormatBytes(result.Memory.Usage) + " / unlimited"
+	default:
+		result.MemUsage = FormatBytes(result.Memory.Usage) + " / " + FormatBytes(result.Memory.Limit)
+	}
 
 	// Calculate network totals across all interfaces
 	for _, net := range v.Networks {
-		result.netRx += net.RxBytes
-		result.netTx += net.TxBytes
+		result.NetRx += net.RxBytes
+		result.NetTx += net.TxBytes
+	}
+
+	// Process count; unlimited pids are reported as 0 or max uint64 depending on cgroup version
+	result.PIDs = v.PidsStats.Current
+	if v.PidsStats.Limit > 0 && v.PidsStats.Limit < math.MaxUint32 {
+		result.PIDsLimit = v.PidsStats.Limit
 	}
 
+	// Block I/O totals, with rates against the previous sample
+	for _, entry := range v.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(entry.Op) {
+		case "read":
+			result.Block.Read += entry.Value
+		case "write":
+			result.Block.Write += entry.Value
+		}
+	}
+	c.blockIORates(containerID, v.Read, &result.Block)
+	result.BlockIO = FormatBytes(result.Block.Read) + " / " + FormatBytes(result.Block.Write)
+
 	return result
 }
 
-func formatBytes(bytes uint64) string {
+// FormatBytes formats a byte count with binary units, e.g. "1.5 MiB"
+func FormatBytes(bytes uint64) string {
 	const unit = 1024
 	if bytes < unit {
-		return "0 B"
+		return fmt.Sprintf("%d B", bytes)
 	}
 	div, exp := uint64(unit), 0
 	for n := bytes / unit; n >= unit; n /= unit {
@@ -218,8 +725,17 @@ func (c *Client) RestartContainer(containerID string) error {
 }
 
 func (c *Client) StopContainer(containerID string) error {
-	timeout := 10
-	return c.cli.ContainerStop(c.ctx, containerID, container.StopOptions{Timeout: &timeout})
+	return c.StopContainerTimeout(containerID, DefaultStopTimeout)
+}
+
+// DefaultStopTimeout is how long StopContainer waits after SIGTERM before killing
+const DefaultStopTimeout = 10 * time.Second
+
+// StopContainerTimeout stops a container, giving it timeout to shut down after
+// SIGTERM before it is killed
+func (c *Client) StopContainerTimeout(containerID string, timeout time.Duration) error {
+	seconds := int(timeout / time.Second)
+	return c.cli.ContainerStop(c.ctx, containerID, container.StopOptions{Timeout: &seconds})
 }
 
 func (c *Client) StartContainer(containerID string) error {
@@ -234,21 +750,124 @@ func (c *Client) RemoveContainer(containerID string) error {
 }
 
 func (c *Client) GetContainerLogs(containerID string, tail int) (string, error) {
+	var buf bytes.Buffer
+	err := c.StreamLogs(containerID, LogOptions{Tail: tail}, &buf, &buf)
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// LogOptions controls which logs StreamLogs returns
+type LogOptions struct {
+	Tail       int       // Number of lines from the end; 0 or less for all
+	Follow     bool      // Keep streaming new output until the context is canceled
+	Since      time.Time // Only output from this time on; zero for all
+	Timestamps bool      // Prefix each line with its RFC 3339 timestamp and a space
+}
+
+// StreamLogs writes container logs to stdout/stderr, demultiplexing the stream
+// for containers without a TTY
+func (c *Client) StreamLogs(containerID string, opts LogOptions, stdout, stderr io.Writer) error {
+	return c.StreamLogsContext(c.ctx, containerID, opts, stdout, stderr)
+}
+
+// StreamLogsContext is StreamLogs ending when ctx is canceled, e.g. when the client
+// following the logs goes away
+func (c *Client) StreamLogsContext(ctx context.Context, containerID string, opts LogOptions, stdout, stderr io.Writer) error {
+	inspect, err := c.cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return err
+	}
+
+	tail := "all"
+	if opts.Tail > 0 {
+		tail = fmt.Sprintf("%d", opts.Tail)
+	}
 	options := container.LogsOptions{
 		ShowStdout: true,
 		ShowStderr: true,
-		Tail:       fmt.Sprintf("%d", tail),
+		Follow:     opts.Follow,
+		Tail:       tail,
+		Timestamps: opts.Timestamps,
+	}
+	if !opts.Since.IsZero() {
+		options.Since = opts.Since.Format(time.RFC3339Nano)
 	}
 
-	logs, err := c.cli.ContainerLogs(c.ctx, containerID, options)
+	logs, err := c.cli.ContainerLogs(ctx, containerID, options)
 	if err != nil {
-		return "", err
+		return err
 	}
 	defer logs.Close()
 
-	// Read all logs
-	buf := make([]byte, 1024*1024) // 1MB buffer
-	n, _ := logs.Read(buf)
+	// TTY containers produce a raw stream; others are multiplexed with frame headers
+	if inspect.Config.Tty {
+		_, err = io.Copy(stdout, logs)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, logs)
+	}
+	return err
+}
+
+// ExportContainer writes a tarball of the container's filesystem to w
+func (c *Client) ExportContainer(containerID string, w io.Writer) error {
+	reader, err := c.cli.ContainerExport(c.ctx, containerID)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	_, err = io.Copy(w, reader)
+	return err
+}
+
+// SaveImage writes a tarball of the image (like `docker save`) to w
+func (c *Client) SaveImage(imageRef string, w io.Writer) error {
+	reader, err := c.cli.ImageSave(c.ctx, []string{imageRef})
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	_, err = io.Copy(w, reader)
+	return err
+}
+
+// ExitState describes how a container stopped
+type ExitState struct {
+	Running   bool
+	ExitCode  int
+	OOMKilled bool
+}
+
+// Crashed reports whether the container stopped on its own rather than being stopped:
+// killed for running out of memory, or exited with an error other than the
+// SIGTERM/SIGKILL codes `docker stop` produces
+func (s ExitState) Crashed() bool {
+	if s.Running {
+		return false
+	}
+	return s.OOMKilled || (s.ExitCode != 0 && s.ExitCode != 137 && s.ExitCode != 143)
+}
+
+// GetExitState inspects how a container stopped
+func (c *Client) GetExitState(containerID string) (ExitState, error) {
+	inspect, err := c.cli.ContainerInspect(c.ctx, containerID)
+	if err != nil {
+		return ExitState{}, err
+	}
+	if inspect.State == nil {
+		return ExitState{}, nil
+	}
+	return ExitState{
+		Running:   inspect.State.Running,
+		ExitCode:  inspect.State.ExitCode,
+		OOMKilled: inspect.State.OOMKilled,
+	}, nil
+}
 
-	return string(buf[:n]), nil
+// SignalContainer sends a signal (e.g. "SIGHUP") to the container's main process
+func (c *Client) SignalContainer(containerID, signal string) error {
+	return c.cli.ContainerKill(c.ctx, containerID, signal)
 }
@@ -0,0 +1,32 @@
+package docker
+
+import (
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// Labels docker compose sets on the containers it creates. model re-exports them
+// for the tree, which groups by them.
+const (
+	ComposeProjectLabel = "com.docker.compose.project"
+	ComposeServiceLabel = "com.docker.compose.service"
+)
+
+// ComposeServices returns the services of a compose project that have a container,
+// running or not
+func (c *Client) ComposeServices(project string) (map[string]bool, error) {
+	containers, err := c.cli.ContainerList(c.ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", ComposeProjectLabel+"="+project)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	services := make(map[string]bool, len(containers))
+	for _, ctr := range containers {
+		if service := ctr.Labels[ComposeServiceLabel]; service != "" {
+			services[service] = true
+		}
+	}
+	return services, nil
+}
@@ -0,0 +1,143 @@
+package docker
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// connectionScript prints the socket tables of the container's network namespace,
+// each preceded by its protocol
+const connectionScript = `for f in tcp tcp6 udp udp6; do echo "@$f"; cat /proc/net/$f; done 2>/dev/null`
+
+// tcpStates names the socket states of /proc/net/tcp (include/net/tcp_states.h)
+var tcpStates = map[string]string{
+	"01": "ESTABLISHED",
+	"02": "SYN_SENT",
+	"03": "SYN_RECV",
+	"04": "FIN_WAIT1",
+	"05": "FIN_WAIT2",
+	"06": "TIME_WAIT",
+	"07": "CLOSE",
+	"08": "CLOSE_WAIT",
+	"09": "LAST_ACK",
+	"0A": "LISTEN",
+	"0B": "CLOSING",
+}
+
+// Connection is a socket in a container's network namespace
+type Connection struct {
+	Proto   string // tcp, tcp6, udp or udp6
+	Local   string // host:port
+	Remote  string // host:port; *:* for listening and unconnected sockets
+	State   string // TCP state like ESTABLISHED or LISTEN; UNCONN for unconnected UDP sockets
+	TxQueue uint64
+	RxQueue uint64
+}
+
+// Listening reports whether the socket waits for connections or datagrams rather
+// than being connected to a peer
+func (c Connection) Listening() bool {
+	return c.State == "LISTEN" || c.State == "UNCONN"
+}
+
+// ListConnections reads the TCP and UDP sockets of a running Linux container by
+// exec'ing a shell inside it, so minimal images don't need netstat or ss
+func (c *Client) ListConnections(containerID string) ([]Connection, error) {
+	output, exitCode, err := c.Exec(containerID, []string{"/bin/sh", "-c", connectionScript})
+	if err != nil {
+		return nil, err
+	}
+	if exitCode != 0 && !strings.Contains(output, "sl") {
+		return nil, fmt.Errorf("reading /proc/net needs /bin/sh in the container (exit code %d: %s)", exitCode, strings.TrimSpace(output))
+	}
+	return parseConnections(output), nil
+}
+
+// parseConnections parses the output of connectionScript
+func parseConnections(output string) []Connection {
+	var connections []Connection
+	proto := ""
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, "@") {
+			proto = line[1:]
+			continue
+		}
+		fields := strings.Fields(line)
+		// Skip the header and anything that isn't a socket entry ("0: ...")
+		if len(fields) < 5 || !strings.HasSuffix(fields[0], ":") || proto == "" {
+			continue
+		}
+
+		local, ok := parseSocketAddress(fields[1])
+		if !ok {
+			continue
+		}
+		remote, ok := parseSocketAddress(fields[2])
+		if !ok {
+			continue
+		}
+
+		state := tcpStates[fields[3]]
+		if strings.HasPrefix(proto, "udp") {
+			// UDP reuses the TCP codes: 01 for connected sockets, 07 for the rest
+			state = "UNCONN"
+			if fields[3] == "01" {
+				state = "ESTABLISHED"
+			}
+		}
+		if state == "" {
+			state = fields[3]
+		}
+		if state == "LISTEN" || state == "UNCONN" {
+			remote = "*:*"
+		}
+
+		tx, rx, _ := strings.Cut(fields[4], ":")
+		txQueue, _ := strconv.ParseUint(tx, 16, 64)
+		rxQueue, _ := strconv.ParseUint(rx, 16, 64)
+
+		connections = append(connections, Connection{
+			Proto:   proto,
+			Local:   local,
+			Remote:  remote,
+			State:   state,
+			TxQueue: txQueue,
+			RxQueue: rxQueue,
+		})
+	}
+	return connections
+}
+
+// parseSocketAddress decodes an address like 0100007F:1F90. The address is stored
+// as 32-bit words in host byte order (little-endian on the architectures Docker
+// runs on), the port in network byte order.
+func parseSocketAddress(s string) (string, bool) {
+	addr, portHex, ok := strings.Cut(s, ":")
+	if !ok {
+		return "", false
+	}
+	raw, err := hex.DecodeString(addr)
+	if err != nil || (len(raw) != net.IPv4len && len(raw) != net.IPv6len) {
+		return "", false
+	}
+	port, err := strconv.ParseUint(portHex, 16, 16)
+	if err != nil {
+		return "", false
+	}
+
+	ip := make(net.IP, len(raw))
+	for i := 0; i < len(raw); i += 4 {
+		ip[i], ip[i+1], ip[i+2], ip[i+3] = raw[i+3], raw[i+2], raw[i+1], raw[i]
+	}
+	host := ip.String()
+	if ip.IsUnspecified() {
+		host = "*"
+	}
+	if port == 0 {
+		return host + ":*", true
+	}
+	return net.JoinHostPort(host, strconv.FormatUint(port, 10)), true
+}
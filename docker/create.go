@@ -0,0 +1,67 @@
+package docker
+
+import (
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+)
+
+// CreateOptions describes an ad-hoc container, roughly what `docker run -d` accepts
+type CreateOptions struct {
+	Image         string
+	Name          string
+	Ports         []string // host:container port specs, e.g. "8080:80"
+	Env           []string // KEY=value pairs
+	Volumes       []string // host:container[:ro] bind mounts
+	RestartPolicy string   // no, on-failure, unless-stopped, always
+}
+
+// CreateContainer pulls the image if it is missing, then creates and starts the container.
+// It returns the short ID of the new container. progress, if not nil, receives pull updates.
+func (c *Client) CreateContainer(opts CreateOptions, progress func(PullProgress)) (string, error) {
+	if err := c.ensureImage(opts.Image, progress); err != nil {
+		return "", err
+	}
+
+	exposedPorts, portBindings, err := nat.ParsePortSpecs(opts.Ports)
+	if err != nil {
+		return "", err
+	}
+
+	config := &container.Config{
+		Image:        opts.Image,
+		Env:          opts.Env,
+		ExposedPorts: exposedPorts,
+	}
+	hostConfig := &container.HostConfig{
+		Binds:        opts.Volumes,
+		PortBindings: portBindings,
+		RestartPolicy: container.RestartPolicy{
+			Name: container.RestartPolicyMode(opts.RestartPolicy),
+		},
+	}
+
+	resp, err := c.cli.ContainerCreate(c.ctx, config, hostConfig, nil, nil, opts.Name)
+	if err != nil {
+		return "", err
+	}
+
+	if err := c.cli.ContainerStart(c.ctx, resp.ID, container.StartOptions{}); err != nil {
+		return "", err
+	}
+
+	return resp.ID[:12], nil
+}
+
+// ensureImage pulls the image only when it is not available locally (like `docker run`)
+func (c *Client) ensureImage(ref string, progress func(PullProgress)) error {
+	_, err := c.cli.ImageInspect(c.ctx, ref)
+	if err == nil {
+		return nil
+	}
+	if !cerrdefs.IsNotFound(err) {
+		return err
+	}
+
+	return c.PullImage(ref, progress)
+}
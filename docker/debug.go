@@ -0,0 +1,83 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/client"
+	"github.com/ekinertac/dtop/debuglog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// withDebugLog records the duration and outcome of every daemon API call in the debug
+// log, when it is open. The Docker client traces each request, from sending it until
+// its response body is closed, so spans are what gets timed.
+func withDebugLog() client.Opt {
+	if !debuglog.Enabled() {
+		return func(*client.Client) error { return nil }
+	}
+	return client.WithTraceProvider(debugTracerProvider{})
+}
+
+type debugTracerProvider struct {
+	noop.TracerProvider
+}
+
+func (debugTracerProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return debugTracer{}
+}
+
+type debugTracer struct {
+	noop.Tracer
+}
+
+func (debugTracer) Start(ctx context.Context, name string, _ ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return ctx, &debugSpan{name: name, start: time.Now()}
+}
+
+// debugSpan is an API call, named "GET /containers/json" by the Docker client
+type debugSpan struct {
+	noop.Span
+	name   string
+	start  time.Time
+	status int64
+	err    string
+}
+
+func (s *debugSpan) IsRecording() bool {
+	return true
+}
+
+func (s *debugSpan) SetAttributes(attrs ...attribute.KeyValue) {
+	for _, attr := range attrs {
+		// http.status_code or http.response.status_code, depending on the semconv version
+		if strings.HasSuffix(string(attr.Key), "status_code") {
+			s.status = attr.Value.AsInt64()
+		}
+	}
+}
+
+func (s *debugSpan) SetStatus(code codes.Code, description string) {
+	if code == codes.Error {
+		s.err = description
+	}
+}
+
+func (s *debugSpan) End(...trace.SpanEndOption) {
+	duration := time.Since(s.start)
+	if s.err == "" && s.status >= 500 {
+		// The daemon failed; client errors like 404s for removed containers are routine
+		s.err = http.StatusText(int(s.status))
+	}
+	if s.err != "" {
+		debuglog.Error("api", errors.New(s.err), "call", s.name, "status", s.status, "duration", duration)
+		return
+	}
+	debuglog.Debug("api", "call", s.name, "status", s.status, "duration", duration)
+}
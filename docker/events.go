@@ -0,0 +1,66 @@
+package docker
+
+import (
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// Container event actions kept for the timeline
+const (
+	EventStart  = "start"
+	EventStop   = "stop"
+	EventDie    = "die"
+	EventOOM    = "oom"
+	EventHealth = "health_status"
+)
+
+// ContainerEvent is a lifecycle event the daemon reported for a container
+type ContainerEvent struct {
+	Time        time.Time `json:"time"`
+	ContainerID string    `json:"container_id"` // Short ID, as in ContainerInfo
+	Name        string    `json:"name"`
+	Action      string    `json:"action"`           // One of the Event* actions
+	Detail      string    `json:"detail,omitempty"` // Exit code for die, status for health_status
+}
+
+// WatchEvents calls handle with every start, stop, die, oom and health status event
+// until the stream fails or the client's context ends
+func (c *Client) WatchEvents(handle func(ContainerEvent)) error {
+	msgs, errs := c.cli.Events(c.ctx, events.ListOptions{
+		Filters: filters.NewArgs(
+			filters.Arg("type", string(events.ContainerEventType)),
+			filters.Arg("event", EventStart),
+			filters.Arg("event", EventStop),
+			filters.Arg("event", EventDie),
+			filters.Arg("event", EventOOM),
+			filters.Arg("event", EventHealth), // Matches "health_status: healthy" and the like
+		),
+	})
+	for {
+		select {
+		case msg := <-msgs:
+			id := msg.Actor.ID
+			if len(id) > 12 {
+				id = id[:12]
+			}
+			e := ContainerEvent{
+				Time:        time.Unix(0, msg.TimeNano),
+				ContainerID: id,
+				Name:        msg.Actor.Attributes["name"],
+				Action:      string(msg.Action),
+			}
+			switch {
+			case e.Action == EventDie:
+				e.Detail = msg.Actor.Attributes["exitCode"]
+			case strings.HasPrefix(e.Action, EventHealth+":"):
+				e.Action, e.Detail = EventHealth, strings.TrimSpace(strings.TrimPrefix(e.Action, EventHealth+":"))
+			}
+			handle(e)
+		case err := <-errs:
+			return err
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package docker
+
+import (
+	"bytes"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// Exec runs cmd inside the container and returns its combined output and exit code
+func (c *Client) Exec(containerID string, cmd []string) (string, int, error) {
+	exec, err := c.cli.ContainerExecCreate(c.ctx, containerID, container.ExecOptions{
+		Cmd:          cmd,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return "", 0, err
+	}
+
+	resp, err := c.cli.ContainerExecAttach(c.ctx, exec.ID, container.ExecAttachOptions{})
+	if err != nil {
+		return "", 0, err
+	}
+	defer resp.Close()
+
+	var output bytes.Buffer
+	if _, err := stdcopy.StdCopy(&output, &output, resp.Reader); err != nil {
+		return "", 0, err
+	}
+
+	inspect, err := c.cli.ContainerExecInspect(c.ctx, exec.ID)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return output.String(), inspect.ExitCode, nil
+}
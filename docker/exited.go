@@ -0,0 +1,57 @@
+package docker
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// exitInfo is what inspecting a stopped container tells about how it stopped
+type exitInfo struct {
+	code       int
+	finishedAt time.Time
+}
+
+// statusExitPattern matches the exit code docker puts in a stopped container's status,
+// e.g. "Exited (137) 5 minutes ago"
+var statusExitPattern = regexp.MustCompile(`^Exited \((-?\d+)\)`)
+
+// exited returns a stopped container's exit code and when it stopped. The list API
+// doesn't report when, so containers are inspected once and the result is cached
+// until they're seen running again or the status shows another exit code.
+func (c *Client) exited(containerID, status string) (exitInfo, bool) {
+	c.exitedMu.Lock()
+	info, ok := c.exitedInfo[containerID]
+	c.exitedMu.Unlock()
+
+	if ok {
+		match := statusExitPattern.FindStringSubmatch(status)
+		if match == nil || match[1] == strconv.Itoa(info.code) {
+			return info, true
+		}
+	}
+
+	inspect, err := c.cli.ContainerInspect(c.ctx, containerID)
+	if err != nil || inspect.State == nil {
+		return exitInfo{}, false
+	}
+	info.code = inspect.State.ExitCode
+	info.finishedAt, _ = time.Parse(time.RFC3339Nano, inspect.State.FinishedAt)
+
+	c.exitedMu.Lock()
+	c.exitedInfo[containerID] = info
+	c.exitedMu.Unlock()
+	return info, true
+}
+
+// forgetExited drops cached exit info of containers that are running again and, when
+// every container was listed, of those that are gone
+func (c *Client) forgetExited(running, stopped map[string]bool, all bool) {
+	c.exitedMu.Lock()
+	defer c.exitedMu.Unlock()
+	for id := range c.exitedInfo {
+		if running[id] || (all && !stopped[id]) {
+			delete(c.exitedInfo, id)
+		}
+	}
+}
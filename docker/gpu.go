@@ -0,0 +1,113 @@
+package docker
+
+import (
+	"strconv"
+	"strings"
+)
+
+// GPUStats aggregates NVIDIA GPU usage across all GPUs visible to a container
+type GPUStats struct {
+	UtilPerc float64 // Average utilization across GPUs
+	MemUsed  uint64  // Bytes
+	MemTotal uint64  // Bytes
+}
+
+// SetGPUStats enables or disables GPU stats collection via nvidia-smi
+func (c *Client) SetGPUStats(enabled bool) {
+	c.collectGPU.Store(enabled)
+}
+
+// hasGPU reports whether the container requested GPU devices; the inspect result is cached
+func (c *Client) hasGPU(containerID string) bool {
+	c.gpuMu.Lock()
+	capable, known := c.gpuCapable[containerID]
+	c.gpuMu.Unlock()
+	if known {
+		return capable
+	}
+
+	inspect, err := c.cli.ContainerInspect(c.ctx, containerID)
+	if err != nil {
+		return false
+	}
+
+	capable = inspect.HostConfig.Runtime == "nvidia"
+	for _, req := range inspect.HostConfig.DeviceRequests {
+		if req.Driver == "nvidia" {
+			capable = true
+		}
+		for _, caps := range req.Capabilities {
+			for _, cap := range caps {
+				if cap == "gpu" {
+					capable = true
+				}
+			}
+		}
+	}
+
+	c.gpuMu.Lock()
+	c.gpuCapable[containerID] = capable
+	c.gpuMu.Unlock()
+
+	return capable
+}
+
+// forgetGPU drops the cached GPU capability of containers that are no longer running
+func (c *Client) forgetGPU(running map[string]bool) {
+	c.gpuMu.Lock()
+	defer c.gpuMu.Unlock()
+	for id := range c.gpuCapable {
+		if !running[id] {
+			delete(c.gpuCapable, id)
+		}
+	}
+}
+
+// getGPUStats queries nvidia-smi inside the container, returning nil when unavailable
+func (c *Client) getGPUStats(containerID string) *GPUStats {
+	if !c.hasGPU(containerID) {
+		return nil
+	}
+
+	output, exitCode, err := c.Exec(containerID, []string{
+		"nvidia-smi",
+		"--query-gpu=utilization.gpu,memory.used,memory.total",
+		"--format=csv,noheader,nounits",
+	})
+	if err != nil || exitCode != 0 {
+		return nil
+	}
+
+	return parseNvidiaSmi(output)
+}
+
+// parseNvidiaSmi parses "util, used MiB, total MiB" lines, one per GPU
+func parseNvidiaSmi(output string) *GPUStats {
+	stats := &GPUStats{}
+	gpus := 0
+
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := strings.Split(line, ",")
+		if len(fields) != 3 {
+			continue
+		}
+		util, err1 := strconv.ParseFloat(strings.TrimSpace(fields[0]), 64)
+		used, err2 := strconv.ParseUint(strings.TrimSpace(fields[1]), 10, 64)
+		total, err3 := strconv.ParseUint(strings.TrimSpace(fields[2]), 10, 64)
+		if err1 != nil || err2 != nil || err3 != nil {
+			continue
+		}
+
+		stats.UtilPerc += util
+		stats.MemUsed += used * 1024 * 1024
+		stats.MemTotal += total * 1024 * 1024
+		gpus++
+	}
+
+	if gpus == 0 {
+		return nil
+	}
+	stats.UtilPerc /= float64(gpus)
+
+	return stats
+}
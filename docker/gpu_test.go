@@ -0,0 +1,26 @@
+package docker
+
+import "testing"
+
+func TestParseNvidiaSmi(t *testing.T) {
+	const mib = 1024 * 1024
+	tests := []struct {
+		name   string
+		output string
+		want   *GPUStats
+	}{
+		{"one GPU", "45, 1024, 8192\n", &GPUStats{UtilPerc: 45, MemUsed: 1024 * mib, MemTotal: 8192 * mib}},
+		{"two GPUs average utilization and sum memory", "20, 100, 1000\n60, 300, 1000\n", &GPUStats{UtilPerc: 40, MemUsed: 400 * mib, MemTotal: 2000 * mib}},
+		{"bad lines skipped", "[N/A], 100, 1000\n50, 200, 1000\nNo devices were found", &GPUStats{UtilPerc: 50, MemUsed: 200 * mib, MemTotal: 1000 * mib}},
+		{"no GPUs", "No devices were found\n", nil},
+		{"empty", "", nil},
+	}
+	for _, tt := range tests {
+		got := parseNvidiaSmi(tt.output)
+		switch {
+		case got == nil && tt.want == nil:
+		case got == nil || tt.want == nil || *got != *tt.want:
+			t.Errorf("%s: parseNvidiaSmi(%q) = %+v, want %+v", tt.name, tt.output, got, tt.want)
+		}
+	}
+}
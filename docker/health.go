@@ -0,0 +1,64 @@
+package docker
+
+import (
+	"errors"
+	"time"
+)
+
+// HealthcheckResult is the outcome of running a container's healthcheck by hand
+type HealthcheckResult struct {
+	Command  []string
+	Output   string
+	ExitCode int // 0 healthy, anything else unhealthy
+	Duration time.Duration
+}
+
+// RunHealthcheck executes the container's configured healthcheck test now, instead of
+// waiting for the next scheduled check. The result isn't recorded in the container's
+// health status.
+func (c *Client) RunHealthcheck(containerID string) (*HealthcheckResult, error) {
+	inspect, err := c.cli.ContainerInspect(c.ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+	if inspect.Config == nil || inspect.Config.Healthcheck == nil || len(inspect.Config.Healthcheck.Test) == 0 {
+		return nil, errors.New("container has no healthcheck")
+	}
+
+	// Test is ["CMD", args...], ["CMD-SHELL", command] or ["NONE"]
+	test := inspect.Config.Healthcheck.Test
+	var cmd []string
+	switch test[0] {
+	case "CMD":
+		cmd = test[1:]
+	case "CMD-SHELL":
+		if len(test) < 2 {
+			return nil, errors.New("healthcheck has an empty command")
+		}
+		if inspect.Platform == "windows" {
+			cmd = []string{"cmd", "/S", "/C", test[1]}
+		} else {
+			cmd = []string{"/bin/sh", "-c", test[1]}
+		}
+	case "NONE":
+		return nil, errors.New("healthcheck is disabled")
+	default:
+		return nil, errors.New("unsupported healthcheck test " + test[0])
+	}
+	if len(cmd) == 0 {
+		return nil, errors.New("healthcheck has an empty command")
+	}
+
+	start := time.Now()
+	output, exitCode, err := c.Exec(containerID, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HealthcheckResult{
+		Command:  cmd,
+		Output:   output,
+		ExitCode: exitCode,
+		Duration: time.Since(start),
+	}, nil
+}
@@ -0,0 +1,75 @@
+package docker
+
+import (
+	"strings"
+)
+
+// HostInfo summarizes the daemon and the host it runs on
+type HostInfo struct {
+	Name              string
+	ServerVersion     string
+	APIVersion        string
+	OperatingSystem   string
+	OSType            string
+	KernelVersion     string
+	Architecture      string
+	NCPU              int
+	MemTotal          uint64
+	StorageDriver     string
+	LoggingDriver     string
+	CgroupDriver      string
+	CgroupVersion     string
+	DockerRootDir     string
+	Rootless          bool
+	Experimental      bool // Experimental features such as checkpoints are enabled
+	Images            int
+	Containers        int
+	ContainersRunning int
+	ContainersPaused  int
+	ContainersStopped int
+	SwarmState        string // inactive, pending, active, error or locked
+	SwarmManager      bool
+	Warnings          []string
+}
+
+// HostInfo queries the daemon's system info
+func (c *Client) HostInfo() (*HostInfo, error) {
+	info, err := c.cli.Info(c.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rootless := false
+	for _, opt := range info.SecurityOptions {
+		if strings.Contains(opt, "name=rootless") {
+			rootless = true
+		}
+	}
+
+	return &HostInfo{
+		Name:              info.Name,
+		ServerVersion:     info.ServerVersion,
+		APIVersion:        c.cli.ClientVersion(),
+		OperatingSystem:   info.OperatingSystem,
+		OSType:            info.OSType,
+		KernelVersion:     info.KernelVersion,
+		Architecture:      info.Architecture,
+		NCPU:              info.NCPU,
+		MemTotal:          uint64(info.MemTotal),
+		StorageDriver:     info.Driver,
+		LoggingDriver:     info.LoggingDriver,
+		CgroupDriver:      info.CgroupDriver,
+		CgroupVersion:     info.CgroupVersion,
+		DockerRootDir:     info.DockerRootDir,
+		Rootless:          rootless,
+		Experimental:      info.ExperimentalBuild,
+		Images:            info.Images,
+		Containers:        info.Containers,
+		ContainersRunning: info.ContainersRunning,
+		ContainersPaused:  info.ContainersPaused,
+		ContainersStopped: info.ContainersStopped,
+		SwarmState:        string(info.Swarm.LocalNodeState),
+		SwarmManager:      info.Swarm.ControlAvailable,
+		Warnings:          info.Warnings,
+	}, nil
+}
@@ -0,0 +1,97 @@
+package docker
+
+import (
+	"strings"
+	"time"
+
+	"github.com/distribution/reference"
+	"github.com/docker/docker/api/types/image"
+)
+
+// imageTagsTTL is how long the list of tagged images is reused between container lists
+const imageTagsTTL = 30 * time.Second
+
+// imageTags caches which image IDs still have a tag
+type imageTags struct {
+	tagged  map[string]bool // Image ID -> has at least one tag
+	fetched time.Time
+}
+
+// danglingImages reports, for each image ID, whether it has lost all its tags (it was
+// re-tagged, pulled over or deleted while containers still use it). The image list
+// is cached and only refetched when stale or when an unknown image shows up.
+func (c *Client) danglingImages(imageIDs []string) map[string]bool {
+	c.imagesMu.Lock()
+	defer c.imagesMu.Unlock()
+
+	stale := time.Since(c.images.fetched) > imageTagsTTL
+	for _, id := range imageIDs {
+		if _, known := c.images.tagged[id]; !known {
+			stale = true
+		}
+	}
+	if stale {
+		images, err := c.cli.ImageList(c.ctx, image.ListOptions{All: true})
+		if err != nil {
+			// Unknown rather than wrong: report nothing as dangling
+			return map[string]bool{}
+		}
+		c.images.tagged = make(map[string]bool, len(images))
+		for _, img := range images {
+			tagged := false
+			for _, tag := range img.RepoTags {
+				if tag != "<none>:<none>" {
+					tagged = true
+				}
+			}
+			c.images.tagged[img.ID] = tagged
+		}
+		c.images.fetched = time.Now()
+	}
+
+	dangling := make(map[string]bool)
+	for _, id := range imageIDs {
+		// Deleted images aren't listed at all
+		if !c.images.tagged[id] {
+			dangling[id] = true
+		}
+	}
+	return dangling
+}
+
+// ImageByDigest reports whether a container was created from a digest or an image ID
+// rather than a tag, so it doesn't follow tag updates
+func (c ContainerInfo) ImageByDigest() bool {
+	return strings.Contains(c.Image, "@sha256:") || strings.HasPrefix(c.Image, "sha256:")
+}
+
+// ShortImage shortens an image reference to name:tag for display, dropping the
+// registry and namespace: "ghcr.io/org/app:1.2" -> "app:1.2". Digests and image IDs
+// are cut to 12 characters.
+func ShortImage(ref string) string {
+	if strings.HasPrefix(ref, "sha256:") {
+		return shortDigest(strings.TrimPrefix(ref, "sha256:"))
+	}
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		// Likely an image ID the daemon shows for images that lost their tag
+		return shortDigest(ref)
+	}
+
+	path := reference.Path(named)
+	short := path[strings.LastIndex(path, "/")+1:]
+	if tagged, ok := named.(reference.Tagged); ok {
+		short += ":" + tagged.Tag()
+	}
+	if digested, ok := named.(reference.Digested); ok {
+		short += "@" + shortDigest(digested.Digest().Encoded())
+	}
+	return short
+}
+
+func shortDigest(digest string) string {
+	if len(digest) > 12 {
+		return digest[:12]
+	}
+	return digest
+}
@@ -0,0 +1,69 @@
+package docker
+
+import (
+	"github.com/docker/docker/api/types/container"
+)
+
+// ResourceLimits are a container's memory and CPU limits; zero means unlimited
+type ResourceLimits struct {
+	Memory     int64   // Bytes
+	MemorySwap int64   // Memory plus swap in bytes; -1 for unlimited swap
+	CPUs       float64 // Cores, from --cpus or --cpu-quota/--cpu-period
+	CPUPeriod  int64   // CFS period in microseconds when the CPU limit is quota based, else 0
+
+	// MemoryReservation is the soft limit (--memory-reservation) in bytes the
+	// container is held to when the host runs short of memory
+	MemoryReservation int64
+}
+
+// GetResourceLimits inspects a container's memory and CPU limits
+func (c *Client) GetResourceLimits(containerID string) (ResourceLimits, error) {
+	inspect, err := c.cli.ContainerInspect(c.ctx, containerID)
+	if err != nil {
+		return ResourceLimits{}, err
+	}
+	if inspect.HostConfig == nil {
+		return ResourceLimits{}, nil
+	}
+
+	r := inspect.HostConfig.Resources
+	limits := ResourceLimits{Memory: r.Memory, MemorySwap: r.MemorySwap, MemoryReservation: r.MemoryReservation}
+	switch {
+	case r.NanoCPUs > 0:
+		limits.CPUs = float64(r.NanoCPUs) / 1e9
+	case r.CPUQuota > 0:
+		period := r.CPUPeriod
+		if period == 0 {
+			period = 100000 // CFS default
+		}
+		limits.CPUs = float64(r.CPUQuota) / float64(period)
+		limits.CPUPeriod = period
+	}
+	return limits, nil
+}
+
+// SetResourceLimits changes a container's memory (bytes) and CPU (cores) limits in
+// place; a zero value leaves that limit unchanged. current is used to keep the swap
+// limit valid when raising memory above it.
+func (c *Client) SetResourceLimits(containerID string, current ResourceLimits, memory int64, cpus float64) error {
+	var resources container.Resources
+	if memory > 0 {
+		resources.Memory = memory
+		if current.MemorySwap > 0 && current.MemorySwap < memory {
+			// The daemon rejects a memory limit above the swap limit; keep the
+			// `docker run --memory` default of as much swap as memory
+			resources.MemorySwap = 2 * memory
+		}
+	}
+	if cpus > 0 {
+		if current.CPUPeriod > 0 {
+			// Nano CPUs and a CFS quota can't both be set, so stay with the quota
+			resources.CPUPeriod = current.CPUPeriod
+			resources.CPUQuota = int64(cpus * float64(current.CPUPeriod))
+		} else {
+			resources.NanoCPUs = int64(cpus * 1e9)
+		}
+	}
+	_, err := c.cli.ContainerUpdate(c.ctx, containerID, container.UpdateConfig{Resources: resources})
+	return err
+}
@@ -0,0 +1,129 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// minLogRateInterval is the shortest interval a log rate is computed over; lists in
+// quick succession (e.g. after an action) keep the previous rate
+const minLogRateInterval = time.Second
+
+// LogRate is how much log output a container produced per second over the last interval
+type LogRate struct {
+	LinesPerSec float64
+	BytesPerSec float64
+}
+
+// logCounter follows a container's log stream and counts what goes by
+type logCounter struct {
+	lines  atomic.Uint64
+	bytes  atomic.Uint64
+	cancel context.CancelFunc
+
+	// Guarded by Client.logMu
+	prevLines uint64
+	prevBytes uint64
+	prevAt    time.Time
+	rate      *LogRate // nil until the first full interval
+}
+
+// Write counts bytes and lines of log output
+func (l *logCounter) Write(p []byte) (int, error) {
+	l.bytes.Add(uint64(len(p)))
+	l.lines.Add(uint64(bytes.Count(p, []byte{'\n'})))
+	return len(p), nil
+}
+
+// SetLogRates enables or disables log rate tracking. It keeps a log stream open per
+// running container, so it is opt-in; disabling closes the streams.
+func (c *Client) SetLogRates(enabled bool) {
+	c.collectLogs.Store(enabled)
+	if enabled {
+		return
+	}
+	c.logMu.Lock()
+	defer c.logMu.Unlock()
+	for id, counter := range c.logStreams {
+		counter.cancel()
+		delete(c.logStreams, id)
+	}
+}
+
+// logRates follows the logs of newly running containers, stops following those that
+// stopped, and returns the current rate of each followed container
+func (c *Client) logRates(running map[string]bool) map[string]*LogRate {
+	rates := make(map[string]*LogRate)
+	if !c.collectLogs.Load() {
+		return rates
+	}
+
+	c.logMu.Lock()
+	defer c.logMu.Unlock()
+
+	now := time.Now()
+	for id := range running {
+		counter, ok := c.logStreams[id]
+		if !ok {
+			c.logStreams[id] = c.followLogs(id, now)
+			continue
+		}
+
+		elapsed := now.Sub(counter.prevAt)
+		if elapsed >= minLogRateInterval {
+			lines, written := counter.lines.Load(), counter.bytes.Load()
+			counter.rate = &LogRate{
+				LinesPerSec: float64(lines-counter.prevLines) / elapsed.Seconds(),
+				BytesPerSec: float64(written-counter.prevBytes) / elapsed.Seconds(),
+			}
+			counter.prevLines, counter.prevBytes, counter.prevAt = lines, written, now
+		}
+		rates[id] = counter.rate
+	}
+
+	for id, counter := range c.logStreams {
+		if !running[id] {
+			counter.cancel()
+			delete(c.logStreams, id)
+		}
+	}
+	return rates
+}
+
+// followLogs starts counting a container's new log output in the background
+func (c *Client) followLogs(containerID string, since time.Time) *logCounter {
+	ctx, cancel := context.WithCancel(c.ctx)
+	counter := &logCounter{cancel: cancel, prevAt: since}
+
+	go func() {
+		inspect, err := c.cli.ContainerInspect(ctx, containerID)
+		if err != nil {
+			return
+		}
+		logs, err := c.cli.ContainerLogs(ctx, containerID, container.LogsOptions{
+			ShowStdout: true,
+			ShowStderr: true,
+			Follow:     true,
+			Since:      strconv.FormatInt(since.Unix(), 10),
+		})
+		if err != nil {
+			return
+		}
+		defer logs.Close()
+
+		// TTY containers produce a raw stream; others are multiplexed with frame headers
+		if inspect.Config.Tty {
+			_, _ = io.Copy(counter, logs)
+		} else {
+			_, _ = stdcopy.StdCopy(counter, counter, logs)
+		}
+	}()
+	return counter
+}
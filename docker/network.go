@@ -0,0 +1,106 @@
+package docker
+
+import (
+	"sort"
+)
+
+// EmbeddedDNSServer is the address of the daemon's DNS server on user-defined networks
+const EmbeddedDNSServer = "127.0.0.11"
+
+// ContainerNetwork is a container's endpoint on one network
+type ContainerNetwork struct {
+	Name        string
+	IPAddress   string
+	PrefixLen   int
+	Gateway     string
+	IPv6Address string
+	IPv6Prefix  int
+	IPv6Gateway string
+	MacAddress  string
+	Aliases     []string
+	DNSNames    []string // Names other containers on the network can resolve this one by
+}
+
+// NetworkInfo is a container's network attachments and DNS configuration
+type NetworkInfo struct {
+	Mode       string // Network mode, e.g. bridge, host or a network name
+	Hostname   string
+	Domainname string
+	Networks   []ContainerNetwork // Sorted by name
+	DNS        []string           // Custom nameservers (--dns); empty uses the daemon's or host's
+	DNSSearch  []string
+	DNSOptions []string
+	ExtraHosts []string
+}
+
+// EmbeddedDNS reports whether the container resolves names through the daemon's
+// embedded DNS server, which is the case on user-defined networks
+func (n *NetworkInfo) EmbeddedDNS() bool {
+	for _, network := range n.Networks {
+		switch network.Name {
+		case "bridge", "host", "none":
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// GetNetworkInfo inspects a container's networks and DNS settings
+func (c *Client) GetNetworkInfo(containerID string) (*NetworkInfo, error) {
+	inspect, err := c.cli.ContainerInspect(c.ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &NetworkInfo{}
+	if inspect.Config != nil {
+		info.Hostname = inspect.Config.Hostname
+		info.Domainname = inspect.Config.Domainname
+	}
+	if hc := inspect.HostConfig; hc != nil {
+		info.Mode = string(hc.NetworkMode)
+		info.DNS = hc.DNS
+		info.DNSSearch = hc.DNSSearch
+		info.DNSOptions = hc.DNSOptions
+		info.ExtraHosts = hc.ExtraHosts
+	}
+	if inspect.NetworkSettings != nil {
+		for name, endpoint := range inspect.NetworkSettings.Networks {
+			if endpoint == nil {
+				continue
+			}
+			info.Networks = append(info.Networks, ContainerNetwork{
+				Name:        name,
+				IPAddress:   endpoint.IPAddress,
+				PrefixLen:   endpoint.IPPrefixLen,
+				Gateway:     endpoint.Gateway,
+				IPv6Address: endpoint.GlobalIPv6Address,
+				IPv6Prefix:  endpoint.GlobalIPv6PrefixLen,
+				IPv6Gateway: endpoint.IPv6Gateway,
+				MacAddress:  endpoint.MacAddress,
+				Aliases:     endpoint.Aliases,
+				DNSNames:    endpoint.DNSNames,
+			})
+		}
+	}
+	sort.Slice(info.Networks, func(i, j int) bool {
+		return info.Networks[i].Name < info.Networks[j].Name
+	})
+	return info, nil
+}
+
+// PrimaryIP returns the first IPv4 address of the container, falling back to IPv6
+func (n *NetworkInfo) PrimaryIP() string {
+	for _, network := range n.Networks {
+		if network.IPAddress != "" {
+			return network.IPAddress
+		}
+	}
+	for _, network := range n.Networks {
+		if network.IPv6Address != "" {
+			return network.IPv6Address
+		}
+	}
+	return ""
+}
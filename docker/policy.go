@@ -0,0 +1,60 @@
+package docker
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// RestartPolicies are the restart policies in the order NextRestartPolicy cycles through them
+var RestartPolicies = []string{"no", "on-failure", "unless-stopped", "always"}
+
+// RestartPolicy is a container's restart policy
+type RestartPolicy struct {
+	Name       string
+	MaxRetries int // on-failure only; 0 means unlimited
+}
+
+func (p RestartPolicy) String() string {
+	if p.Name == "on-failure" && p.MaxRetries > 0 {
+		return fmt.Sprintf("on-failure (max %d retries)", p.MaxRetries)
+	}
+	return p.Name
+}
+
+// NextRestartPolicy returns the policy after name in RestartPolicies, wrapping around
+func NextRestartPolicy(name string) string {
+	for i, policy := range RestartPolicies {
+		if policy == name {
+			return RestartPolicies[(i+1)%len(RestartPolicies)]
+		}
+	}
+	return RestartPolicies[0]
+}
+
+// GetRestartPolicy inspects a container's restart policy
+func (c *Client) GetRestartPolicy(containerID string) (RestartPolicy, error) {
+	inspect, err := c.cli.ContainerInspect(c.ctx, containerID)
+	if err != nil {
+		return RestartPolicy{}, err
+	}
+	if inspect.HostConfig == nil {
+		return RestartPolicy{Name: "no"}, nil
+	}
+	policy := RestartPolicy{
+		Name:       string(inspect.HostConfig.RestartPolicy.Name),
+		MaxRetries: inspect.HostConfig.RestartPolicy.MaximumRetryCount,
+	}
+	if policy.Name == "" {
+		policy.Name = "no"
+	}
+	return policy, nil
+}
+
+// SetRestartPolicy changes a running or stopped container's restart policy in place
+func (c *Client) SetRestartPolicy(containerID, name string) error {
+	_, err := c.cli.ContainerUpdate(c.ctx, containerID, container.UpdateConfig{
+		RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyMode(name)},
+	})
+	return err
+}
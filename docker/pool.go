@@ -0,0 +1,60 @@
+package docker
+
+import "sync"
+
+// DefaultStatsConcurrency bounds simultaneous stats requests to the daemon
+const DefaultStatsConcurrency = 8
+
+// statsCall is an in-flight stats request that concurrent callers can wait on
+type statsCall struct {
+	done  chan struct{}
+	stats ContainerStats
+}
+
+// statsPool limits concurrent stats requests and coalesces duplicate requests
+// for the same container into a single API call
+type statsPool struct {
+	sem      chan struct{}
+	mu       sync.Mutex
+	inflight map[string]*statsCall
+}
+
+func newStatsPool(concurrency int) *statsPool {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &statsPool{
+		sem:      make(chan struct{}, concurrency),
+		inflight: make(map[string]*statsCall),
+	}
+}
+
+// do runs fetch for the container, or waits for an identical request already in flight
+func (p *statsPool) do(containerID string, fetch func() ContainerStats) ContainerStats {
+	p.mu.Lock()
+	if call, ok := p.inflight[containerID]; ok {
+		p.mu.Unlock()
+		<-call.done
+		return call.stats
+	}
+	call := &statsCall{done: make(chan struct{})}
+	p.inflight[containerID] = call
+	p.mu.Unlock()
+
+	p.sem <- struct{}{}
+	call.stats = fetch()
+	<-p.sem
+
+	p.mu.Lock()
+	delete(p.inflight, containerID)
+	p.mu.Unlock()
+	close(call.done)
+
+	return call.stats
+}
+
+// SetStatsConcurrency sets how many stats requests may run at once. Call before
+// collecting stats; requests already running keep the previous limit.
+func (c *Client) SetStatsConcurrency(n int) {
+	c.stats = newStatsPool(n)
+}
@@ -0,0 +1,77 @@
+package docker
+
+import (
+	"net"
+	"strconv"
+
+	"github.com/docker/go-connections/nat"
+)
+
+// PortBinding is a host port a container publishes
+type PortBinding struct {
+	HostIP        string // Interface address; empty for all interfaces
+	HostPort      int
+	ContainerPort int
+	Proto         string // tcp, udp or sctp
+}
+
+// Interface names the address the port is bound on, e.g. "0.0.0.0" or "127.0.0.1",
+// or "all" for every interface of both IPv4 and IPv6
+func (b PortBinding) Interface() string {
+	if b.HostIP == "" {
+		return "all"
+	}
+	return b.HostIP
+}
+
+// Overlaps reports whether two bindings of the same port and protocol would take
+// the same socket: the same address, or a wildcard address of the other's family
+func (b PortBinding) Overlaps(other PortBinding) bool {
+	if b.HostPort != other.HostPort || b.Proto != other.Proto {
+		return false
+	}
+	a, o := net.ParseIP(b.HostIP), net.ParseIP(other.HostIP)
+	if a == nil || o == nil {
+		// An empty address binds every interface of both families
+		return true
+	}
+	if (a.To4() == nil) != (o.To4() == nil) {
+		return false
+	}
+	return a.Equal(o) || a.IsUnspecified() || o.IsUnspecified()
+}
+
+// GetPortBindings inspects the host ports a container publishes: the ports bound
+// while it runs, or the ports it is configured to bind when started. Ports left for
+// the daemon to pick are skipped while the container is stopped.
+func (c *Client) GetPortBindings(containerID string) ([]PortBinding, error) {
+	inspect, err := c.cli.ContainerInspect(c.ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var ports nat.PortMap
+	switch {
+	case inspect.State != nil && inspect.State.Running && inspect.NetworkSettings != nil:
+		ports = inspect.NetworkSettings.Ports
+	case inspect.HostConfig != nil:
+		ports = inspect.HostConfig.PortBindings
+	}
+
+	bindings := []PortBinding{}
+	for port, hostBindings := range ports {
+		for _, hb := range hostBindings {
+			hostPort, err := strconv.Atoi(hb.HostPort)
+			if err != nil || hostPort == 0 {
+				continue
+			}
+			bindings = append(bindings, PortBinding{
+				HostIP:        hb.HostIP,
+				HostPort:      hostPort,
+				ContainerPort: port.Int(),
+				Proto:         port.Proto(),
+			})
+		}
+	}
+	return bindings, nil
+}
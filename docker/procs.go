@@ -0,0 +1,124 @@
+package docker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// clockTicks is USER_HZ, the unit of CPU times in /proc/<pid>/stat; it is 100 on
+// every mainstream Linux architecture
+const clockTicks = 100
+
+// pageSize converts the rss field of /proc/<pid>/stat (pages) to bytes
+const pageSize = 4096
+
+// processScript prints the container's uptime followed by the stat line and command
+// line of every process. It needs only a POSIX shell and cat.
+const processScript = `cat /proc/uptime; for p in /proc/[0-9]*; do echo "@"; cat $p/stat; echo; cat $p/cmdline; echo; done 2>/dev/null`
+
+// ProcessStat is one process inside a container, read from /proc
+type ProcessStat struct {
+	PID      int
+	PPID     int
+	Name     string // Executable name (comm)
+	Command  string // Full command line; empty for kernel threads and zombies
+	State    string // R running, S sleeping, D disk wait, Z zombie, ...
+	Threads  int
+	CPUTicks uint64 // User plus system CPU time in clock ticks
+	RSS      uint64 // Resident memory in bytes
+}
+
+// ProcessSample is a snapshot of the processes in a container
+type ProcessSample struct {
+	Uptime    float64 // Seconds since boot when the sample was taken, for CPU rates
+	Processes []ProcessStat
+}
+
+// CPUPercent returns the process's CPU usage between two samples; 100% is one core,
+// like docker stats
+func (s *ProcessSample) CPUPercent(prev *ProcessSample, p ProcessStat) (float64, bool) {
+	if prev == nil || s.Uptime <= prev.Uptime {
+		return 0, false
+	}
+	for _, old := range prev.Processes {
+		if old.PID == p.PID && old.CPUTicks <= p.CPUTicks {
+			seconds := float64(p.CPUTicks-old.CPUTicks) / clockTicks
+			return seconds / (s.Uptime - prev.Uptime) * 100, true
+		}
+	}
+	return 0, false
+}
+
+// SampleProcesses reads the processes of a running Linux container by exec'ing a
+// shell inside it
+func (c *Client) SampleProcesses(containerID string) (*ProcessSample, error) {
+	output, exitCode, err := c.Exec(containerID, []string{"/bin/sh", "-c", processScript})
+	if err != nil {
+		return nil, err
+	}
+	if exitCode != 0 && !strings.Contains(output, "@") {
+		return nil, fmt.Errorf("reading /proc needs /bin/sh in the container (exit code %d: %s)", exitCode, strings.TrimSpace(output))
+	}
+	return parseProcessSample(output)
+}
+
+// parseProcessSample parses the output of processScript
+func parseProcessSample(output string) (*ProcessSample, error) {
+	chunks := strings.Split(output, "@\n")
+	uptime := strings.Fields(chunks[0])
+	if len(uptime) == 0 {
+		return nil, fmt.Errorf("unexpected /proc/uptime output %q", chunks[0])
+	}
+	seconds, err := strconv.ParseFloat(uptime[0], 64)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected /proc/uptime output %q", chunks[0])
+	}
+
+	sample := &ProcessSample{Uptime: seconds}
+	for _, chunk := range chunks[1:] {
+		stat, cmdline, _ := strings.Cut(chunk, "\n")
+		p, ok := parseProcStat(stat)
+		if !ok {
+			// The process exited between listing and reading
+			continue
+		}
+		p.Command = strings.TrimSpace(strings.ReplaceAll(strings.TrimSuffix(cmdline, "\n"), "\x00", " "))
+		sample.Processes = append(sample.Processes, p)
+	}
+	return sample, nil
+}
+
+// parseProcStat parses a /proc/<pid>/stat line. The executable name is in
+// parentheses and may itself contain spaces and parentheses.
+func parseProcStat(line string) (ProcessStat, bool) {
+	open := strings.IndexByte(line, '(')
+	close := strings.LastIndexByte(line, ')')
+	if open < 0 || close < open {
+		return ProcessStat{}, false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(line[:open]))
+	if err != nil {
+		return ProcessStat{}, false
+	}
+
+	// Fields after the name, starting with field 3 (state)
+	fields := strings.Fields(line[close+1:])
+	if len(fields) < 22 {
+		return ProcessStat{}, false
+	}
+	field := func(n int) uint64 {
+		v, _ := strconv.ParseUint(fields[n-3], 10, 64)
+		return v
+	}
+
+	return ProcessStat{
+		PID:      pid,
+		PPID:     int(field(4)),
+		Name:     line[open+1 : close],
+		State:    fields[0],
+		Threads:  int(field(20)),
+		CPUTicks: field(14) + field(15),
+		RSS:      field(24) * pageSize,
+	}, true
+}
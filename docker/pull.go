@@ -0,0 +1,90 @@
+package docker
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/docker/docker/api/types/image"
+)
+
+// PullLayer is the progress of one layer of an image pull
+type PullLayer struct {
+	ID      string
+	Status  string // e.g. "Downloading", "Extracting", "Pull complete"
+	Current int64  // Bytes done in the current phase
+	Total   int64  // 0 when the phase has no known size
+}
+
+// PullProgress is a snapshot of an image pull
+type PullProgress struct {
+	Layers []PullLayer
+	Status string // Latest message not tied to a layer, e.g. "Digest: sha256:…"
+}
+
+// pullMessage is one line of the JSON progress stream returned by the daemon
+type pullMessage struct {
+	ID             string `json:"id"`
+	Status         string `json:"status"`
+	ProgressDetail struct {
+		Current int64 `json:"current"`
+		Total   int64 `json:"total"`
+	} `json:"progressDetail"`
+	Error string `json:"error"`
+}
+
+// PullImage pulls ref, calling progress (if not nil) with a snapshot after every update
+func (c *Client) PullImage(ref string, progress func(PullProgress)) error {
+	reader, err := c.cli.ImagePull(c.ctx, ref, image.PullOptions{})
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	return followProgress(reader, progress)
+}
+
+// followProgress reads a pull or push progress stream until it ends, calling progress
+// (if not nil) with a snapshot after every update
+func followProgress(reader io.Reader, progress func(PullProgress)) error {
+	var state PullProgress
+	layers := make(map[string]int) // Layer ID -> index in state.Layers
+
+	// The operation only completes once the progress stream is drained
+	decoder := json.NewDecoder(reader)
+	for {
+		var msg pullMessage
+		if err := decoder.Decode(&msg); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if msg.Error != "" {
+			return errors.New(msg.Error)
+		}
+
+		// Messages without an ID, and "Pulling from" whose ID is the tag, describe the whole pull
+		if msg.ID == "" || strings.HasPrefix(msg.Status, "Pulling from") {
+			state.Status = msg.Status
+			if msg.ID != "" {
+				state.Status = msg.ID + ": " + msg.Status
+			}
+		} else {
+			i, ok := layers[msg.ID]
+			if !ok {
+				i = len(state.Layers)
+				layers[msg.ID] = i
+				state.Layers = append(state.Layers, PullLayer{ID: msg.ID})
+			}
+			state.Layers[i].Status = msg.Status
+			state.Layers[i].Current = msg.ProgressDetail.Current
+			state.Layers[i].Total = msg.ProgressDetail.Total
+		}
+
+		if progress != nil {
+			snapshot := state
+			snapshot.Layers = append([]PullLayer(nil), state.Layers...)
+			progress(snapshot)
+		}
+	}
+}
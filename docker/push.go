@@ -0,0 +1,176 @@
+package docker
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/distribution/reference"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/registry"
+)
+
+// dockerHubServer is the key Docker Hub credentials are stored under
+const dockerHubServer = "https://index.docker.io/v1/"
+
+// RegistryAuth is the login for pushing to a registry
+type RegistryAuth struct {
+	Username string
+	Password string // Password, access token or identity token
+	Server   string
+}
+
+// dockerConfigFile is the part of ~/.docker/config.json that holds logins
+type dockerConfigFile struct {
+	Auths map[string]struct {
+		Auth          string `json:"auth"` // base64 of "username:password"
+		IdentityToken string `json:"identitytoken"`
+	} `json:"auths"`
+	CredsStore  string            `json:"credsStore"`
+	CredHelpers map[string]string `json:"credHelpers"`
+}
+
+// RegistryServer returns the registry an image reference is pushed to, as the docker
+// CLI names it in its credential store
+func RegistryServer(ref string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return "", err
+	}
+	domain := reference.Domain(named)
+	if domain == "docker.io" {
+		return dockerHubServer, nil
+	}
+	return domain, nil
+}
+
+// StoredRegistryAuth looks up the docker CLI's saved login for server, from its config
+// file or credential helper. It returns false when there is none.
+func StoredRegistryAuth(server string) (RegistryAuth, bool) {
+	dir := os.Getenv("DOCKER_CONFIG")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return RegistryAuth{}, false
+		}
+		dir = filepath.Join(home, ".docker")
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	if err != nil {
+		return RegistryAuth{}, false
+	}
+	var file dockerConfigFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return RegistryAuth{}, false
+	}
+
+	helper := file.CredsStore
+	if h, ok := file.CredHelpers[server]; ok {
+		helper = h
+	}
+	if helper != "" {
+		return credentialHelperAuth(helper, server)
+	}
+
+	for key, entry := range file.Auths {
+		if normalizeServer(key) != normalizeServer(server) {
+			continue
+		}
+		if entry.IdentityToken != "" {
+			return RegistryAuth{Password: entry.IdentityToken, Server: server}, true
+		}
+		decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+		if err != nil {
+			return RegistryAuth{}, false
+		}
+		username, password, ok := strings.Cut(string(decoded), ":")
+		return RegistryAuth{Username: username, Password: password, Server: server}, ok
+	}
+	return RegistryAuth{}, false
+}
+
+// credentialHelperAuth asks a docker-credential-* helper for the login to server
+func credentialHelperAuth(helper, server string) (RegistryAuth, bool) {
+	cmd := exec.Command("docker-credential-"+helper, "get")
+	cmd.Stdin = strings.NewReader(server)
+	output, err := cmd.Output()
+	if err != nil {
+		return RegistryAuth{}, false
+	}
+	var creds struct {
+		Username string `json:"Username"`
+		Secret   string `json:"Secret"`
+	}
+	if err := json.Unmarshal(output, &creds); err != nil || creds.Secret == "" {
+		return RegistryAuth{}, false
+	}
+	if creds.Username == "<token>" {
+		// Identity tokens are stored without a username
+		creds.Username = ""
+	}
+	return RegistryAuth{Username: creds.Username, Password: creds.Secret, Server: server}, true
+}
+
+// normalizeServer strips the scheme and path from a registry address, so
+// "https://registry.example.com/v1/" matches "registry.example.com"
+func normalizeServer(server string) string {
+	server = strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+	host, _, _ := strings.Cut(server, "/")
+	return host
+}
+
+// Login checks credentials with the registry through the daemon. It returns an
+// identity token when the registry issues one, to use instead of the password.
+func (c *Client) Login(auth RegistryAuth) (RegistryAuth, error) {
+	resp, err := c.cli.RegistryLogin(c.ctx, registry.AuthConfig{
+		Username:      auth.Username,
+		Password:      auth.Password,
+		ServerAddress: auth.Server,
+	})
+	if err != nil {
+		return auth, err
+	}
+	if resp.IdentityToken != "" {
+		auth.Password = resp.IdentityToken
+		auth.Username = ""
+	}
+	return auth, nil
+}
+
+// TagImage adds target as a name for the source image
+func (c *Client) TagImage(source, target string) error {
+	return c.cli.ImageTag(c.ctx, source, target)
+}
+
+// PushImage pushes ref to its registry, calling progress (if not nil) with a snapshot
+// after every update. An empty auth pushes anonymously.
+func (c *Client) PushImage(ref string, auth RegistryAuth, progress func(PullProgress)) error {
+	config := registry.AuthConfig{ServerAddress: auth.Server}
+	if auth.Username == "" {
+		config.IdentityToken = auth.Password
+	} else {
+		config.Username = auth.Username
+		config.Password = auth.Password
+	}
+	encoded, err := registry.EncodeAuthConfig(config)
+	if err != nil {
+		return err
+	}
+
+	reader, err := c.cli.ImagePush(c.ctx, ref, image.PushOptions{RegistryAuth: encoded})
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	if err := followProgress(reader, progress); err != nil {
+		if strings.Contains(err.Error(), "denied") || strings.Contains(err.Error(), "unauthorized") {
+			return fmt.Errorf("%w (log in with a username and password)", err)
+		}
+		return err
+	}
+	return nil
+}
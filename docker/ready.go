@@ -0,0 +1,77 @@
+package docker
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Conditions a container can be waited for, as in compose's depends_on
+const (
+	ConditionStarted   = "service_started"
+	ConditionHealthy   = "service_healthy"
+	ConditionCompleted = "service_completed_successfully"
+)
+
+// ErrNoHealthcheck is returned when waiting for a container without a healthcheck
+// to become healthy
+var ErrNoHealthcheck = errors.New("container has no healthcheck")
+
+// readyPollInterval is how often WaitReady inspects the container
+const readyPollInterval = 500 * time.Millisecond
+
+// WaitReady waits until a container meets the condition: running, healthy, or
+// exited with code 0. It fails once the condition can no longer be met (the
+// container exited or turned unhealthy) or after the timeout.
+func (c *Client) WaitReady(containerID, condition string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		inspect, err := c.cli.ContainerInspect(c.ctx, containerID)
+		if err != nil {
+			return err
+		}
+		state := inspect.State
+		if state == nil {
+			return errors.New("container has no state")
+		}
+
+		switch condition {
+		case ConditionCompleted:
+			if !state.Running && state.Status == "exited" {
+				if state.ExitCode != 0 {
+					return fmt.Errorf("exited with code %d", state.ExitCode)
+				}
+				return nil
+			}
+		case ConditionHealthy:
+			if state.Health == nil {
+				return ErrNoHealthcheck
+			}
+			if !state.Running {
+				return fmt.Errorf("exited with code %d before becoming healthy", state.ExitCode)
+			}
+			switch state.Health.Status {
+			case "healthy":
+				return nil
+			case "unhealthy":
+				return errors.New("unhealthy")
+			}
+		default:
+			if state.Running {
+				return nil
+			}
+			if state.Status == "exited" || state.Status == "dead" {
+				return fmt.Errorf("exited with code %d", state.ExitCode)
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("not ready after %s", timeout)
+		}
+		select {
+		case <-c.ctx.Done():
+			return c.ctx.Err()
+		case <-time.After(readyPollInterval):
+		}
+	}
+}
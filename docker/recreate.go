@@ -0,0 +1,158 @@
+package docker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+)
+
+// RecreateContainer replaces a container with a new one from imageRef, keeping its
+// name, configuration and networks. The old container is renamed out of the way
+// first and only removed once the new one has started, so a failure leaves it in place.
+// It returns the ID of the new container.
+func (c *Client) RecreateContainer(containerID, imageRef string) (string, error) {
+	inspect, err := c.cli.ContainerInspect(c.ctx, containerID)
+	if err != nil {
+		return "", err
+	}
+	if inspect.ContainerJSONBase == nil || inspect.Config == nil {
+		return "", fmt.Errorf("container %s has no configuration", containerID)
+	}
+
+	name := strings.TrimPrefix(inspect.Name, "/")
+	config, networking := containerSpec(inspect)
+	config.Image = imageRef
+
+	wasRunning := inspect.State != nil && inspect.State.Running
+	oldName := name + "-dtop-old"
+	if err := c.cli.ContainerRename(c.ctx, containerID, oldName); err != nil {
+		return "", err
+	}
+	restore := func() {
+		c.cli.ContainerRename(c.ctx, containerID, name)
+		if wasRunning {
+			c.StartContainer(containerID)
+		}
+	}
+
+	if wasRunning {
+		if err := c.StopContainer(containerID); err != nil {
+			restore()
+			return "", err
+		}
+	}
+
+	created, err := c.cli.ContainerCreate(c.ctx, config, inspect.HostConfig, networking, nil, name)
+	if err != nil {
+		restore()
+		return "", err
+	}
+	if wasRunning {
+		if err := c.StartContainer(created.ID); err != nil {
+			c.RemoveContainer(created.ID)
+			restore()
+			return "", err
+		}
+	}
+
+	return created.ID, c.RemoveContainer(containerID)
+}
+
+// containerSpec returns the configuration and networks to create a container like
+// the inspected one
+func containerSpec(inspect container.InspectResponse) (*container.Config, *network.NetworkingConfig) {
+	config := *inspect.Config
+	// The default hostname is the short container ID; let the new container get its own
+	if config.Hostname != "" && strings.HasPrefix(inspect.ID, config.Hostname) {
+		config.Hostname = ""
+	}
+
+	// Endpoint settings carry runtime state (IDs, assigned addresses); keep only the
+	// user's configuration
+	networking := &network.NetworkingConfig{EndpointsConfig: map[string]*network.EndpointSettings{}}
+	if inspect.NetworkSettings != nil {
+		for networkName, endpoint := range inspect.NetworkSettings.Networks {
+			if endpoint == nil {
+				continue
+			}
+			networking.EndpointsConfig[networkName] = &network.EndpointSettings{
+				IPAMConfig: endpoint.IPAMConfig,
+				Links:      endpoint.Links,
+				Aliases:    endpoint.Aliases,
+				DriverOpts: endpoint.DriverOpts,
+			}
+		}
+	}
+	return &config, networking
+}
+
+// ContainerSnapshot is what is kept of a container to create it again after it is removed
+type ContainerSnapshot struct {
+	Name       string
+	Running    bool // Start the container once it is created again
+	config     *container.Config
+	hostConfig *container.HostConfig
+	networking *network.NetworkingConfig
+}
+
+// SnapshotContainer keeps a container's configuration, mounts and networks so that
+// RestoreContainer can create it again. Anonymous volumes outlive the removal, so they
+// are mounted again by name instead of getting fresh ones.
+func (c *Client) SnapshotContainer(containerID string) (*ContainerSnapshot, error) {
+	inspect, err := c.cli.ContainerInspect(c.ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+	if inspect.ContainerJSONBase == nil || inspect.Config == nil || inspect.HostConfig == nil {
+		return nil, fmt.Errorf("container %s has no configuration", containerID)
+	}
+
+	config, networking := containerSpec(inspect)
+	hostConfig := *inspect.HostConfig
+	hostConfig.Binds = append([]string(nil), inspect.HostConfig.Binds...)
+	mounted := map[string]bool{}
+	for _, bind := range hostConfig.Binds {
+		if parts := strings.Split(bind, ":"); len(parts) >= 2 {
+			mounted[parts[1]] = true
+		}
+	}
+	for _, m := range hostConfig.Mounts {
+		mounted[m.Target] = true
+	}
+	for _, m := range inspect.Mounts {
+		if m.Type != mount.TypeVolume || m.Name == "" || mounted[m.Destination] {
+			continue
+		}
+		bind := m.Name + ":" + m.Destination
+		if !m.RW {
+			bind += ":ro"
+		}
+		hostConfig.Binds = append(hostConfig.Binds, bind)
+	}
+
+	return &ContainerSnapshot{
+		Name:       strings.TrimPrefix(inspect.Name, "/"),
+		Running:    inspect.State != nil && inspect.State.Running,
+		config:     config,
+		hostConfig: &hostConfig,
+		networking: networking,
+	}, nil
+}
+
+// RestoreContainer creates a container from a snapshot under its old name, starting
+// it if it was running, and returns the new container's ID
+func (c *Client) RestoreContainer(snapshot *ContainerSnapshot) (string, error) {
+	created, err := c.cli.ContainerCreate(c.ctx, snapshot.config, snapshot.hostConfig, snapshot.networking, nil, snapshot.Name)
+	if err != nil {
+		return "", err
+	}
+	if snapshot.Running {
+		if err := c.StartContainer(created.ID); err != nil {
+			return created.ID, err
+		}
+	}
+	return created.ID, nil
+}
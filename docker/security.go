@@ -0,0 +1,99 @@
+package docker
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SecurityInfo is the security-relevant part of a container's configuration
+type SecurityInfo struct {
+	Privileged     bool
+	User           string // As configured; empty runs as the image's user
+	CapAdd         []string
+	CapDrop        []string
+	Seccomp        string // "default", "unconfined" or a custom profile
+	AppArmor       string // Applied profile; empty when the host has no AppArmor
+	NoNewPrivs     bool   // no-new-privileges is set
+	ReadOnlyRootfs bool
+	PIDMode        string // "host" shares the host's process namespace
+	NetworkMode    string // "host" shares the host's network stack
+	IPCMode        string
+	UsernsMode     string
+	Devices        []string // Host devices passed through, as host:container
+	PublicPorts    []string // Ports published on all interfaces, e.g. 0.0.0.0:5432->5432/tcp
+	LocalPorts     []string // Ports published on a specific address
+	DockerSocket   string   // Host path of a mounted Docker socket, if any
+}
+
+// RootUser reports whether the container's process runs as root, assuming the
+// image doesn't set a user when the container doesn't
+func (s *SecurityInfo) RootUser() bool {
+	user, _, _ := strings.Cut(s.User, ":")
+	return user == "" || user == "root" || user == "0"
+}
+
+// GetSecurityInfo inspects a container's privileges, confinement and exposure
+func (c *Client) GetSecurityInfo(containerID string) (*SecurityInfo, error) {
+	inspect, err := c.cli.ContainerInspect(c.ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &SecurityInfo{AppArmor: inspect.AppArmorProfile, Seccomp: "default"}
+	if inspect.Config != nil {
+		info.User = inspect.Config.User
+	}
+	if hc := inspect.HostConfig; hc != nil {
+		info.Privileged = hc.Privileged
+		info.CapAdd = hc.CapAdd
+		info.CapDrop = hc.CapDrop
+		info.ReadOnlyRootfs = hc.ReadonlyRootfs
+		info.PIDMode = string(hc.PidMode)
+		info.NetworkMode = string(hc.NetworkMode)
+		info.IPCMode = string(hc.IpcMode)
+		info.UsernsMode = string(hc.UsernsMode)
+		for _, device := range hc.Devices {
+			info.Devices = append(info.Devices, device.PathOnHost+":"+device.PathInContainer)
+		}
+		for _, opt := range hc.SecurityOpt {
+			// Both key=value and the older key:value forms are accepted by the daemon
+			key, value, ok := strings.Cut(opt, "=")
+			if !ok {
+				key, value, _ = strings.Cut(opt, ":")
+			}
+			switch key {
+			case "seccomp":
+				info.Seccomp = value
+			case "no-new-privileges":
+				info.NoNewPrivs = value == "" || value == "true"
+			}
+		}
+		if info.Privileged {
+			info.Seccomp = "unconfined"
+		}
+
+		for port, bindings := range hc.PortBindings {
+			for _, b := range bindings {
+				ip := b.HostIP
+				switch ip {
+				case "", "0.0.0.0", "::":
+					if ip == "" {
+						ip = "0.0.0.0"
+					}
+					info.PublicPorts = append(info.PublicPorts, fmt.Sprintf("%s:%s->%s", ip, b.HostPort, port))
+				default:
+					info.LocalPorts = append(info.LocalPorts, fmt.Sprintf("%s:%s->%s", ip, b.HostPort, port))
+				}
+			}
+		}
+		sort.Strings(info.PublicPorts)
+		sort.Strings(info.LocalPorts)
+	}
+	for _, mount := range inspect.Mounts {
+		if strings.HasSuffix(mount.Source, "docker.sock") {
+			info.DockerSocket = mount.Source
+		}
+	}
+	return info, nil
+}
@@ -0,0 +1,100 @@
+package docker
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// startedSlack absorbs clock skew between dtop and the daemon when comparing uptimes
+const startedSlack = 5 * time.Second
+
+// statusUptimePattern matches the uptime docker puts in a running container's status,
+// e.g. "Up 5 minutes (healthy)"
+var statusUptimePattern = regexp.MustCompile(`^Up (Less than a second|About a minute|About an hour|(\d+) (second|minute|hour|day|week|month|year)s?)`)
+
+var statusUptimeUnits = map[string]time.Duration{
+	"second": time.Second,
+	"minute": time.Minute,
+	"hour":   time.Hour,
+	"day":    24 * time.Hour,
+	"week":   7 * 24 * time.Hour,
+	"month":  30 * 24 * time.Hour,
+	"year":   365 * 24 * time.Hour,
+}
+
+// statusUptimeBound returns an upper bound on the uptime in a container's status text.
+// The daemon rounds it down to its largest unit, so "3 hours" is less than 4 hours.
+func statusUptimeBound(status string) (time.Duration, bool) {
+	match := statusUptimePattern.FindStringSubmatch(status)
+	if match == nil {
+		return 0, false
+	}
+	switch match[1] {
+	case "Less than a second":
+		return time.Second, true
+	case "About a minute":
+		return 2 * time.Minute, true
+	case "About an hour":
+		return 2 * time.Hour, true
+	}
+	n, err := strconv.Atoi(match[2])
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(n+1) * statusUptimeUnits[match[3]], true
+}
+
+// startedAt returns when a running container was last started. The list API only
+// reports creation time, so containers are inspected once and the result is cached
+// until the status shows a shorter uptime than the cached start (it was restarted).
+func (c *Client) startedAt(containerID, status string) time.Time {
+	c.startedMu.Lock()
+	started, ok := c.started[containerID]
+	c.startedMu.Unlock()
+
+	if ok {
+		bound, known := statusUptimeBound(status)
+		if !known || time.Since(started) <= bound+startedSlack {
+			return started
+		}
+	}
+
+	inspect, err := c.cli.ContainerInspect(c.ctx, containerID)
+	if err != nil || inspect.State == nil {
+		return time.Time{}
+	}
+	started, err = time.Parse(time.RFC3339Nano, inspect.State.StartedAt)
+	if err != nil {
+		return time.Time{}
+	}
+
+	c.startedMu.Lock()
+	c.started[containerID] = started
+	c.startedMu.Unlock()
+	return started
+}
+
+// forgetStarted drops cached start times of containers that are no longer running
+func (c *Client) forgetStarted(running map[string]bool) {
+	c.startedMu.Lock()
+	defer c.startedMu.Unlock()
+	for id := range c.started {
+		if !running[id] {
+			delete(c.started, id)
+		}
+	}
+}
+
+// withShortIDs adds the short IDs of the running containers, which the UI asks for
+// stats by, to their full IDs
+func withShortIDs(running map[string]bool) map[string]bool {
+	ids := make(map[string]bool, 2*len(running))
+	for id := range running {
+		ids[id] = true
+		if len(id) > 12 {
+			ids[id[:12]] = true
+		}
+	}
+	return ids
+}
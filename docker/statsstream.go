@@ -0,0 +1,121 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// statsStream follows a container's stats stream and keeps its latest sample. The
+// daemon samples all streamed containers with one collector, so a tick reading the
+// cached samples costs it nothing; polling makes it sample each container on request.
+type statsStream struct {
+	cancel context.CancelFunc
+
+	mu     sync.Mutex
+	latest ContainerStats
+	ready  bool // A sample with a CPU baseline arrived
+	ended  bool // The stream closed or failed; it is reopened if the container still runs
+}
+
+// sample returns the latest sample, if one is ready
+func (s *statsStream) sample() (ContainerStats, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.latest, s.ready
+}
+
+// SetStatsStreams sets how many running containers are followed over a long-lived
+// stats stream instead of being polled on every refresh; the rest are still polled.
+// 0 disables streaming and closes the open streams.
+func (c *Client) SetStatsStreams(limit int) {
+	c.streamMu.Lock()
+	defer c.streamMu.Unlock()
+	c.streamLimit = max(limit, 0)
+	for id, stream := range c.statsStreams {
+		if len(c.statsStreams) <= c.streamLimit {
+			break
+		}
+		stream.cancel()
+		delete(c.statsStreams, id)
+	}
+}
+
+// followStats opens streams for newly running containers up to the limit, reopens
+// ended ones and closes those of containers that stopped. Streams are keyed by short
+// ID, as GetContainerStats is called with.
+func (c *Client) followStats(running map[string]bool) {
+	short := make(map[string]bool, len(running))
+	for id := range running {
+		short[id[:12]] = true
+	}
+
+	c.streamMu.Lock()
+	defer c.streamMu.Unlock()
+
+	for id, stream := range c.statsStreams {
+		stream.mu.Lock()
+		ended := stream.ended
+		stream.mu.Unlock()
+		if !short[id] || ended {
+			stream.cancel()
+			delete(c.statsStreams, id)
+		}
+	}
+	for id := range short {
+		if len(c.statsStreams) >= c.streamLimit {
+			return
+		}
+		if _, ok := c.statsStreams[id]; !ok {
+			c.statsStreams[id] = c.streamStats(id)
+		}
+	}
+}
+
+// streamedStats returns the latest streamed sample of a container, if it is followed
+// and has one
+func (c *Client) streamedStats(containerID string) (ContainerStats, bool) {
+	c.streamMu.Lock()
+	stream, ok := c.statsStreams[containerID]
+	c.streamMu.Unlock()
+	if !ok {
+		return ContainerStats{}, false
+	}
+	return stream.sample()
+}
+
+// streamStats starts following a container's stats stream in the background
+func (c *Client) streamStats(containerID string) *statsStream {
+	ctx, cancel := context.WithCancel(c.ctx)
+	stream := &statsStream{cancel: cancel}
+
+	go func() {
+		defer func() {
+			stream.mu.Lock()
+			stream.ended = true
+			stream.mu.Unlock()
+		}()
+		stats, err := c.cli.ContainerStats(ctx, containerID, true)
+		if err != nil {
+			return
+		}
+		defer stats.Body.Close()
+
+		decoder := json.NewDecoder(stats.Body)
+		for {
+			var v statsResponse
+			if err := decoder.Decode(&v); err != nil {
+				return
+			}
+			// The first sample has no previous CPU reading to compute usage from
+			if v.PreRead.IsZero() {
+				continue
+			}
+			sample := c.convertStats(containerID, &v)
+			stream.mu.Lock()
+			stream.latest, stream.ready = sample, true
+			stream.mu.Unlock()
+		}
+	}()
+	return stream
+}
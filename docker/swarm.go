@@ -0,0 +1,140 @@
+package docker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/swarm"
+	units "github.com/docker/go-units"
+	"github.com/ekinertac/dtop/debuglog"
+)
+
+// swarmTTL is how long task placement is reused between container lists
+const swarmTTL = 10 * time.Second
+
+// Labels the swarm agent puts on task containers
+const (
+	swarmServiceLabel = "com.docker.swarm.service.name"
+	swarmTaskLabel    = "com.docker.swarm.task.id"
+	swarmNodeLabel    = "com.docker.swarm.node.id"
+)
+
+// swarmPlacement caches where swarm tasks run, as seen from a manager node
+type swarmPlacement struct {
+	manager bool
+	local   string          // Hostname of the node dtop is connected to
+	remote  []ContainerInfo // Running task containers on other nodes
+	fetched time.Time
+}
+
+// placement returns the cached task placement, refreshing it when stale. Daemons that
+// aren't swarm managers only cost an Info call per refresh.
+func (c *Client) placement() swarmPlacement {
+	c.swarmMu.Lock()
+	defer c.swarmMu.Unlock()
+
+	if time.Since(c.swarm.fetched) < swarmTTL {
+		return c.swarm
+	}
+	placement, err := c.fetchPlacement()
+	if err != nil {
+		// Keep the previous placement rather than dropping remote rows on a hiccup
+		debuglog.Error("swarm placement", err)
+		return c.swarm
+	}
+	c.swarm = placement
+	return placement
+}
+
+// fetchPlacement lists nodes, services and running tasks from a swarm manager
+func (c *Client) fetchPlacement() (swarmPlacement, error) {
+	placement := swarmPlacement{fetched: time.Now()}
+	info, err := c.cli.Info(c.ctx)
+	if err != nil {
+		return placement, err
+	}
+	if !info.Swarm.ControlAvailable {
+		return placement, nil
+	}
+
+	nodes, err := c.cli.NodeList(c.ctx, swarm.NodeListOptions{})
+	if err != nil {
+		return placement, err
+	}
+	hostnames := make(map[string]string, len(nodes))
+	for _, node := range nodes {
+		hostnames[node.ID] = node.Description.Hostname
+	}
+
+	services, err := c.cli.ServiceList(c.ctx, swarm.ServiceListOptions{})
+	if err != nil {
+		return placement, err
+	}
+	serviceNames := make(map[string]string, len(services))
+	for _, service := range services {
+		serviceNames[service.ID] = service.Spec.Name
+	}
+
+	tasks, err := c.cli.TaskList(c.ctx, swarm.TaskListOptions{
+		Filters: filters.NewArgs(filters.Arg("desired-state", "running")),
+	})
+	if err != nil {
+		return placement, err
+	}
+
+	placement.manager = true
+	placement.local = hostnames[info.Swarm.NodeID]
+	for _, task := range tasks {
+		status := task.Status.ContainerStatus
+		if status == nil || status.ContainerID == "" || task.Status.State != swarm.TaskStateRunning {
+			continue
+		}
+		if task.NodeID != info.Swarm.NodeID {
+			placement.remote = append(placement.remote, remoteTask(task, serviceNames[task.ServiceID], hostnames[task.NodeID]))
+		}
+	}
+	return placement, nil
+}
+
+// remoteTask describes a task container on another node the way a local container is
+// listed. Stats and actions aren't available for it from this daemon.
+func remoteTask(task swarm.Task, service, node string) ContainerInfo {
+	// Replicated tasks are named service.slot.task like their containers; global ones use the node ID
+	slot := task.NodeID
+	if task.Slot > 0 {
+		slot = fmt.Sprint(task.Slot)
+	}
+
+	labels := map[string]string{}
+	image := ""
+	if spec := task.Spec.ContainerSpec; spec != nil {
+		for key, value := range spec.Labels {
+			labels[key] = value
+		}
+		image = spec.Image
+	}
+	labels[swarmServiceLabel] = service
+	labels[swarmTaskLabel] = task.ID
+	labels[swarmNodeLabel] = task.NodeID
+
+	containerID := task.Status.ContainerStatus.ContainerID
+	if len(containerID) > 12 {
+		containerID = containerID[:12]
+	}
+	return ContainerInfo{
+		ID:        containerID,
+		Name:      fmt.Sprintf("%s.%s.%s", service, slot, task.ID),
+		Image:     image,
+		State:     "running",
+		Status:    "Up " + units.HumanDuration(time.Since(task.Status.Timestamp)),
+		CreatedAt: task.CreatedAt,
+		StartedAt: task.Status.Timestamp,
+		Labels:    labels,
+		Node:      node,
+		Remote:    true,
+		ContainerStats: ContainerStats{
+			MemUsage: "N/A",
+		},
+	}
+}
@@ -0,0 +1,39 @@
+package docker
+
+// windowsContainerStats converts a Windows container stats sample. Windows has no
+// online_cpus or system CPU usage, reports memory as the private working set without
+// a limit, and disk I/O in storage_stats.
+func (c *Client) windowsContainerStats(containerID string, v *statsResponse) ContainerStats {
+	result := ContainerStats{}
+
+	// CPU usage is in 100ns intervals. Compare against the intervals elapsed since the
+	// previous sample, so like on Linux 100% is one full processor.
+	elapsed := uint64(v.Read.Sub(v.PreRead).Nanoseconds()) / 100
+	if elapsed > 0 && v.CPUStats.CPUUsage.TotalUsage > v.PreCPUStats.CPUUsage.TotalUsage {
+		used := v.CPUStats.CPUUsage.TotalUsage - v.PreCPUStats.CPUUsage.TotalUsage
+		result.CPUPerc = float64(used) / float64(elapsed) * 100.0
+	}
+
+	// No limit is reported, so usage is measured against host memory
+	result.Memory = memoryBreakdown(v.MemoryStats.PrivateWorkingSet, 0, c.hostMemory(), nil)
+	result.Memory.Reported = v.MemoryStats.PrivateWorkingSet > 0
+	if result.Memory.Limit > 0 {
+		result.MemPerc = float64(result.Memory.Usage) / float64(result.Memory.Limit) * 100.0
+	}
+	result.MemUsage = "N/A"
+	if result.Memory.Reported {
+		result.MemUsage = FormatBytes(result.Memory.Usage)
+	}
+
+	for _, net := range v.Networks {
+		result.NetRx += net.RxBytes
+		result.NetTx += net.TxBytes
+	}
+
+	result.Block.Read = v.StorageStats.ReadSizeBytes
+	result.Block.Write = v.StorageStats.WriteSizeBytes
+	c.blockIORates(containerID, v.Read, &result.Block)
+	result.BlockIO = FormatBytes(result.Block.Read) + " / " + FormatBytes(result.Block.Write)
+
+	return result
+}
@@ -0,0 +1,172 @@
+// Package hooks runs the commands, HTTP requests and chat notifications configured
+// for container events such as crashes and usage crossing a threshold
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/ekinertac/dtop/audit"
+	"github.com/ekinertac/dtop/config"
+)
+
+// hookTimeout bounds how long a command or HTTP request may take
+const hookTimeout = 30 * time.Second
+
+// Event is something dtop observed about a container. It is the JSON payload of
+// URL hooks and is passed to command hooks on stdin and as DTOP_* variables.
+type Event struct {
+	Event       string    `json:"event"`
+	Time        time.Time `json:"time"`
+	ContainerID string    `json:"container_id"`
+	Container   string    `json:"container"`
+	Project     string    `json:"project"`
+	Message     string    `json:"message"`
+	Value       float64   `json:"value"` // Usage percent for cpu/memory, exit code for exited (sent even when 0)
+	Threshold   float64   `json:"threshold,omitempty"`
+	Resolved    bool      `json:"resolved,omitempty"` // The condition cleared; only sent to notifiers
+}
+
+// Runner fires the configured hooks; a nil Runner fires nothing
+type Runner struct {
+	hooks []config.Hook
+	log   *audit.Log
+}
+
+// New creates a runner for the configured hooks; every run is recorded in log
+func New(hooks []config.Hook, log *audit.Log) *Runner {
+	if len(hooks) == 0 {
+		return nil
+	}
+	return &Runner{hooks: hooks, log: log}
+}
+
+// Fire runs the hooks for an event in the background. Resolved events only go to notifiers.
+func (r *Runner) Fire(e Event) {
+	if r == nil {
+		return
+	}
+	for _, hook := range r.hooks {
+		if hook.Event == e.Event && !isThreshold(e.Event) && (!e.Resolved || hook.Notifier()) {
+			go r.run(hook, e)
+		}
+	}
+}
+
+// Crossed runs cpu/memory hooks whose threshold lies between the previous and the
+// current usage, so a hook fires once per rise rather than on every sample above it.
+// Notifiers are also told when usage falls back below the threshold.
+func (r *Runner) Crossed(e Event, previous float64) {
+	if r == nil {
+		return
+	}
+	for _, hook := range r.hooks {
+		if hook.Event != e.Event || !isThreshold(e.Event) {
+			continue
+		}
+		threshold := hook.Threshold
+		if threshold <= 0 {
+			threshold = config.DefaultHookThreshold
+		}
+
+		e := e
+		e.Threshold = threshold
+		switch {
+		case previous < threshold && e.Value >= threshold:
+			e.Message = fmt.Sprintf("%s %s usage %.0f%% exceeded %.0f%%", e.Container, e.Event, e.Value, threshold)
+		case previous >= threshold && e.Value < threshold && hook.Notifier():
+			e.Resolved = true
+			e.Message = fmt.Sprintf("%s %s usage %.0f%% is back below %.0f%%", e.Container, e.Event, e.Value, threshold)
+		default:
+			continue
+		}
+		go r.run(hook, e)
+	}
+}
+
+func isThreshold(event string) bool {
+	return event == config.EventCPU || event == config.EventMemory
+}
+
+func (r *Runner) run(hook config.Hook, e Event) {
+	var payload []byte
+	var err error
+	switch {
+	case hook.Slack != "":
+		payload, err = json.Marshal(slackMessage(e))
+		if err == nil {
+			err = post(hook.Slack, payload)
+		}
+	case hook.Discord != "":
+		payload, err = json.Marshal(discordMessage(e))
+		if err == nil {
+			err = post(hook.Discord, payload)
+		}
+	default:
+		payload, err = json.Marshal(e)
+		if err != nil {
+			break
+		}
+		if hook.URL != "" {
+			err = post(hook.URL, payload)
+		} else {
+			err = command(hook.Command, e, payload)
+		}
+	}
+
+	action := "hook " + e.Event
+	if e.Resolved {
+		action += " resolved"
+	}
+	r.log.Record(action, e.Container, err)
+}
+
+// post sends a JSON payload to url
+func post(url string, payload []byte) error {
+	client := http.Client{Timeout: hookTimeout}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+	return nil
+}
+
+// command runs a shell command with the event on stdin and in the environment
+func command(line string, e Event, payload []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", line)
+	} else {
+		cmd = exec.CommandContext(ctx, "/bin/sh", "-c", line)
+	}
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(),
+		"DTOP_EVENT="+e.Event,
+		"DTOP_CONTAINER="+e.Container,
+		"DTOP_CONTAINER_ID="+e.ContainerID,
+		"DTOP_PROJECT="+e.Project,
+		"DTOP_MESSAGE="+e.Message,
+		"DTOP_VALUE="+strconv.FormatFloat(e.Value, 'f', -1, 64),
+	)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil && len(output) > 0 {
+		return fmt.Errorf("%w: %s", err, bytes.TrimSpace(output))
+	}
+	return err
+}
@@ -0,0 +1,93 @@
+package hooks
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ekinertac/dtop/config"
+)
+
+// Alert colors: red while firing, green once resolved
+const (
+	firingColor   = 0xFF5555
+	resolvedColor = 0x00C853
+)
+
+// notifyField is a labelled value shown in a chat message
+type notifyField struct {
+	name  string
+	value string
+}
+
+// title summarizes the event for the message heading
+func (e Event) title() string {
+	if e.Resolved {
+		return fmt.Sprintf("Resolved: %s %s", e.Container, e.Event)
+	}
+	return fmt.Sprintf("Alert: %s %s", e.Container, e.Event)
+}
+
+// fields lists the container, project and metric values for chat messages
+func (e Event) fields() []notifyField {
+	fields := []notifyField{
+		{"Container", e.Container},
+		{"Project", e.Project},
+	}
+	switch e.Event {
+	case config.EventCPU, config.EventMemory:
+		fields = append(fields,
+			notifyField{"Usage", fmt.Sprintf("%.1f%%", e.Value)},
+			notifyField{"Threshold", fmt.Sprintf("%.0f%%", e.Threshold)},
+		)
+	case config.EventExited:
+		fields = append(fields, notifyField{"Exit code", fmt.Sprintf("%.0f", e.Value)})
+	}
+	return fields
+}
+
+func (e Event) color() int {
+	if e.Resolved {
+		return resolvedColor
+	}
+	return firingColor
+}
+
+// slackMessage formats an event for a Slack incoming webhook
+func slackMessage(e Event) map[string]any {
+	fields := []map[string]any{}
+	for _, f := range e.fields() {
+		fields = append(fields, map[string]any{"title": f.name, "value": f.value, "short": true})
+	}
+	return map[string]any{
+		"text": e.title(),
+		"attachments": []map[string]any{{
+			"color":  fmt.Sprintf("#%06X", e.color()),
+			"text":   e.Message,
+			"fields": fields,
+			"footer": "dtop",
+			"ts":     e.Time.Unix(),
+		}},
+	}
+}
+
+// discordMessage formats an event for a Discord webhook. Discord rejects embeds with
+// an empty field value, so those are left out.
+func discordMessage(e Event) map[string]any {
+	fields := []map[string]any{}
+	for _, f := range e.fields() {
+		if f.value == "" {
+			continue
+		}
+		fields = append(fields, map[string]any{"name": f.name, "value": f.value, "inline": true})
+	}
+	return map[string]any{
+		"username": "dtop",
+		"embeds": []map[string]any{{
+			"title":       e.title(),
+			"description": e.Message,
+			"color":       e.color(),
+			"fields":      fields,
+			"timestamp":   e.Time.UTC().Format(time.RFC3339),
+		}},
+	}
+}
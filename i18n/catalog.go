@@ -0,0 +1,322 @@
+package i18n
+
+// catalogs holds the translations per language, keyed by the English message. To add
+// a language, add its locale and a catalog; messages missing from it stay English.
+var catalogs = map[string]map[string]string{
+	"de": {
+		// Titles and table
+		"dtop - Docker Container Monitor": "dtop - Docker-Container-Monitor",
+		"No containers found":             "Keine Container gefunden",
+		"NAME":                            "NAME",
+		"STATUS":                          "STATUS",
+		"CPU":                             "CPU",
+		"MEMORY":                          "SPEICHER",
+		"CPU cores":                       "CPU-Kerne",
+		"MEMORY used":                     "SPEICHER belegt",
+		"NET RX/TX":                       "NETZ RX/TX",
+		"DISK R/W /s":                     "DISK L/S /s",
+		"PIDS":                            "PIDS",
+		"NODE":                            "KNOTEN",
+		"ID":                              "ID",
+		"IMAGE":                           "IMAGE",
+		"PORTS":                           "PORTS",
+		"GPU":                             "GPU",
+		"LOGS/s":                          "LOGS/s",
+		"STARTED":                         "GESTARTET",
+		"UPTIME":                          "LAUFZEIT",
+		"Actions for project: %s":         "Aktionen für Projekt: %s",
+		"Actions for container: %s":       "Aktionen für Container: %s",
+		"on":                              "an",
+		"off":                             "aus",
+
+		// Help labels
+		"navigate":                       "navigieren",
+		"collapse/expand":                "zu-/aufklappen",
+		"all":                            "alle",
+		"menu":                           "Menü",
+		"quick action":                   "Schnellaktion",
+		"jump":                           "springen",
+		"mark/compare":                   "markieren/vergleichen",
+		"pin":                            "anheften",
+		"hide/reveal":                    "aus-/einblenden",
+		"zoom":                           "zoomen",
+		"group by":                       "gruppieren",
+		"sort":                           "sortieren",
+		"details":                        "Details",
+		"host":                           "Host",
+		"new":                            "neu",
+		"build":                          "bauen",
+		"cleanup":                        "aufräumen",
+		"log strip":                      "Logleiste",
+		"audit":                          "Protokoll",
+		"sizing":                         "Dimensionierung",
+		"quotas":                         "Kontingente",
+		"ports":                          "Ports",
+		"search logs":                    "Logs durchsuchen",
+		"open in logs":                   "in Logs öffnen",
+		"new search":                     "neue Suche",
+		"undo":                           "rückgängig",
+		"profile":                        "Profil",
+		"note":                           "Notiz",
+		"node":                           "Knoten",
+		"host bar":                       "Hostleiste",
+		"units":                          "Einheiten",
+		"log rate":                       "Lograte",
+		"start times":                    "Startzeiten",
+		"image":                          "Image",
+		"gpu":                            "GPU",
+		"read-only":                      "schreibgeschützt",
+		"describe":                       "beschreiben",
+		"quit":                           "beenden",
+		"pause":                          "Pause",
+		"speed":                          "Tempo",
+		"select":                         "auswählen",
+		"execute":                        "ausführen",
+		"back":                           "zurück",
+		"scroll":                         "blättern",
+		"hide (continues in background)": "ausblenden (läuft im Hintergrund weiter)",
+		"follow":                         "folgen",
+		"keep/remove":                    "behalten/entfernen",
+		"all/none":                       "alle/keine",
+		"remove ticked":                  "Markierte entfernen",
+		"clear marks":                    "Markierungen löschen",
+		"hide listening":                 "lauschende ausblenden",
+		"show listening":                 "lauschende einblenden",
+		"refresh":                        "aktualisieren",
+		"cycle restart policy":           "Neustart-Richtlinie wechseln",
+		"copy IP":                        "IP kopieren",
+		"field":                          "Feld",
+		"submit":                         "absenden",
+		"clear":                          "leeren",
+		"cancel":                         "abbrechen",
+		"type to filter":                 "tippen zum Filtern",
+		"save CSV":                       "als CSV speichern",
+		"sort by memory":                 "nach Speicher sortieren",
+		"sort by CPU":                    "nach CPU sortieren",
+		"continue":                       "fortfahren",
+		"pan":                            "verschieben",
+		"expand JSON":                    "JSON aufklappen",
+		"JSON":                           "JSON",
+		"level":                          "Stufe",
+		"wrap":                           "umbrechen",
+		"colors":                         "Farben",
+		"time":                           "Zeit",
+		"delta":                          "Abstand",
+
+		// Menu labels
+		"Restart All":                         "Alle neu starten",
+		"Stop All":                            "Alle stoppen",
+		"Down (stop & remove, keeps volumes)": "Down (stoppen & entfernen, Volumes bleiben)",
+		"Start All":                           "Alle starten",
+		"Start All in Dependency Order":       "Alle nach Abhängigkeiten starten",
+		"Edit compose file & redeploy":        "Compose-Datei bearbeiten & neu ausrollen",
+		"Restart":                             "Neu starten",
+		"Stop":                                "Stoppen",
+		"Stop (custom timeout)…":              "Stoppen (eigenes Timeout)…",
+		"Processes":                           "Prozesse",
+		"Connections":                         "Verbindungen",
+		"Send signal…":                        "Signal senden…",
+		"Attach":                              "Anhängen",
+		"Remove (keeps volumes)":              "Entfernen (Volumes bleiben)",
+		"Start":                               "Starten",
+		"Logs":                                "Logs",
+		"Run healthcheck":                     "Healthcheck ausführen",
+		"Details":                             "Details",
+		"Checkpoints…":                        "Checkpoints…",
+		"Resource limits…":                    "Ressourcenlimits…",
+		"Cycle restart policy":                "Neustart-Richtlinie wechseln",
+		"Security":                            "Sicherheit",
+		"Labels":                              "Labels",
+		"Export filesystem…":                  "Dateisystem exportieren…",
+		"Build image…":                        "Image bauen…",
+		"Pull image":                          "Image ziehen",
+		"Tag & push image…":                   "Image taggen & pushen…",
+		"Save image…":                         "Image speichern…",
+		"Cancel":                              "Abbrechen",
+		"Clear project marks":                 "Projektmarkierungen löschen",
+		"Redeploy (docker compose up -d)":     "Neu ausrollen (docker compose up -d)",
+		"Not now":                             "Nicht jetzt",
+
+		// Form fields
+		"Name":           "Name",
+		"Image":          "Image",
+		"Ports":          "Ports",
+		"Env":            "Umgebung",
+		"Volumes":        "Volumes",
+		"Memory":         "Speicher",
+		"CPUs":           "CPUs",
+		"Restart policy": "Neustart-Richtlinie",
+		"Tag":            "Tag",
+		"Context":        "Kontext",
+		"Dockerfile":     "Dockerfile",
+		"Push as":        "Pushen als",
+		"Recreate":       "Neu erstellen",
+		"Username":       "Benutzername",
+		"Password":       "Passwort",
+		"Path":           "Pfad",
+		"Lines":          "Zeilen",
+		"Grace period":   "Schonfrist",
+		"Note":           "Notiz",
+		"Pattern":        "Muster",
+		"Since":          "Seit",
+		"Containers":     "Container",
+	},
+	"es": {
+		// Titles and table
+		"dtop - Docker Container Monitor": "dtop - Monitor de contenedores Docker",
+		"No containers found":             "No se encontraron contenedores",
+		"NAME":                            "NOMBRE",
+		"STATUS":                          "ESTADO",
+		"CPU":                             "CPU",
+		"MEMORY":                          "MEMORIA",
+		"CPU cores":                       "Núcleos CPU",
+		"MEMORY used":                     "MEMORIA usada",
+		"NET RX/TX":                       "RED RX/TX",
+		"DISK R/W /s":                     "DISCO L/E /s",
+		"PIDS":                            "PIDS",
+		"NODE":                            "NODO",
+		"ID":                              "ID",
+		"IMAGE":                           "IMAGEN",
+		"PORTS":                           "PUERTOS",
+		"GPU":                             "GPU",
+		"LOGS/s":                          "LOGS/s",
+		"STARTED":                         "INICIADO",
+		"UPTIME":                          "ACTIVO",
+		"Actions for project: %s":         "Acciones del proyecto: %s",
+		"Actions for container: %s":       "Acciones del contenedor: %s",
+		"on":                              "sí",
+		"off":                             "no",
+
+		// Help labels
+		"navigate":                       "navegar",
+		"collapse/expand":                "plegar/desplegar",
+		"all":                            "todos",
+		"menu":                           "menú",
+		"quick action":                   "acción rápida",
+		"jump":                           "saltar",
+		"mark/compare":                   "marcar/comparar",
+		"pin":                            "fijar",
+		"hide/reveal":                    "ocultar/mostrar",
+		"zoom":                           "ampliar",
+		"group by":                       "agrupar",
+		"sort":                           "ordenar",
+		"details":                        "detalles",
+		"host":                           "host",
+		"new":                            "nuevo",
+		"build":                          "construir",
+		"cleanup":                        "limpieza",
+		"log strip":                      "barra de logs",
+		"audit":                          "auditoría",
+		"sizing":                         "dimensionado",
+		"quotas":                         "cuotas",
+		"ports":                          "puertos",
+		"search logs":                    "buscar en logs",
+		"open in logs":                   "abrir en logs",
+		"new search":                     "nueva búsqueda",
+		"undo":                           "deshacer",
+		"profile":                        "perfil",
+		"note":                           "nota",
+		"node":                           "nodo",
+		"host bar":                       "barra del host",
+		"units":                          "unidades",
+		"log rate":                       "tasa de logs",
+		"start times":                    "horas de inicio",
+		"image":                          "imagen",
+		"gpu":                            "GPU",
+		"read-only":                      "solo lectura",
+		"describe":                       "describir",
+		"quit":                           "salir",
+		"pause":                          "pausa",
+		"speed":                          "velocidad",
+		"select":                         "elegir",
+		"execute":                        "ejecutar",
+		"back":                           "volver",
+		"scroll":                         "desplazar",
+		"hide (continues in background)": "ocultar (sigue en segundo plano)",
+		"follow":                         "seguir",
+		"keep/remove":                    "conservar/eliminar",
+		"all/none":                       "todos/ninguno",
+		"remove ticked":                  "eliminar marcados",
+		"clear marks":                    "quitar marcas",
+		"hide listening":                 "ocultar en escucha",
+		"show listening":                 "mostrar en escucha",
+		"refresh":                        "actualizar",
+		"cycle restart policy":           "cambiar política de reinicio",
+		"copy IP":                        "copiar IP",
+		"field":                          "campo",
+		"submit":                         "enviar",
+		"clear":                          "borrar",
+		"cancel":                         "cancelar",
+		"type to filter":                 "escribe para filtrar",
+		"save CSV":                       "guardar CSV",
+		"sort by memory":                 "ordenar por memoria",
+		"sort by CPU":                    "ordenar por CPU",
+		"continue":                       "continuar",
+		"pan":                            "desplazar",
+		"expand JSON":                    "desplegar JSON",
+		"JSON":                           "JSON",
+		"level":                          "nivel",
+		"wrap":                           "ajustar",
+		"colors":                         "colores",
+		"time":                           "hora",
+		"delta":                          "intervalo",
+
+		// Menu labels
+		"Restart All":                         "Reiniciar todos",
+		"Stop All":                            "Detener todos",
+		"Down (stop & remove, keeps volumes)": "Down (detener y eliminar, conserva volúmenes)",
+		"Start All":                           "Iniciar todos",
+		"Start All in Dependency Order":       "Iniciar todos por dependencias",
+		"Edit compose file & redeploy":        "Editar archivo compose y redesplegar",
+		"Restart":                             "Reiniciar",
+		"Stop":                                "Detener",
+		"Stop (custom timeout)…":              "Detener (tiempo de espera propio)…",
+		"Processes":                           "Procesos",
+		"Connections":                         "Conexiones",
+		"Send signal…":                        "Enviar señal…",
+		"Attach":                              "Adjuntar",
+		"Remove (keeps volumes)":              "Eliminar (conserva volúmenes)",
+		"Start":                               "Iniciar",
+		"Logs":                                "Logs",
+		"Run healthcheck":                     "Ejecutar healthcheck",
+		"Details":                             "Detalles",
+		"Checkpoints…":                        "Checkpoints…",
+		"Resource limits…":                    "Límites de recursos…",
+		"Cycle restart policy":                "Cambiar política de reinicio",
+		"Security":                            "Seguridad",
+		"Labels":                              "Etiquetas",
+		"Export filesystem…":                  "Exportar sistema de archivos…",
+		"Build image…":                        "Construir imagen…",
+		"Pull image":                          "Descargar imagen",
+		"Tag & push image…":                   "Etiquetar y subir imagen…",
+		"Save image…":                         "Guardar imagen…",
+		"Cancel":                              "Cancelar",
+		"Clear project marks":                 "Quitar marcas de proyectos",
+		"Redeploy (docker compose up -d)":     "Redesplegar (docker compose up -d)",
+		"Not now":                             "Ahora no",
+
+		// Form fields
+		"Name":           "Nombre",
+		"Image":          "Imagen",
+		"Ports":          "Puertos",
+		"Env":            "Entorno",
+		"Volumes":        "Volúmenes",
+		"Memory":         "Memoria",
+		"CPUs":           "CPUs",
+		"Restart policy": "Política de reinicio",
+		"Tag":            "Etiqueta",
+		"Context":        "Contexto",
+		"Dockerfile":     "Dockerfile",
+		"Push as":        "Subir como",
+		"Recreate":       "Recrear",
+		"Username":       "Usuario",
+		"Password":       "Contraseña",
+		"Path":           "Ruta",
+		"Lines":          "Líneas",
+		"Grace period":   "Periodo de gracia",
+		"Note":           "Nota",
+		"Pattern":        "Patrón",
+		"Since":          "Desde",
+		"Containers":     "Contenedores",
+	},
+}
@@ -0,0 +1,138 @@
+// Package i18n translates dtop's interface text and formats numbers and dates for the
+// selected language. Messages are keyed by their English text, so untranslated ones
+// fall back to it.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// language is the selected language; English until Set is called
+var language = "en"
+
+// locale is how a language formats numbers and dates
+type locale struct {
+	decimal   string     // Decimal separator
+	months    [12]string // Abbreviated month names
+	shortDate string     // Day and month, with "Jan" and "02" as in time.Format
+}
+
+var locales = map[string]locale{
+	"en": {
+		decimal:   ".",
+		months:    [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		shortDate: "Jan02",
+	},
+	"de": {
+		decimal:   ",",
+		months:    [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+		shortDate: "02.Jan",
+	},
+	"es": {
+		decimal:   ",",
+		months:    [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sep", "oct", "nov", "dic"},
+		shortDate: "02 Jan",
+	},
+}
+
+// Supported lists the languages dtop has translations for
+func Supported() []string {
+	languages := make([]string, 0, len(locales))
+	for lang := range locales {
+		languages = append(languages, lang)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// Set selects the language; an empty or unsupported one selects the language of the
+// environment, falling back to English
+func Set(lang string) {
+	if _, ok := locales[lang]; !ok {
+		lang = Detect()
+	}
+	language = lang
+}
+
+// Language returns the selected language
+func Language() string {
+	return language
+}
+
+// Detect returns the supported language named by LC_ALL, LC_MESSAGES or LANG, in
+// the order the C library checks them, e.g. "de" for de_DE.UTF-8; English otherwise
+func Detect() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		lang := strings.ToLower(value)
+		if i := strings.IndexAny(lang, "_.@-"); i >= 0 {
+			lang = lang[:i]
+		}
+		if _, ok := locales[lang]; ok {
+			return lang
+		}
+		// The first variable set decides, even when it names a language without
+		// translations (or C/POSIX)
+		return "en"
+	}
+	return "en"
+}
+
+// T translates a message, or returns it unchanged when there is no translation
+func T(msg string) string {
+	if translated, ok := catalogs[language][msg]; ok {
+		return translated
+	}
+	return msg
+}
+
+// Sprintf translates a format string and formats it
+func Sprintf(format string, args ...any) string {
+	return fmt.Sprintf(T(format), args...)
+}
+
+// Help translates a help line of "keys:label" entries separated by two spaces,
+// label by label, e.g. "↑↓:scroll  q/esc:back"
+func Help(help string) string {
+	if language == "en" {
+		return help
+	}
+	entries := strings.Split(help, "  ")
+	for i, entry := range entries {
+		// The key may itself be a colon, as in "::jump"
+		colon := -1
+		if len(entry) > 1 {
+			colon = strings.Index(entry[1:], ":") + 1
+		}
+		if colon <= 0 {
+			entries[i] = T(entry)
+			continue
+		}
+		entries[i] = entry[:colon+1] + T(entry[colon+1:])
+	}
+	return strings.Join(entries, "  ")
+}
+
+// Number formats a number with the language's decimal separator
+func Number(format string, args ...any) string {
+	text := fmt.Sprintf(format, args...)
+	if sep := locales[language].decimal; sep != "." {
+		text = strings.ReplaceAll(text, ".", sep)
+	}
+	return text
+}
+
+// ShortDate formats the day and month of t, e.g. "Mar14" in English or "14.Mär" in
+// German
+func ShortDate(t time.Time) string {
+	l := locales[language]
+	layout := strings.Replace(l.shortDate, "Jan", "\x00", 1)
+	return strings.Replace(t.Format(layout), "\x00", l.months[t.Month()-1], 1)
+}
@@ -1,11 +1,14 @@
 package model
 
 import (
+	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/ekinertac/dtop/docker"
+	"github.com/ekinertac/dtop/i18n"
 )
 
 type NodeType int
@@ -28,6 +31,10 @@ type Tree struct {
 	Root     *TreeNode
 	Flat     []*TreeNode // Flattened view for navigation
 	Selected int
+	Zoom     string // Project shown on its own, or "" for the whole tree
+
+	// Ungrouped lists the containers without project rows (GroupByNone)
+	Ungrouped bool
 }
 
 // ParseProjectName extracts the project name from a container name
@@ -50,19 +57,227 @@ func ParseProjectName(containerName string) string {
 	return containerName
 }
 
-// BuildTree groups containers by project prefix
+// StandaloneProject is the group holding containers that aren't part of a compose project
+const StandaloneProject = "(standalone)"
+
+// ComposeProjectLabel is set by docker compose on every container it manages
+const ComposeProjectLabel = docker.ComposeProjectLabel
+
+// Labels docker compose sets on every container it manages, identifying the service
+// and the replica number within it
+const (
+	ComposeServiceLabel = docker.ComposeServiceLabel
+	ComposeNumberLabel  = "com.docker.compose.container-number"
+)
+
+// ServiceReplica returns the compose service and replica number of a container, and
+// false for containers not managed by compose
+func ServiceReplica(c *docker.ContainerInfo) (string, int, bool) {
+	service := c.Labels[ComposeServiceLabel]
+	if service == "" {
+		return "", 0, false
+	}
+	number, err := strconv.Atoi(c.Labels[ComposeNumberLabel])
+	if err != nil {
+		return "", 0, false
+	}
+	return service, number, true
+}
+
+// DisplayName is how a container is shown in the tree: "service #replica" for
+// compose containers, otherwise the container name
+func DisplayName(c *docker.ContainerInfo) string {
+	if service, number, ok := ServiceReplica(c); ok {
+		return fmt.Sprintf("%s #%d", service, number)
+	}
+	return c.Name
+}
+
+// UnlabeledProject holds containers without the label the tree is grouped by
+const UnlabeledProject = "(unlabeled)"
+
+// NoNetworkProject holds containers attached to no network when grouping by network
+const NoNetworkProject = "(no network)"
+
+// AllProject is the single group holding every container when the tree isn't grouped
+const AllProject = "(all)"
+
+// CatchAll reports whether a group collects containers that belong to no real
+// project, such as StandaloneProject
+func CatchAll(name string) bool {
+	switch name {
+	case StandaloneProject, UnlabeledProject, NoNetworkProject, AllProject:
+		return true
+	}
+	return false
+}
+
+// Grouping selects what the tree groups containers by
+type Grouping string
+
+const (
+	GroupByProject Grouping = "project" // Compose project, GroupLabel or name prefix
+	GroupByImage   Grouping = "image"
+	GroupByNetwork Grouping = "network" // First attached network, alphabetically
+	GroupByStatus  Grouping = "status"
+	GroupByNone    Grouping = "none" // One flat list
+)
+
+// Groupings lists the groupings in the order the UI cycles through them
+var Groupings = []Grouping{GroupByProject, GroupByImage, GroupByNetwork, GroupByStatus, GroupByNone}
+
+// Order sorts the containers within each group
+type Order string
+
+const (
+	OrderByName   Order = "name"   // Service and replica number, then name
+	OrderByCPU    Order = "cpu"    // Busiest first
+	OrderByMemory Order = "memory" // Largest first
+	OrderByUptime Order = "uptime" // Most recently started first, stopped containers last
+)
+
+// Orders lists the orders in the order the UI cycles through them
+var Orders = []Order{OrderByName, OrderByCPU, OrderByMemory, OrderByUptime}
+
+// before reports whether a comes before b in the order; false for ties
+func (o Order) before(a, b *docker.ContainerInfo) bool {
+	switch o {
+	case OrderByCPU:
+		return a.CPUPerc > b.CPUPerc
+	case OrderByMemory:
+		return a.Memory.Usage > b.Memory.Usage
+	case OrderByUptime:
+		return a.StartedAt.After(b.StartedAt)
+	}
+	return false
+}
+
+// FavoritesProject is the group at the top of the tree holding pinned containers
+const FavoritesProject = "★ Favorites"
+
+// FavoriteKey identifies a pinned container or project by name, so pins survive
+// containers being recreated
+func FavoriteKey(node *TreeNode) string {
+	if node.Container != nil {
+		return "container:" + node.Container.Name
+	}
+	return "project:" + node.Name
+}
+
+// TreeOptions controls how containers are grouped into projects
+type TreeOptions struct {
+	// GroupStandalone collects non-compose containers under StandaloneProject
+	// instead of deriving a project from each container's name prefix
+	GroupStandalone bool
+
+	// GroupLabel, if set, groups containers by the value of this label instead of
+	// by compose project; containers without it go under UnlabeledProject
+	GroupLabel string
+
+	// GroupBy groups containers by something other than their project; empty means
+	// GroupByProject. GroupByNone lists the containers without project rows.
+	GroupBy Grouping
+
+	// Order sorts the containers within each group by usage or uptime; empty means
+	// OrderByName
+	Order Order
+
+	// ProjectFor, if set, maps a container name to the project it is shown under,
+	// overriding the grouping above; "" groups the container as usual
+	ProjectFor func(containerName string) string
+
+	// Favorites holds the FavoriteKey of pinned nodes. Pinned containers are moved
+	// to FavoritesProject and pinned projects are listed first.
+	Favorites map[string]bool
+}
+
+// ProjectName returns the group a container belongs to. Unless opts.GroupBy says
+// otherwise that is its project: the project a rule maps it to, the compose project
+// label when present, otherwise the standalone group or the name prefix.
+func ProjectName(c *docker.ContainerInfo, opts TreeOptions) string {
+	switch opts.GroupBy {
+	case GroupByImage:
+		return docker.ShortImage(c.Image)
+	case GroupByNetwork:
+		if len(c.Networks) == 0 {
+			return NoNetworkProject
+		}
+		return c.Networks[0]
+	case GroupByStatus:
+		if strings.Contains(c.Status, "(unhealthy)") {
+			return "unhealthy"
+		}
+		return c.State
+	case GroupByNone:
+		return AllProject
+	}
+	if opts.ProjectFor != nil {
+		if project := opts.ProjectFor(c.Name); project != "" {
+			return project
+		}
+	}
+	if opts.GroupLabel != "" {
+		if value := c.Labels[opts.GroupLabel]; value != "" {
+			return value
+		}
+		return UnlabeledProject
+	}
+	if project := c.Labels[ComposeProjectLabel]; project != "" {
+		return project
+	}
+	if opts.GroupStandalone {
+		return StandaloneProject
+	}
+	return ParseProjectName(c.Name)
+}
+
+// BuildTree groups containers by compose project, falling back to the name prefix
 func BuildTree(containers []docker.ContainerInfo) *Tree {
-	root := &TreeNode{
-		Type:     NodeTypeProject,
-		Name:     "root",
-		Expanded: true,
-		Children: []*TreeNode{},
+	return BuildTreeWithOptions(containers, TreeOptions{})
+}
+
+// BuildTreeWithOptions groups containers into projects according to opts
+func BuildTreeWithOptions(containers []docker.ContainerInfo, opts TreeOptions) *Tree {
+	tree := &Tree{}
+	tree.Update(containers, opts)
+	return tree
+}
+
+// Update merges a fresh container list into the tree in place. Nodes are matched by
+// project name and container ID, so existing nodes keep their identity, expansion
+// state and last stats sample, and the selection follows the selected node.
+func (t *Tree) Update(containers []docker.ContainerInfo, opts TreeOptions) {
+	if t.Root == nil {
+		t.Root = &TreeNode{
+			Type:     NodeTypeProject,
+			Name:     "root",
+			Expanded: true,
+			Children: []*TreeNode{},
+		}
 	}
+	selected := t.GetSelected()
+	selectedIndex := t.Selected
+	t.Ungrouped = opts.GroupBy == GroupByNone
 
-	// Group containers by project
-	projects := make(map[string][]*docker.ContainerInfo)
+	// Index existing nodes
+	projectNodes := make(map[string]*TreeNode, len(t.Root.Children))
+	containerNodes := make(map[string]*TreeNode, len(containers))
+	for _, project := range t.Root.Children {
+		projectNodes[project.Name] = project
+		for _, child := range project.Children {
+			if child.Container != nil {
+				containerNodes[child.Container.ID] = child
+			}
+		}
+	}
+
+	// Group containers by project; pointers keep regrouping large lists cheap
+	projects := make(map[string][]*docker.ContainerInfo, len(projectNodes))
 	for i := range containers {
-		projectName := ParseProjectName(containers[i].Name)
+		projectName := ProjectName(&containers[i], opts)
+		if opts.Favorites["container:"+containers[i].Name] && !t.Ungrouped {
+			projectName = FavoritesProject
+		}
 		projects[projectName] = append(projects[projectName], &containers[i])
 	}
 
@@ -71,53 +286,201 @@ func BuildTree(containers []docker.ContainerInfo) *Tree {
 	for name := range projects {
 		projectNames = append(projectNames, name)
 	}
-	sort.Strings(projectNames)
+	// Standalone/unlabeled containers always go last so real projects stand out,
+	// and favorites always go first
+	rank := func(name string) int {
+		switch {
+		case name == FavoritesProject:
+			return 0
+		case opts.Favorites["project:"+name]:
+			return 1
+		case CatchAll(name):
+			return 3
+		}
+		return 2
+	}
+	sort.Slice(projectNames, func(i, j int) bool {
+		if rank(projectNames[i]) != rank(projectNames[j]) {
+			return rank(projectNames[i]) < rank(projectNames[j])
+		}
+		return projectNames[i] < projectNames[j]
+	})
 
-	// Build tree structure in alphabetical order
+	// Rebuild the child lists from existing nodes where possible
+	t.Root.Children = make([]*TreeNode, 0, len(projectNames))
 	for _, projectName := range projectNames {
 		containers := projects[projectName]
-		
-		// Sort containers within project alphabetically
+
+		// Sort containers within project by service and replica number (so web #2
+		// comes before web #10), then alphabetically
 		sort.Slice(containers, func(i, j int) bool {
-			return containers[i].Name < containers[j].Name
+			si, ni, iok := ServiceReplica(containers[i])
+			sj, nj, jok := ServiceReplica(containers[j])
+			if iok && jok && si == sj {
+				return ni < nj
+			}
+			return DisplayName(containers[i]) < DisplayName(containers[j])
 		})
 
-		projectNode := &TreeNode{
-			Type:     NodeTypeProject,
-			Name:     projectName,
-			Expanded: true,
-			Parent:   root,
-			Children: []*TreeNode{},
+		projectNode, exists := projectNodes[projectName]
+		if !exists {
+			projectNode = &TreeNode{
+				Type:     NodeTypeProject,
+				Name:     projectName,
+				Expanded: true,
+			}
 		}
+		projectNode.Parent = t.Root
+		projectNode.Children = make([]*TreeNode, 0, len(containers))
 
 		for _, container := range containers {
-			containerNode := &TreeNode{
-				Type:      NodeTypeContainer,
-				Name:      container.Name,
-				Container: container,
-				Parent:    projectNode,
+			containerNode, exists := containerNodes[container.ID]
+			if exists {
+				// Keep the last stats sample until fresh stats arrive
+				stats := containerNode.Container.ContainerStats
+				*containerNode.Container = *container
+				containerNode.Container.ContainerStats = stats
+				containerNode.Name = container.Name
+			} else {
+				info := *container
+				containerNode = &TreeNode{
+					Type:      NodeTypeContainer,
+					Name:      container.Name,
+					Container: &info,
+				}
 			}
+			containerNode.Parent = projectNode
 			projectNode.Children = append(projectNode.Children, containerNode)
 		}
 
-		root.Children = append(root.Children, projectNode)
+		// Stats are sorted on as carried over from the last sample; the list itself
+		// has none
+		if opts.Order != "" && opts.Order != OrderByName {
+			sort.SliceStable(projectNode.Children, func(i, j int) bool {
+				return opts.Order.before(projectNode.Children[i].Container, projectNode.Children[j].Container)
+			})
+		}
+
+		t.Root.Children = append(t.Root.Children, projectNode)
 	}
 
-	tree := &Tree{
-		Root:     root,
-		Selected: 0,
+	t.UpdateFlatView()
+
+	// Follow the selected node; if it disappeared, stay at the same position
+	t.Selected = selectedIndex
+	for i, node := range t.Flat {
+		if node == selected {
+			t.Selected = i
+			break
+		}
+	}
+	if t.Selected >= len(t.Flat) {
+		t.Selected = len(t.Flat) - 1
+	}
+	if t.Selected < 0 {
+		t.Selected = 0
 	}
-	tree.UpdateFlatView()
+}
 
-	return tree
+// ProjectSummary aggregates the health and resource usage of a project's containers
+type ProjectSummary struct {
+	Running   int
+	Stopped   int // Not in the tree, which only lists running containers; left to the caller
+	Unhealthy int
+	CPUPerc   float64 // Sum of container CPU percentages
+	MemUsage  uint64  // Sum of container memory usage in bytes
+}
+
+// Summary aggregates the containers directly under a project node
+func (n *TreeNode) Summary() ProjectSummary {
+	var s ProjectSummary
+	for _, child := range n.Children {
+		c := child.Container
+		if c == nil {
+			continue
+		}
+		switch {
+		case strings.Contains(c.Status, "(unhealthy)"):
+			s.Unhealthy++
+		case c.State == "running":
+			s.Running++
+		}
+		s.CPUPerc += c.CPUPerc
+		s.MemUsage += c.Memory.Usage
+	}
+	return s
 }
 
 // UpdateFlatView creates a flattened view of visible nodes for navigation
 func (t *Tree) UpdateFlatView() {
-	t.Flat = []*TreeNode{}
+	t.Flat = make([]*TreeNode, 0, t.visibleCount())
+
+	// A zoomed project shows just its containers; zoom ends if the project disappears
+	if t.Zoom != "" {
+		for _, project := range t.Root.Children {
+			if project.Name == t.Zoom {
+				t.Flat = append(t.Flat, project.Children...)
+				return
+			}
+		}
+		t.Zoom = ""
+	}
+
+	// Without grouping there is one catch-all project, shown as just its containers
+	if t.Ungrouped {
+		for _, project := range t.Root.Children {
+			t.Flat = append(t.Flat, project.Children...)
+		}
+		return
+	}
+
 	t.flattenNode(t.Root, 0)
 }
 
+// ZoomIn shows only the project of the selected node, keeping the selection
+func (t *Tree) ZoomIn() {
+	node := t.GetSelected()
+	if node == nil || t.Ungrouped {
+		return
+	}
+	project := node
+	if node.Type == NodeTypeContainer {
+		project = node.Parent
+	}
+	if project == nil || len(project.Children) == 0 {
+		return
+	}
+
+	t.Zoom = project.Name
+	t.UpdateFlatView()
+	if node == project {
+		t.Selected = 0
+	} else {
+		t.Select(node)
+	}
+}
+
+// ZoomOut returns to the whole tree, keeping the selection
+func (t *Tree) ZoomOut() {
+	node := t.GetSelected()
+	t.Zoom = ""
+	t.UpdateFlatView()
+	if node != nil {
+		t.Select(node)
+	}
+}
+
+// visibleCount is the number of nodes the flat view holds, so it is allocated once
+func (t *Tree) visibleCount() int {
+	count := len(t.Root.Children)
+	for _, project := range t.Root.Children {
+		if project.Expanded || project.Name == t.Zoom {
+			count += len(project.Children)
+		}
+	}
+	return count
+}
+
 func (t *Tree) flattenNode(node *TreeNode, depth int) {
 	// Don't add root to flat view
 	if node.Type != NodeTypeProject || node.Name != "root" {
@@ -140,6 +503,69 @@ func (t *Tree) GetSelected() *TreeNode {
 	return t.Flat[t.Selected]
 }
 
+// Select selects node, expanding its project first if it is collapsed and leaving
+// the zoom if node is outside the zoomed project
+func (t *Tree) Select(node *TreeNode) {
+	if t.Zoom != "" && (node.Parent == nil || node.Parent.Name != t.Zoom) {
+		t.Zoom = ""
+		t.UpdateFlatView()
+	}
+	if node.Parent != nil && node.Parent.Name != "root" && !node.Parent.Expanded {
+		node.Parent.Expanded = true
+		t.UpdateFlatView()
+	}
+	for i, n := range t.Flat {
+		if n == node {
+			t.Selected = i
+			return
+		}
+	}
+}
+
+// Containers returns every container in the tree, including collapsed projects
+func (t *Tree) Containers() []*docker.ContainerInfo {
+	if t.Root == nil {
+		return []*docker.ContainerInfo{}
+	}
+	count := 0
+	for _, project := range t.Root.Children {
+		count += len(project.Children)
+	}
+	containers := make([]*docker.ContainerInfo, 0, count)
+	collectContainers(t.Root, &containers)
+	return containers
+}
+
+func collectContainers(node *TreeNode, containers *[]*docker.ContainerInfo) {
+	if node.Container != nil {
+		*containers = append(*containers, node.Container)
+	}
+	for _, child := range node.Children {
+		collectContainers(child, containers)
+	}
+}
+
+// FindContainer returns the node for the container with the given ID, including
+// containers inside collapsed projects
+func (t *Tree) FindContainer(id string) *TreeNode {
+	if t.Root == nil {
+		return nil
+	}
+	return findContainer(t.Root, id)
+}
+
+func findContainer(node *TreeNode, id string) *TreeNode {
+	if node.Container != nil && node.Container.ID == id {
+		return node
+	}
+	for _, child := range node.Children {
+		if found := findContainer(child, id); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
 // MoveUp moves selection up
 func (t *Tree) MoveUp() {
 	if t.Selected > 0 {
@@ -163,6 +589,24 @@ func (t *Tree) ToggleExpanded() {
 	}
 }
 
+// SetAllExpanded expands or collapses every project. When collapsing, a selected
+// container moves the selection to its project so it stays visible.
+func (t *Tree) SetAllExpanded(expanded bool) {
+	selected := t.GetSelected()
+	if !expanded && selected != nil && selected.Type == NodeTypeContainer && selected.Parent != nil {
+		selected = selected.Parent
+	}
+
+	for _, node := range t.Root.Children {
+		if node.Type == NodeTypeProject {
+			node.Expanded = expanded
+		}
+	}
+	t.UpdateFlatView()
+
+	t.RestoreSelection(t.GetNodePath(selected))
+}
+
 // GetDepth returns the depth of a node in the tree
 func (t *Tree) GetDepth(node *TreeNode) int {
 	depth := 0
@@ -213,49 +657,96 @@ func (t *Tree) RestoreSelection(path string) {
 
 // FormatUptime formats the container uptime
 func FormatUptime(created time.Time) string {
-	duration := time.Since(created)
-	
-	days := int(duration.Hours() / 24)
-	hours := int(duration.Hours()) % 24
-	minutes := int(duration.Minutes()) % 60
-	
-	if days > 0 {
-		return formatDuration(days, hours, minutes, "d", "h", "m")
-	}
-	if hours > 0 {
-		return formatDuration(hours, minutes, 0, "h", "m", "")
-	}
-	return formatDuration(minutes, 0, 0, "m", "", "")
+	return HumanizeDuration(time.Since(created))
 }
 
-func formatDuration(a, b, c int, aUnit, bUnit, cUnit string) string {
-	result := ""
-	if a > 0 {
-		result += formatUnit(a, aUnit)
-	}
-	if b > 0 {
-		if result != "" {
-			result += " "
+// ContainerUptime describes how long a container has been in its current state:
+// the uptime since its last start while running, otherwise the state and time since
+// it exited
+func ContainerUptime(c *docker.ContainerInfo) string {
+	switch c.State {
+	case "running":
+		return FormatUptime(startTime(c))
+	case "exited", "dead":
+		if c.FinishedAt.IsZero() {
+			return c.State
 		}
-		result += formatUnit(b, bUnit)
+		return "exit " + HumanizeDuration(time.Since(c.FinishedAt))
+	default:
+		// restarting, paused, created, removing
+		return c.State
 	}
-	if c > 0 && cUnit != "" {
-		if result != "" {
-			result += " "
+}
+
+// ContainerStarted is ContainerUptime with absolute times: when a running container
+// started or when a stopped one exited
+func ContainerStarted(c *docker.ContainerInfo) string {
+	switch c.State {
+	case "running":
+		return FormatStartTime(startTime(c))
+	case "exited", "dead":
+		if c.FinishedAt.IsZero() {
+			return c.State
 		}
-		result += formatUnit(c, cUnit)
+		return "exit " + FormatStartTime(c.FinishedAt)
+	default:
+		return c.State
 	}
-	return result
 }
 
-func formatUnit(value int, unit string) string {
-	if unit == "" {
-		return ""
+// startTime is when a running container last started, falling back to its creation
+// time when that's unknown (e.g. in recordings made before it was tracked)
+func startTime(c *docker.ContainerInfo) time.Time {
+	if c.StartedAt.IsZero() {
+		return c.CreatedAt
+	}
+	return c.StartedAt
+}
+
+// FormatStartTime formats a time the way ps shows start times: "15:04" for today,
+// the day and month for this year ("Jan02" in English), otherwise the year
+func FormatStartTime(t time.Time) string {
+	t = t.Local()
+	now := time.Now()
+	switch {
+	case t.YearDay() == now.YearDay() && t.Year() == now.Year():
+		return t.Format("15:04")
+	case t.Year() == now.Year():
+		return i18n.ShortDate(t)
+	default:
+		return t.Format("2006")
 	}
-	return formatInt(value) + unit
 }
 
-func formatInt(value int) string {
-	return string(rune('0' + value/10)) + string(rune('0' + value%10))
+// HumanizeDuration formats a duration compactly with its two most significant
+// units, e.g. "45s", "3m12s", "5h 20m", "2d 4h", "5w 2d"
+func HumanizeDuration(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+
+	const (
+		day  = 24 * time.Hour
+		week = 7 * day
+	)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d/time.Second))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d/time.Minute), int(d%time.Minute/time.Second))
+	case d < day:
+		return joinUnits(int(d/time.Hour), "h", int(d%time.Hour/time.Minute), "m")
+	case d < week:
+		return joinUnits(int(d/day), "d", int(d%day/time.Hour), "h")
+	default:
+		return joinUnits(int(d/week), "w", int(d%week/day), "d")
+	}
 }
 
+// joinUnits formats "2d 4h", dropping the minor unit when it is zero
+func joinUnits(major int, majorUnit string, minor int, minorUnit string) string {
+	if minor == 0 {
+		return fmt.Sprintf("%d%s", major, majorUnit)
+	}
+	return fmt.Sprintf("%d%s %d%s", major, majorUnit, minor, minorUnit)
+}
@@ -0,0 +1,117 @@
+package model
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ekinertac/dtop/docker"
+)
+
+// benchContainers returns n running containers spread over compose projects of 20
+func benchContainers(n int) []docker.ContainerInfo {
+	containers := make([]docker.ContainerInfo, n)
+	for i := range containers {
+		project := fmt.Sprintf("project-%02d", i/20)
+		service := fmt.Sprintf("service-%02d", i%20)
+		containers[i] = docker.ContainerInfo{
+			ID:        fmt.Sprintf("%064x", i),
+			Name:      project + "-" + service + "-1",
+			Image:     "example/" + service + ":latest",
+			State:     "running",
+			Status:    "Up 2 hours",
+			CreatedAt: time.Now().Add(-2 * time.Hour),
+			Labels: map[string]string{
+				ComposeProjectLabel: project,
+				ComposeServiceLabel: service,
+				ComposeNumberLabel:  "1",
+			},
+			ContainerStats: docker.ContainerStats{CPUPerc: float64(i % 100), MemPerc: 12.5, MemUsage: "64MiB / 512MiB"},
+		}
+	}
+	return containers
+}
+
+func BenchmarkTreeUpdate(b *testing.B) {
+	containers := benchContainers(500)
+	tree := &Tree{}
+	tree.Update(containers, TreeOptions{GroupStandalone: true})
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		containers[i%len(containers)].CPUPerc = float64(i % 100)
+		tree.Update(containers, TreeOptions{GroupStandalone: true})
+	}
+}
+
+func BenchmarkTreeFlatten(b *testing.B) {
+	tree := &Tree{}
+	tree.Update(benchContainers(500), TreeOptions{GroupStandalone: true})
+	tree.SetAllExpanded(true)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.UpdateFlatView()
+	}
+}
+
+func TestHumanizeDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{-time.Second, "0s"},
+		{0, "0s"},
+		{45 * time.Second, "45s"},
+		{time.Minute, "1m00s"},
+		{3*time.Minute + 12*time.Second, "3m12s"},
+		{time.Hour, "1h"},
+		{5*time.Hour + 20*time.Minute + 30*time.Second, "5h 20m"},
+		{24 * time.Hour, "1d"},
+		{2*24*time.Hour + 4*time.Hour, "2d 4h"},
+		{7 * 24 * time.Hour, "1w"},
+		{5*7*24*time.Hour + 2*24*time.Hour + time.Hour, "5w 2d"},
+	}
+	for _, tt := range tests {
+		if got := HumanizeDuration(tt.d); got != tt.want {
+			t.Errorf("HumanizeDuration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestProjectSummary(t *testing.T) {
+	container := func(state, status string, cpu float64, mem uint64) *TreeNode {
+		c := &docker.ContainerInfo{State: state, Status: status}
+		c.CPUPerc = cpu
+		c.Memory.Usage = mem
+		return &TreeNode{Type: NodeTypeContainer, Container: c}
+	}
+
+	tests := []struct {
+		name     string
+		children []*TreeNode
+		want     ProjectSummary
+	}{
+		{"empty", nil, ProjectSummary{}},
+		{
+			"running and unhealthy",
+			[]*TreeNode{
+				container("running", "Up 2 hours (healthy)", 10, 100),
+				container("running", "Up 5 minutes", 2.5, 50),
+				container("running", "Up 1 hour (unhealthy)", 1, 25),
+			},
+			ProjectSummary{Running: 2, Unhealthy: 1, CPUPerc: 13.5, MemUsage: 175},
+		},
+		{
+			"nodes without a container are skipped",
+			[]*TreeNode{{Type: NodeTypeProject}, container("running", "Up 3 days", 4, 10)},
+			ProjectSummary{Running: 1, CPUPerc: 4, MemUsage: 10},
+		},
+	}
+	for _, tt := range tests {
+		node := &TreeNode{Type: NodeTypeProject, Children: tt.children}
+		if got := node.Summary(); got != tt.want {
+			t.Errorf("%s: Summary() = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
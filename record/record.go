@@ -0,0 +1,111 @@
+package record
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ekinertac/dtop/docker"
+)
+
+// Frame is one recorded update: a stats sample for one container when Stats is set,
+// a daemon event when Event is set, otherwise a container list
+type Frame struct {
+	Time        time.Time              `json:"time"`
+	Containers  []docker.ContainerInfo `json:"containers,omitempty"`
+	ContainerID string                 `json:"container_id,omitempty"`
+	Stats       *docker.ContainerStats `json:"stats,omitempty"`
+	Event       *docker.ContainerEvent `json:"event,omitempty"`
+}
+
+// Recorder appends frames to a file as JSON lines
+type Recorder struct {
+	mu   sync.Mutex
+	file *os.File
+	enc  *json.Encoder
+}
+
+// Create starts a recording, truncating path if it exists
+func Create(path string) (*Recorder, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Recorder{file: f, enc: json.NewEncoder(f)}, nil
+}
+
+// Containers records a container list. A nil recorder records nothing.
+func (r *Recorder) Containers(containers []docker.ContainerInfo) {
+	if r == nil {
+		return
+	}
+	r.write(Frame{Time: time.Now(), Containers: containers})
+}
+
+// Stats records a stats sample for one container. A nil recorder records nothing.
+func (r *Recorder) Stats(containerID string, stats docker.ContainerStats) {
+	if r == nil {
+		return
+	}
+	r.write(Frame{Time: time.Now(), ContainerID: containerID, Stats: &stats})
+}
+
+// Event records a daemon event. A nil recorder records nothing.
+func (r *Recorder) Event(e docker.ContainerEvent) {
+	if r == nil {
+		return
+	}
+	r.write(Frame{Time: time.Now(), Event: &e})
+}
+
+func (r *Recorder) write(frame Frame) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// A failed write only loses the frame; monitoring carries on
+	r.enc.Encode(frame)
+}
+
+// Close finishes the recording
+func (r *Recorder) Close() error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
+
+// Load reads every frame of a recording, oldest first
+func Load(path string) ([]Frame, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	frames := []Frame{}
+	scanner := bufio.NewScanner(f)
+	// Container lists for large hosts don't fit the default 64KB line limit
+	scanner.Buffer(make([]byte, 0, 1024*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var frame Frame
+		if err := json.Unmarshal(scanner.Bytes(), &frame); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		frames = append(frames, frame)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(frames) == 0 {
+		return nil, fmt.Errorf("%s: recording is empty", path)
+	}
+	return frames, nil
+}
@@ -0,0 +1,275 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ekinertac/dtop/docker"
+	"github.com/ekinertac/dtop/model"
+	"github.com/mattn/go-runewidth"
+)
+
+// accessibleMode renders for screen readers. The helpers shared by every view check
+// it to leave out bars, graphs, spinners and box borders, which screen readers
+// either skip or spell out glyph by glyph.
+var accessibleMode bool
+
+// applyAccessible switches accessible rendering on or off
+func applyAccessible(on bool) {
+	accessibleMode = on
+	modalStyle = modalStyle.Border(modalBorder())
+}
+
+// modalBorder is the border of boxed overlays; blank in accessible mode
+func modalBorder() lipgloss.Border {
+	if accessibleMode {
+		return lipgloss.HiddenBorder()
+	}
+	return lipgloss.RoundedBorder()
+}
+
+// usageLevel puts into words what the gauge colors say about a usage percentage:
+// "critical" from danger, "high" from warn, empty below
+func usageLevel(percent, warn, danger float64) string {
+	switch {
+	case percent >= danger:
+		return "critical"
+	case percent >= warn:
+		return "high"
+	}
+	return ""
+}
+
+// spokenBytes formats a byte count for sentences, e.g. "1.2MB" or "0 bytes"
+func spokenBytes(bytes uint64) string {
+	if text := formatNetBytes(bytes); text != "0" {
+		return text + "B"
+	}
+	return "0 bytes"
+}
+
+// accessibleRow renders a tree row as a line of comma-separated facts instead of
+// columns. The selected row starts with ">" so the selection doesn't rely on the
+// highlight alone; number is the row's number key, or 0.
+func (m Model) accessibleRow(node *model.TreeNode, selected bool, number int) string {
+	var facts []string
+	switch node.Type {
+	case model.NodeTypeProject:
+		facts = m.projectFacts(node)
+	case model.NodeTypeContainer:
+		if node.Container == nil {
+			return ""
+		}
+		facts = m.containerFacts(node)
+	}
+
+	line := "  "
+	if selected {
+		line = "> "
+	}
+	line += strings.Repeat("  ", m.tree.GetDepth(node))
+	if number > 0 {
+		line += fmt.Sprintf("%d: ", number)
+	}
+	line += strings.Join(facts, ", ")
+	if m.width > 0 {
+		line = runewidth.Truncate(line, m.width, "…")
+	}
+
+	if selected {
+		return selectedStyle.Render(line)
+	}
+	if node.Type == model.NodeTypeProject {
+		return projectStyle.Render(line)
+	}
+	return containerStyle.Render(line)
+}
+
+// projectFacts lists what a project row shows
+func (m Model) projectFacts(node *model.TreeNode) []string {
+	facts := []string{"project " + node.Name}
+	if node.Expanded {
+		facts = append(facts, "expanded")
+	} else {
+		facts = append(facts, "collapsed")
+	}
+	if m.isFavorite(node) {
+		facts = append(facts, "pinned")
+	}
+	if m.projectMarks[node.Name] {
+		facts = append(facts, "marked")
+	}
+	if m.flashing(node.Name) {
+		facts = append(facts, "alert: a container crashed")
+	}
+	if progress := m.projectProgressText(node.Name); progress != "" {
+		facts = append(facts, progress)
+	}
+
+	summary := m.projectSummary(node)
+	counts := fmt.Sprintf("%d containers, %d running", len(node.Children)+summary.Stopped, summary.Running)
+	if summary.Stopped > 0 {
+		counts += fmt.Sprintf(", %d stopped", summary.Stopped)
+	}
+	if summary.Unhealthy > 0 {
+		counts += fmt.Sprintf(", %d unhealthy", summary.Unhealthy)
+	}
+	facts = append(facts, counts)
+	if !node.Expanded {
+		facts = append(facts, fmt.Sprintf("CPU %.0f%%", summary.CPUPerc), "memory "+spokenBytes(summary.MemUsage))
+	}
+	if m.noteOf(node) != "" {
+		facts = append(facts, "has a note")
+	}
+	return facts
+}
+
+// containerFacts lists what a container row shows, with words for the cues the
+// table gives by color alone
+func (m Model) containerFacts(node *model.TreeNode) []string {
+	c := node.Container
+	facts := []string{model.DisplayName(c)}
+	if node.Parent != nil && node.Parent.Name == model.FavoritesProject {
+		facts[0] = c.Name
+	}
+	if isPlaceholder(c) {
+		return append(facts, "no container yet")
+	}
+
+	state := c.Status
+	if countdown := m.stopCountdownText(c.ID); countdown != "" {
+		state = countdown
+	} else if action := m.actionText(c.ID); action != "" {
+		state = action
+	}
+	facts = append(facts, state)
+
+	if m.flashing(c.ID) {
+		facts = append(facts, "alert: just crashed or turned unhealthy")
+	}
+	if m.isMarked(c.ID) {
+		facts = append(facts, "marked")
+	}
+	if m.showHidden && m.isHidden(c) {
+		facts = append(facts, "hidden")
+	}
+
+	if c.State == "running" && !c.Remote {
+		t := m.config.Thresholds
+		cpu := fmt.Sprintf("CPU %.0f%%", c.CPUPerc)
+		if m.absoluteUnits {
+			cpu = fmt.Sprintf("CPU %.2f cores", c.CPUPerc/100)
+		}
+		if level := usageLevel(c.CPUPerc, t.CPUWarn, t.CPUDanger); level != "" {
+			cpu += " " + level
+		}
+		mem := "memory not reported"
+		if c.MemUsage != "N/A" {
+			mem = fmt.Sprintf("memory %.0f%%", c.MemPerc)
+			if m.absoluteUnits {
+				mem = "memory " + spokenBytes(c.Memory.Usage)
+			}
+			if level := usageLevel(c.MemPerc, t.MemoryWarn, t.MemoryDanger); level != "" {
+				mem += " " + level
+			}
+		}
+		facts = append(facts, cpu, mem)
+		if pidsRatio(c.PIDs, c.PIDsLimit) >= pidsWarnRatio {
+			facts = append(facts, "processes near their limit")
+		}
+		if m.showLogRate && chatty(c) {
+			facts = append(facts, "chatty logs")
+		}
+	}
+	if c.Remote {
+		facts = append(facts, "on node "+c.Node)
+	}
+
+	if c.ImageDangling {
+		facts = append(facts, "image lost its tag")
+	}
+	if m.noteOf(node) != "" {
+		facts = append(facts, "has a note")
+	}
+	return facts
+}
+
+// describeSelected puts the selected row into full sentences, for the status bar
+func (m Model) describeSelected() string {
+	node := m.tree.GetSelected()
+	switch {
+	case node == nil:
+		return "Nothing is selected"
+	case node.Type == model.NodeTypeProject:
+		return m.describeProject(node)
+	case node.Container != nil:
+		return m.describeContainer(node)
+	}
+	return ""
+}
+
+// describeProject describes a project and its combined usage
+func (m Model) describeProject(node *model.TreeNode) string {
+	summary := m.projectSummary(node)
+	text := fmt.Sprintf("Project %s has %d containers: %d running, %d stopped and %d unhealthy.",
+		node.Name, len(node.Children)+summary.Stopped, summary.Running, summary.Stopped, summary.Unhealthy)
+	if summary.Running > 0 {
+		text += fmt.Sprintf(" Together they use %.1f%% CPU and %s of memory.", summary.CPUPerc, spokenBytes(summary.MemUsage))
+	}
+	if node.Expanded {
+		text += " It is expanded."
+	} else {
+		text += " It is collapsed; press right to expand it."
+	}
+	if note := m.noteOf(node); note != "" {
+		text += " Note: " + note
+	}
+	return text
+}
+
+// describeContainer describes a container: where it belongs, its state and usage
+func (m Model) describeContainer(node *model.TreeNode) string {
+	c := node.Container
+	if isPlaceholder(c) {
+		return fmt.Sprintf("%s is a service of project %s with no container yet.", c.Name, node.Parent.Name)
+	}
+
+	text := fmt.Sprintf("%s is %s", c.Name, c.State)
+	if node.Parent != nil && !model.CatchAll(node.Parent.Name) && node.Parent.Name != model.FavoritesProject {
+		text += " in project " + node.Parent.Name
+	}
+	text += fmt.Sprintf(" (%s), from image %s.", c.Status, docker.ShortImage(c.Image))
+
+	switch {
+	case c.Remote:
+		text += fmt.Sprintf(" It runs on node %s, so its usage isn't available here.", c.Node)
+	case c.State == "running":
+		text += fmt.Sprintf(" It uses %.1f%% CPU, or %.2f cores", c.CPUPerc, c.CPUPerc/100)
+		if c.Memory.Reported {
+			text += fmt.Sprintf(", and %s of %s memory, %.0f%%", spokenBytes(c.Memory.Usage), spokenBytes(c.Memory.Limit), c.MemPerc)
+		}
+		text += fmt.Sprintf(". It has received %s and sent %s over the network, and runs %d processes.",
+			spokenBytes(c.NetRx), spokenBytes(c.NetTx), c.PIDs)
+		t := m.config.Thresholds
+		if level := usageLevel(c.CPUPerc, t.CPUWarn, t.CPUDanger); level != "" {
+			text += " CPU usage is " + level + "."
+		}
+		if level := usageLevel(c.MemPerc, t.MemoryWarn, t.MemoryDanger); level != "" && c.Memory.Reported {
+			text += " Memory usage is " + level + "."
+		}
+	case c.State == "exited" || c.State == "dead":
+		text += fmt.Sprintf(" It exited with code %d.", c.ExitCode)
+	}
+
+	if len(c.Ports) > 0 {
+		text += " Ports: " + strings.Join(c.Ports, ", ") + "."
+	}
+	if c.ImageDangling {
+		text += " Its image has lost its tag."
+	}
+	if note := m.noteOf(node); note != "" {
+		text += " Note: " + note
+	}
+	return text
+}
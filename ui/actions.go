@@ -0,0 +1,196 @@
+package ui
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// actionSpinnerInterval is how often the spinner of in-flight actions advances
+const actionSpinnerInterval = 120 * time.Millisecond
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// actionQueue runs container actions one at a time per container, so a second
+// Restart waits for the first instead of racing it. It is shared by every copy of
+// the model.
+type actionQueue struct {
+	mu      sync.Mutex
+	queues  map[string][]*queuedAction // Container ID -> actions; the first one is running
+	ticking bool                       // A spinner tick is scheduled
+}
+
+// queuedAction is an action waiting for or running on a container
+type queuedAction struct {
+	verb  string // e.g. "restart"
+	label string // In-flight description, e.g. "restarting"
+	run   func() error
+	done  chan error
+}
+
+func newActionQueue() *actionQueue {
+	return &actionQueue{queues: make(map[string][]*queuedAction)}
+}
+
+// enqueue adds an action for a container and returns a channel that receives its
+// result. It returns false without queueing when the same action is already waiting
+// or running on the container.
+func (q *actionQueue) enqueue(containerID, verb, label string, run func() error) (<-chan error, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, queued := range q.queues[containerID] {
+		if queued.verb == verb {
+			return nil, false
+		}
+	}
+	action := &queuedAction{verb: verb, label: label, run: run, done: make(chan error, 1)}
+	q.queues[containerID] = append(q.queues[containerID], action)
+	if len(q.queues[containerID]) == 1 {
+		go q.work(containerID)
+	}
+	return action.done, true
+}
+
+// work runs a container's actions in order until its queue is empty
+func (q *actionQueue) work(containerID string) {
+	for {
+		q.mu.Lock()
+		action := q.queues[containerID][0]
+		q.mu.Unlock()
+
+		action.done <- action.run()
+
+		// Dropping the emptied queue under the same lock lets the next enqueue start a new worker
+		q.mu.Lock()
+		remaining := q.queues[containerID][1:]
+		if len(remaining) == 0 {
+			delete(q.queues, containerID)
+			q.mu.Unlock()
+			return
+		}
+		q.queues[containerID] = remaining
+		q.mu.Unlock()
+	}
+}
+
+// inFlight returns the label of the action running on a container and how many
+// more wait behind it; the label is empty when the container is idle
+func (q *actionQueue) inFlight(containerID string) (string, int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	queue := q.queues[containerID]
+	if len(queue) == 0 {
+		return "", 0
+	}
+	return queue[0].label, len(queue) - 1
+}
+
+// claimTicker reports whether the caller should schedule the spinner tick, so only
+// one tick loop runs at a time
+func (q *actionQueue) claimTicker() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.ticking {
+		return false
+	}
+	q.ticking = true
+	return true
+}
+
+// keepTicking reports whether actions are still in flight; when none are, the tick
+// loop ends and the next action claims it again
+func (q *actionQueue) keepTicking() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.ticking = len(q.queues) > 0
+	return q.ticking
+}
+
+// actionTickMsg advances the spinner of in-flight actions
+type actionTickMsg struct{}
+
+// actionDoneMsg reports the result of a queued container action
+type actionDoneMsg struct {
+	name string
+	verb string
+	err  error
+	undo *undoAction // Reverts the action; nil when it can't be undone
+}
+
+// containerAction queues an action on a container, records it in the audit log and
+// reports the result. A repeat of an action that is still pending is dropped.
+func (m *Model) containerAction(containerID, name, verb, label string, run func() error) tea.Cmd {
+	return m.queueAction(containerID, name, verb, label, run, nil)
+}
+
+// queueAction is containerAction for actions that can be undone: once run succeeds,
+// undo (when not nil) describes how to revert it
+func (m *Model) queueAction(containerID, name, verb, label string, run func() error, undo func() *undoAction) tea.Cmd {
+	done, ok := m.actions.enqueue(containerID, verb, label, run)
+	if !ok {
+		return func() tea.Msg {
+			return statusMsg(fmt.Sprintf("%s is already %s", name, label))
+		}
+	}
+
+	audit := m.audit
+	wait := func() tea.Msg {
+		err := <-done
+		audit.Record(verb, name, err)
+		msg := actionDoneMsg{name: name, verb: verb, err: err}
+		if err == nil && undo != nil {
+			msg.undo = undo()
+		}
+		return msg
+	}
+	cmds := []tea.Cmd{wait, m.refreshContainers()}
+	if m.actions.claimTicker() {
+		cmds = append(cmds, actionTick())
+	}
+	return tea.Batch(cmds...)
+}
+
+// actionTick schedules the next spinner frame
+func actionTick() tea.Cmd {
+	return tea.Tick(actionSpinnerInterval, func(time.Time) tea.Msg {
+		return actionTickMsg{}
+	})
+}
+
+func (m Model) handleActionDone(msg actionDoneMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.status = fmt.Sprintf("%s %s failed: %v", msg.verb, msg.name, msg.err)
+	} else {
+		m.status = fmt.Sprintf("%s: %s done", msg.name, msg.verb)
+	}
+	if msg.undo != nil {
+		m.pushUndo(*msg.undo)
+		m.status += " — " + msg.undo.hint()
+	}
+	return m, m.refreshContainers()
+}
+
+// actionText describes the action in flight on a container for the status column,
+// e.g. "⠹ restarting… +1 queued"; empty when the container is idle
+func (m Model) actionText(containerID string) string {
+	label, queued := m.actions.inFlight(containerID)
+	if label == "" {
+		return ""
+	}
+	text := spinnerFrames[spinnerFrame()] + " " + label + "…"
+	if accessibleMode {
+		text = label + "…"
+	}
+	if queued > 0 {
+		text += fmt.Sprintf(" +%d queued", queued)
+	}
+	return text
+}
+
+// spinnerFrame is the index of the current spinner frame
+func spinnerFrame() int {
+	return int(time.Now().UnixMilli()/actionSpinnerInterval.Milliseconds()) % len(spinnerFrames)
+}
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/term"
+	"github.com/ekinertac/dtop/docker"
+	"github.com/muesli/cancelreader"
+)
+
+// attachCmd implements tea.ExecCommand so the TUI is suspended while attached
+type attachCmd struct {
+	client        *docker.Client
+	containerID   string
+	containerName string
+	stdin         io.Reader
+	stdout        io.Writer
+	stderr        io.Writer
+}
+
+func (a *attachCmd) SetStdin(r io.Reader)  { a.stdin = r }
+func (a *attachCmd) SetStdout(w io.Writer) { a.stdout = w }
+func (a *attachCmd) SetStderr(w io.Writer) { a.stderr = w }
+
+func (a *attachCmd) Run() error {
+	fmt.Fprintf(a.stdout, "Attached to %s - press ctrl+p ctrl+q to detach\r\n", a.containerName)
+
+	// Raw mode so ctrl+c and the detach keys reach the container instead of dtop
+	opts := docker.AttachOptions{}
+	if f, ok := a.stdin.(*os.File); ok && term.IsTerminal(f.Fd()) {
+		state, err := term.MakeRaw(f.Fd())
+		if err != nil {
+			return err
+		}
+		defer term.Restore(f.Fd(), state)
+
+		opts.RawTerminal = true
+		if width, height, err := term.GetSize(f.Fd()); err == nil {
+			opts.Width, opts.Height = width, height
+		}
+	}
+
+	// Cancelable so the stdin copy doesn't swallow keys after we return to the TUI
+	input, err := cancelreader.NewReader(a.stdin)
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+	defer input.Cancel()
+
+	return a.client.AttachContainer(a.containerID, opts, input, a.stdout, a.stderr)
+}
+
+// attachContainer suspends the TUI and attaches to the container's main process
+func (m *Model) attachContainer(containerID, containerName string) tea.Cmd {
+	cmd := &attachCmd{
+		client:        m.dockerClient,
+		containerID:   containerID,
+		containerName: containerName,
+	}
+	return tea.Exec(cmd, func(err error) tea.Msg {
+		m.audit.Record("attach", containerName, err)
+		if err != nil {
+			return errMsg{err}
+		}
+		return m.refreshContainers()()
+	})
+}
@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func (m Model) handleAuditKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.scrollPager(msg.String()) {
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc", "q":
+		m.viewMode = ViewModeMain
+	}
+	return m, nil
+}
+
+// renderAudit lists every action performed this session, newest first
+func (m Model) renderAudit() string {
+	entries := m.audit.Entries()
+
+	lines := []string{}
+	if len(entries) == 0 {
+		lines = append(lines, "No actions performed yet")
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		timestamp := headerStyle.Render(entry.Time.Format("15:04:05"))
+		line := fmt.Sprintf("%s  %s", timestamp, entry)
+		if entry.Err != nil {
+			line = fmt.Sprintf("%s  %s", timestamp, stoppedStyle.Render(entry.String()))
+		}
+		lines = append(lines, line)
+	}
+
+	return m.renderPager("dtop - Audit Log", lines, "↑↓:scroll  q/esc:back")
+}
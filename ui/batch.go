@@ -0,0 +1,336 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/docker"
+	"github.com/ekinertac/dtop/model"
+)
+
+// batchOp is an operation that can be run across a project or several projects at once
+type batchOp struct {
+	verb   string // "restart", "stop", "start" or "down"
+	title  string // Capitalized verb
+	label  string // Progress label, e.g. "Stopping"
+	filter func(c *docker.ContainerInfo) bool
+	run    func(client *docker.Client, containerID string) error
+}
+
+var (
+	restartOp = batchOp{
+		verb:   "restart",
+		title:  "Restart",
+		label:  "Restarting",
+		filter: func(c *docker.ContainerInfo) bool { return c.State == "running" },
+		run:    (*docker.Client).RestartContainer,
+	}
+	stopOp = batchOp{
+		verb:   "stop",
+		title:  "Stop",
+		label:  "Stopping",
+		filter: func(c *docker.ContainerInfo) bool { return c.State == "running" },
+		run:    (*docker.Client).StopContainer,
+	}
+	startOp = batchOp{
+		verb:   "start",
+		title:  "Start",
+		label:  "Starting",
+		filter: func(c *docker.ContainerInfo) bool { return c.State != "running" },
+		run:    (*docker.Client).StartContainer,
+	}
+	downOp = batchOp{
+		verb:   "down",
+		title:  "Down",
+		label:  "Removing",
+		filter: func(c *docker.ContainerInfo) bool { return true },
+		run:    (*docker.Client).RemoveContainer,
+	}
+)
+
+// batchOps are offered for marked projects
+var batchOps = []batchOp{restartOp, stopOp, startOp, downOp}
+
+// Progress of a container in a batch
+const (
+	batchPending = iota
+	batchRunning
+	batchDone
+	batchFailed
+)
+
+// batchItem is a container affected by a batch operation
+type batchItem struct {
+	id      string
+	name    string
+	project string
+	state   int
+	err     error
+}
+
+// batch is the state of the running or last batch operation
+type batch struct {
+	op       batchOp
+	projects []string
+	items    []batchItem
+	done     bool
+	ch       <-chan batchMsg
+}
+
+// startBatchMsg asks the model to run a confirmed batch operation
+type startBatchMsg struct {
+	op       batchOp
+	projects []string
+	items    []batchItem
+}
+
+// batchMsg reports that a container of a batch started or finished, or that the
+// whole batch is over
+type batchMsg struct {
+	index    int
+	finished bool
+	err      error
+	done     bool
+	ch       <-chan batchMsg
+}
+
+// waitForBatch delivers the next update from a batch operation
+func waitForBatch(ch <-chan batchMsg) tea.Cmd {
+	return func() tea.Msg {
+		return <-ch
+	}
+}
+
+// toggleProjectMark marks or unmarks a project for batch operations
+func (m *Model) toggleProjectMark(project string) {
+	if m.projectMarks[project] {
+		delete(m.projectMarks, project)
+	} else {
+		m.projectMarks[project] = true
+	}
+	if n := len(m.projectMarks); n > 0 {
+		m.status = fmt.Sprintf("%d projects marked (enter on a project for batch actions)", n)
+	}
+}
+
+// markedProjects returns the marked projects that are still in the tree, in tree order
+func (m Model) markedProjects() []*model.TreeNode {
+	nodes := []*model.TreeNode{}
+	if m.tree.Root == nil {
+		return nodes
+	}
+	for _, node := range m.tree.Root.Children {
+		if m.projectMarks[node.Name] {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// batchMenuItems offers the batch operations for the marked projects
+func (m *Model) batchMenuItems() []MenuItem {
+	projects := m.markedProjects()
+	if len(projects) == 0 {
+		return nil
+	}
+
+	items := []MenuItem{}
+	for _, op := range batchOps {
+		affected := []batchItem{}
+		names := []string{}
+		for _, project := range projects {
+			names = append(names, project.Name)
+			for _, child := range project.Children {
+				if c := child.Container; c != nil && actionable(c) && op.filter(c) {
+					affected = append(affected, batchItem{id: c.ID, name: c.Name, project: project.Name})
+				}
+			}
+		}
+		if len(affected) == 0 {
+			continue
+		}
+
+		label := fmt.Sprintf("%s all in %d marked projects", op.title, len(projects))
+		if op.verb == "down" {
+			label = fmt.Sprintf("Down %d marked projects (stop & remove, keeps volumes)", len(projects))
+		}
+		items = append(items, MenuItem{
+			Label:   label,
+			Mutates: true,
+			Action: func() tea.Cmd {
+				return func() tea.Msg {
+					return openMenuMsg{
+						title: fmt.Sprintf("%s %d containers in %s?", op.verb, len(affected), strings.Join(names, ", ")),
+						items: []MenuItem{
+							{
+								Label:   fmt.Sprintf("Yes, %s %d containers", op.verb, len(affected)),
+								Mutates: true,
+								Action: func() tea.Cmd {
+									return func() tea.Msg {
+										return startBatchMsg{op: op, projects: names, items: affected}
+									}
+								},
+							},
+							{Label: "Cancel", Action: func() tea.Cmd { return nil }},
+						},
+					}
+				}
+			},
+		})
+	}
+	items = append(items, MenuItem{
+		Label: "Clear project marks",
+		Action: func() tea.Cmd {
+			return func() tea.Msg { return clearProjectMarksMsg{} }
+		},
+	})
+	return items
+}
+
+// clearProjectMarksMsg asks the model to unmark all projects; used by menu actions
+type clearProjectMarksMsg struct{}
+
+// startBatch runs the operation on each container in turn and shows the progress list
+func (m *Model) startBatch(msg startBatchMsg) tea.Cmd {
+	if m.batch != nil && !m.batch.done {
+		m.viewMode = ViewModeBatch
+		m.status = "A batch operation is already running"
+		return nil
+	}
+
+	ch := make(chan batchMsg, len(msg.items)*2+1)
+	m.batch = &batch{op: msg.op, projects: msg.projects, items: msg.items, ch: ch}
+	m.projectMarks = make(map[string]bool)
+	m.pagerScroll = 0
+	m.viewMode = ViewModeBatch
+
+	client, audit, actions := m.dockerClient, m.audit, m.actions
+	label := strings.ToLower(msg.op.label)
+	go func() {
+		audit.Record(msg.op.verb+" all", "projects "+strings.Join(msg.projects, ", "), nil)
+		for i, item := range msg.items {
+			ch <- batchMsg{index: i, ch: ch}
+			// Through the action queue, so containers busy with another action finish it first
+			id := item.id
+			err := fmt.Errorf("already %s", label)
+			done, ok := actions.enqueue(id, msg.op.verb, label, func() error {
+				return msg.op.run(client, id)
+			})
+			if ok {
+				err = <-done
+			}
+			audit.Record(msg.op.verb, item.name, err)
+			ch <- batchMsg{index: i, finished: true, err: err, ch: ch}
+		}
+		ch <- batchMsg{done: true, ch: ch}
+	}()
+	return waitForBatch(ch)
+}
+
+func (m Model) handleBatch(msg batchMsg) (tea.Model, tea.Cmd) {
+	if m.batch == nil || m.batch.ch != msg.ch {
+		return m, nil
+	}
+
+	// Copy so the previous model value keeps its own state
+	next := *m.batch
+	next.items = append([]batchItem(nil), next.items...)
+	m.batch = &next
+
+	if msg.done {
+		next.done = true
+		m.status = next.summary()
+		return m, m.refreshContainers()
+	}
+
+	item := &next.items[msg.index]
+	switch {
+	case !msg.finished:
+		item.state = batchRunning
+	case msg.err != nil:
+		item.state = batchFailed
+		item.err = msg.err
+	default:
+		item.state = batchDone
+	}
+	if m.viewMode != ViewModeBatch {
+		m.status = next.summary()
+	}
+	// Refresh as containers finish so the tree follows along
+	if msg.finished {
+		return m, tea.Batch(waitForBatch(msg.ch), m.refreshContainers())
+	}
+	if m.actions.claimTicker() {
+		return m, tea.Batch(waitForBatch(msg.ch), actionTick())
+	}
+	return m, waitForBatch(msg.ch)
+}
+
+// summary describes the batch's progress for the status bar and the progress list
+func (b *batch) summary() string {
+	finished, failed := 0, 0
+	for _, item := range b.items {
+		switch item.state {
+		case batchDone:
+			finished++
+		case batchFailed:
+			finished++
+			failed++
+		}
+	}
+	s := fmt.Sprintf("%s %d/%d containers in %s", b.op.label, finished, len(b.items), strings.Join(b.projects, ", "))
+	if b.done {
+		s = fmt.Sprintf("%s: %d/%d containers done in %s", b.op.title,
+			finished-failed, len(b.items), strings.Join(b.projects, ", "))
+	}
+	if failed > 0 {
+		s += fmt.Sprintf(", %d failed", failed)
+	}
+	return s
+}
+
+func (m Model) handleBatchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.scrollPager(msg.String()) {
+		return m, nil
+	}
+	switch msg.String() {
+	case "esc", "q":
+		// A running batch continues; progress moves to the status bar
+		m.viewMode = ViewModeMain
+	}
+	return m, nil
+}
+
+func (m Model) renderBatch() string {
+	b := m.batch
+	help := "↑↓:scroll  q/esc:hide (continues in background)"
+	if b.done {
+		help = "↑↓:scroll  q/esc:back"
+	}
+
+	lines := []string{headerStyle.Render(b.summary()), ""}
+
+	// Failures first once the batch is over, so they aren't lost in a long list
+	items := append([]batchItem(nil), b.items...)
+	if b.done {
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].state == batchFailed && items[j].state != batchFailed
+		})
+	}
+	for _, item := range items {
+		name := item.project + "/" + item.name
+		switch item.state {
+		case batchPending:
+			lines = append(lines, helpStyle.Render("  "+name))
+		case batchRunning:
+			lines = append(lines, statusStyle.Render("… "+name))
+		case batchDone:
+			lines = append(lines, runningStyle.Render("✓ "+name))
+		case batchFailed:
+			lines = append(lines, stoppedStyle.Render(fmt.Sprintf("✗ %s: %v", name, item.err)))
+		}
+	}
+	return m.renderPager("dtop - "+b.op.title+" projects", lines, help)
+}
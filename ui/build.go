@@ -0,0 +1,257 @@
+package ui
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// buildTimeout bounds an image build
+const buildTimeout = time.Hour
+
+// buildStepPattern matches BuildKit's plain progress for a Dockerfile step, e.g.
+// "#7 [2/4] RUN apt-get update"
+var buildStepPattern = regexp.MustCompile(`^#\d+ \[[^\]]*?(\d+)/(\d+)\] (.*)$`)
+
+// buildTarget is a container to recreate from the freshly built image
+type buildTarget struct {
+	id   string
+	name string
+}
+
+// build is the state of the running or last image build
+type build struct {
+	tag    string
+	lines  []string
+	step   string // Latest Dockerfile step, e.g. "2/4 RUN apt-get update"
+	done   bool
+	err    error
+	follow bool // Keep the view scrolled to the newest output
+	ch     <-chan buildMsg
+}
+
+// buildMsg delivers a line of build output, or the result once the build is over
+type buildMsg struct {
+	line string
+	done bool
+	err  error
+	ch   <-chan buildMsg
+}
+
+// openBuildFormMsg asks the model to show the build form; used by menu actions
+type openBuildFormMsg struct {
+	image      string
+	contextDir string
+}
+
+// startBuildMsg asks the model to start a build submitted from the build form
+type startBuildMsg struct {
+	tag     string
+	args    []string
+	targets []buildTarget
+}
+
+// waitForBuild delivers the next update from a build
+func waitForBuild(ch <-chan buildMsg) tea.Cmd {
+	return func() tea.Msg {
+		return <-ch
+	}
+}
+
+// openBuildForm opens the form for building an image. image and contextDir prefill
+// the tag and the build context, e.g. from the selected container.
+func (m *Model) openBuildForm(image, contextDir string) {
+	if m.build != nil && !m.build.done {
+		// One build at a time; show the running one
+		m.viewMode = ViewModeBuild
+		return
+	}
+	if contextDir == "" {
+		contextDir = "."
+	}
+
+	fields := []formField{
+		{Label: "Context", Value: contextDir, Placeholder: "path to the build context"},
+		{Label: "Dockerfile", Placeholder: "Dockerfile in the context"},
+		{Label: "Tag", Value: image, Placeholder: "myapp:latest"},
+		{Label: "Recreate", Value: "yes", Placeholder: "yes | no - recreate containers using the tag"},
+	}
+
+	tree := m.tree
+	m.openForm(newForm("Build image", fields, func(values []string) tea.Cmd {
+		contextDir, dockerfile, tag := values[0], values[1], values[2]
+		if contextDir == "" || tag == "" {
+			return func() tea.Msg { return statusMsg("Build needs a context and a tag") }
+		}
+
+		// Containers are picked now, so ones created during the build are left alone
+		var targets []buildTarget
+		if values[3] == "yes" || values[3] == "y" {
+			for _, node := range tree.Flat {
+				if node.Container != nil && sameImage(node.Container.Image, tag) {
+					targets = append(targets, buildTarget{id: node.Container.ID, name: node.Container.Name})
+				}
+			}
+		}
+
+		args := []string{"build", "--progress=plain", "--tag", tag}
+		if dockerfile != "" {
+			args = append(args, "--file", dockerfile)
+		}
+		args = append(args, contextDir)
+		return func() tea.Msg {
+			return startBuildMsg{tag: tag, args: args, targets: targets}
+		}
+	}))
+}
+
+// sameImage reports whether two image references name the same tag, treating a
+// missing tag as latest
+func sameImage(a, b string) bool {
+	withTag := func(ref string) string {
+		if i := strings.LastIndex(ref, ":"); i < 0 || strings.Contains(ref[i:], "/") {
+			return ref + ":latest"
+		}
+		return ref
+	}
+	return withTag(a) == withTag(b)
+}
+
+// startBuild runs docker build in the background, streaming its output to the build
+// view, then recreates targets from the new image
+func (m *Model) startBuild(tag string, args []string, targets []buildTarget) tea.Cmd {
+	ch := make(chan buildMsg, 64)
+	m.build = &build{tag: tag, follow: true, ch: ch}
+	m.pagerScroll = 0
+	m.viewMode = ViewModeBuild
+
+	ctx, cancel := context.WithTimeout(context.Background(), buildTimeout)
+	cmd := m.dockerCommand(ctx, args...)
+	client := m.dockerClient
+	audit := m.audit
+	go func() {
+		defer cancel()
+		send := func(line string) { ch <- buildMsg{line: line, ch: ch} }
+		send("$ docker " + strings.Join(args, " "))
+
+		// BuildKit writes its progress to stderr
+		reader, writer := io.Pipe()
+		cmd.Stdout = writer
+		cmd.Stderr = writer
+		err := cmd.Start()
+		if err == nil {
+			go func() {
+				writer.CloseWithError(cmd.Wait())
+			}()
+			scanner := bufio.NewScanner(reader)
+			scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+			for scanner.Scan() {
+				send(scanner.Text())
+			}
+			err = scanner.Err()
+		}
+		audit.Record("build", tag, err)
+		if err != nil {
+			ch <- buildMsg{done: true, err: err, ch: ch}
+			return
+		}
+
+		for _, target := range targets {
+			send(fmt.Sprintf("Recreating %s from %s…", target.name, tag))
+			_, err := client.RecreateContainer(target.id, tag)
+			audit.Record("recreate", target.name, err)
+			if err != nil {
+				send(fmt.Sprintf("Recreating %s failed: %v", target.name, err))
+			}
+		}
+		ch <- buildMsg{done: true, ch: ch}
+	}()
+
+	return waitForBuild(ch)
+}
+
+func (m Model) handleBuild(msg buildMsg) (tea.Model, tea.Cmd) {
+	if m.build == nil || m.build.ch != msg.ch {
+		return m, nil
+	}
+
+	// Copy so the previous model value keeps its own state
+	next := *m.build
+	m.build = &next
+	if !msg.done {
+		next.lines = append(next.lines, msg.line)
+		if match := buildStepPattern.FindStringSubmatch(msg.line); match != nil {
+			next.step = fmt.Sprintf("%s/%s %s", match[1], match[2], match[3])
+		}
+		if m.viewMode != ViewModeBuild {
+			m.status = "Building " + next.tag + "… " + next.step
+		}
+		return m, waitForBuild(msg.ch)
+	}
+
+	next.done = true
+	next.err = msg.err
+	if msg.err != nil {
+		next.lines = append(next.lines, stoppedStyle.Render("Build failed: "+msg.err.Error()))
+		m.status = fmt.Sprintf("Building %s failed: %v", next.tag, msg.err)
+	} else {
+		next.lines = append(next.lines, runningStyle.Render("Built "+next.tag))
+		m.status = "Built " + next.tag
+	}
+	return m, m.refreshContainers()
+}
+
+func (m Model) handleBuildKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	switch key {
+	case "esc", "q":
+		// The build keeps running; progress moves to the status bar
+		m.viewMode = ViewModeMain
+		return m, nil
+	case "f", "end", "G":
+		next := *m.build
+		next.follow = true
+		m.build = &next
+		return m, nil
+	}
+
+	if m.build.follow {
+		// Start scrolling from the bottom the view is showing
+		m.pagerScroll = len(m.build.lines) + 2 - (m.height - 4)
+	}
+	if m.scrollPager(key) {
+		next := *m.build
+		next.follow = false
+		m.build = &next
+	}
+	return m, nil
+}
+
+func (m Model) renderBuild() string {
+	b := m.build
+	status := "running"
+	switch {
+	case b.done && b.err != nil:
+		status = "failed"
+	case b.done:
+		status = "done"
+	case b.step != "":
+		status = "step " + b.step
+	}
+
+	lines := append([]string{headerStyle.Render(truncateOrPad(status, m.width)), ""}, b.lines...)
+	if b.follow {
+		m.pagerScroll = len(lines)
+	}
+	help := "↑↓:scroll  f:follow  q/esc:hide (continues in background)"
+	if b.done {
+		help = "↑↓:scroll  q/esc:back"
+	}
+	return m.renderPager("dtop - Build "+b.tag, lines, help)
+}
@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// checkpointMenu lists a container's checkpoints with actions to create, restore and
+// delete them. Checkpoints need an experimental daemon with CRIU on the host.
+func (m *Model) checkpointMenu(containerID, containerName string, running bool) tea.Cmd {
+	return func() tea.Msg {
+		experimental, err := m.dockerClient.Experimental()
+		if err != nil {
+			return statusMsg(fmt.Sprintf("Checkpoints of %s: %v", containerName, err))
+		}
+		if !experimental {
+			return statusMsg("Checkpoints need a daemon with experimental features enabled and CRIU installed")
+		}
+		names, err := m.dockerClient.ListCheckpoints(containerID)
+		if err != nil {
+			return statusMsg(fmt.Sprintf("Checkpoints of %s: %v", containerName, err))
+		}
+
+		items := []MenuItem{}
+		if running {
+			for _, leaveRunning := range []bool{false, true} {
+				label := "Checkpoint & stop"
+				if leaveRunning {
+					label = "Checkpoint & keep running"
+				}
+				items = append(items, MenuItem{
+					Label:   label,
+					Mutates: true,
+					Action: func() tea.Cmd {
+						return m.createCheckpoint(containerID, containerName, leaveRunning)
+					},
+				})
+			}
+		}
+		for _, name := range names {
+			if !running {
+				items = append(items, MenuItem{
+					Label:   "Restore " + name,
+					Mutates: true,
+					Action: func() tea.Cmd {
+						return m.checkpointAction("restore checkpoint "+name, containerName, func() error {
+							return m.dockerClient.RestoreCheckpoint(containerID, name)
+						})
+					},
+				})
+			}
+			items = append(items, MenuItem{
+				Label:   "Delete " + name,
+				Mutates: true,
+				Action: func() tea.Cmd {
+					return m.checkpointAction("delete checkpoint "+name, containerName, func() error {
+						return m.dockerClient.DeleteCheckpoint(containerID, name)
+					})
+				},
+			})
+		}
+		if len(items) == 0 {
+			return statusMsg(containerName + " has no checkpoints")
+		}
+		return openMenuMsg{title: fmt.Sprintf("Checkpoints of %s (%d)", containerName, len(names)), items: items}
+	}
+}
+
+// createCheckpoint checkpoints the container under a timestamped name
+func (m *Model) createCheckpoint(containerID, containerName string, leaveRunning bool) tea.Cmd {
+	name := "dtop-" + time.Now().Format("20060102-150405")
+	return m.checkpointAction("checkpoint "+name, containerName, func() error {
+		return m.dockerClient.CheckpointContainer(containerID, name, leaveRunning)
+	})
+}
+
+// checkpointAction runs a checkpoint operation in the background, which can take a
+// while for containers with a lot of memory, and reports the result
+func (m *Model) checkpointAction(action, containerName string, op func() error) tea.Cmd {
+	status := func() tea.Msg {
+		return statusMsg(containerName + ": " + action + "…")
+	}
+	run := func() tea.Msg {
+		err := op()
+		m.audit.Record(action, containerName, err)
+		if err != nil {
+			return statusMsg(fmt.Sprintf("%s: %s failed: %v", containerName, action, err))
+		}
+		return statusMsg(containerName + ": " + action + " done")
+	}
+	return tea.Sequence(status, run, m.refreshContainers())
+}
@@ -0,0 +1,270 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/debuglog"
+	"github.com/ekinertac/dtop/docker"
+	"github.com/ekinertac/dtop/model"
+)
+
+// cleanup is the state of the cleanup review screen
+type cleanup struct {
+	candidates []docker.ContainerInfo
+	keep       map[string]bool // Candidates unticked by the user, by container ID
+	cursor     int
+}
+
+// staleCheckInterval is how often the cleanup policy lists every container to find
+// the exited ones; the tree only holds running containers
+const staleCheckInterval = 5 * time.Minute
+
+// staleMsg delivers the containers flagged by the cleanup policy, oldest first;
+// review opens the review screen with them
+type staleMsg struct {
+	containers []docker.ContainerInfo
+	err        error
+	review     bool
+}
+
+// checkStale lists running and stopped containers in the background and picks the
+// ones that have been exited longer than the cleanup policy allows
+func (m Model) checkStale(review bool) tea.Cmd {
+	cutoff := m.staleCutoff()
+	client := m.dockerClient
+	return func() tea.Msg {
+		containers, err := client.ListAllContainers()
+		if err != nil {
+			return staleMsg{err: err, review: review}
+		}
+		stale := []docker.ContainerInfo{}
+		for i := range containers {
+			if isStale(&containers[i], cutoff) {
+				stale = append(stale, containers[i])
+			}
+		}
+		sort.Slice(stale, func(i, j int) bool {
+			return stale[i].FinishedAt.Before(stale[j].FinishedAt)
+		})
+		return staleMsg{containers: stale, review: review}
+	}
+}
+
+// staleCheckDue starts a check for stale containers once staleCheckInterval has
+// passed since the last one; nil without a cleanup policy and during replays
+func (m *Model) staleCheckDue() tea.Cmd {
+	if m.staleCutoff().IsZero() || m.replay != nil || m.dockerClient == nil || time.Since(m.staleAt) < staleCheckInterval {
+		return nil
+	}
+	m.staleAt = time.Now()
+	return m.checkStale(false)
+}
+
+func (m Model) handleStale(msg staleMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		debuglog.Error("cleanup check", msg.err)
+		if msg.review {
+			m.status = fmt.Sprintf("Failed to list containers: %v", msg.err)
+		}
+		return m, nil
+	}
+	m.stale = msg.containers
+	if msg.review {
+		m.reviewStale()
+		return m, nil
+	}
+	return m, m.autoCleanup()
+}
+
+// isStale reports whether a container exited before cutoff
+func isStale(c *docker.ContainerInfo, cutoff time.Time) bool {
+	if c.State != "exited" && c.State != "dead" {
+		return false
+	}
+	return !c.FinishedAt.IsZero() && c.FinishedAt.Before(cutoff)
+}
+
+// staleCutoff is the exit time before which containers are flagged; zero when no
+// cleanup policy is configured
+func (m Model) staleCutoff() time.Time {
+	if m.config.Cleanup.ExitedDays <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(-time.Duration(m.config.Cleanup.ExitedDays) * 24 * time.Hour)
+}
+
+// openCleanup looks up the containers flagged by the cleanup policy for review
+func (m *Model) openCleanup() tea.Cmd {
+	if m.config.Cleanup.ExitedDays <= 0 {
+		m.status = "No cleanup policy (set cleanup.exited_days in the config)"
+		return nil
+	}
+	if m.replay != nil {
+		m.status = "Not available while replaying a recording"
+		return nil
+	}
+	m.status = "Looking for containers to clean up…"
+	m.staleAt = time.Now()
+	return m.checkStale(true)
+}
+
+// reviewStale shows the flagged containers on the review screen
+func (m *Model) reviewStale() {
+	if len(m.stale) == 0 {
+		m.status = fmt.Sprintf("No containers exited more than %d days ago", m.config.Cleanup.ExitedDays)
+		return
+	}
+	m.status = ""
+	m.cleanup = &cleanup{candidates: m.stale, keep: make(map[string]bool)}
+	m.pagerScroll = 0
+	m.viewMode = ViewModeCleanup
+}
+
+func (m Model) handleCleanupKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	next := *m.cleanup
+	m.cleanup = &next
+
+	switch msg.String() {
+	case "esc", "q":
+		m.viewMode = ViewModeMain
+		m.cleanup = nil
+	case "up", "k":
+		if next.cursor > 0 {
+			next.cursor--
+		}
+	case "down", "j":
+		if next.cursor < len(next.candidates)-1 {
+			next.cursor++
+		}
+	case " ":
+		id := next.candidates[next.cursor].ID
+		keep := make(map[string]bool, len(next.keep)+1)
+		for k := range next.keep {
+			keep[k] = true
+		}
+		if keep[id] {
+			delete(keep, id)
+		} else {
+			keep[id] = true
+		}
+		next.keep = keep
+	case "a":
+		// Tick everything, or untick everything when all are ticked
+		keep := make(map[string]bool)
+		if len(next.keep) == 0 {
+			for _, c := range next.candidates {
+				keep[c.ID] = true
+			}
+		}
+		next.keep = keep
+	case "enter":
+		if m.readOnly {
+			m.status = "Read-only: removing containers is disabled (R to allow changes)"
+			break
+		}
+		remove := []docker.ContainerInfo{}
+		for _, c := range next.candidates {
+			if !next.keep[c.ID] {
+				remove = append(remove, c)
+			}
+		}
+		if len(remove) == 0 {
+			m.status = "Nothing selected for removal"
+			break
+		}
+		m.viewMode = ViewModeMain
+		m.cleanup = nil
+		m.status = fmt.Sprintf("Removing %d containers…", len(remove))
+		return m, m.removeStale(remove, "Removed")
+	}
+
+	// Keep the cursor in view below the summary and header lines
+	visibleHeight := m.height - 4
+	if row := next.cursor + 3; row < m.pagerScroll {
+		m.pagerScroll = row
+	} else if row >= m.pagerScroll+visibleHeight {
+		m.pagerScroll = row - visibleHeight + 1
+	}
+	return m, nil
+}
+
+// removeStale removes containers in the background and reports how many were removed
+func (m *Model) removeStale(containers []docker.ContainerInfo, verb string) tea.Cmd {
+	run := func() tea.Msg {
+		failed := []string{}
+		for _, c := range containers {
+			id := c.ID
+			err := fmt.Errorf("already removing")
+			done, ok := m.actions.enqueue(id, "remove", "removing", func() error {
+				return m.dockerClient.RemoveContainer(id)
+			})
+			if ok {
+				err = <-done
+			}
+			m.audit.Record("cleanup", c.Name, err)
+			if err != nil {
+				failed = append(failed, c.Name)
+			}
+		}
+		status := fmt.Sprintf("%s %d containers exited more than %d days ago", verb,
+			len(containers)-len(failed), m.config.Cleanup.ExitedDays)
+		if len(failed) > 0 {
+			status += "; failed: " + strings.Join(failed, ", ")
+		}
+		return statusMsg(status)
+	}
+	return tea.Sequence(run, m.refreshContainers(), m.checkStale(false))
+}
+
+// autoCleanup removes newly flagged containers when the policy asks for it. It is
+// off in read-only mode and during replays.
+func (m *Model) autoCleanup() tea.Cmd {
+	if !m.config.Cleanup.AutoRemove || m.readOnly || m.replay != nil {
+		return nil
+	}
+	remove := []docker.ContainerInfo{}
+	for _, c := range m.stale {
+		// Removal takes a check or two to show up in the list
+		if !m.cleaning[c.ID] {
+			m.cleaning[c.ID] = true
+			remove = append(remove, c)
+		}
+	}
+	if len(remove) == 0 {
+		return nil
+	}
+	return m.removeStale(remove, "Auto-removed")
+}
+
+func (m Model) renderCleanup() string {
+	c := m.cleanup
+	title := "dtop - Cleanup review"
+	help := "↑↓:select  space:keep/remove  a:all/none  enter:remove ticked  q/esc:back"
+
+	ticked := len(c.candidates) - len(c.keep)
+	lines := []string{
+		headerStyle.Render(fmt.Sprintf("%d containers exited more than %d days ago, %d ticked for removal (volumes are kept)",
+			len(c.candidates), m.config.Cleanup.ExitedDays, ticked)),
+		"",
+		headerStyle.Render(fmt.Sprintf("    %-30s %-24s %-6s %s", "NAME", "IMAGE", "EXIT", "EXITED")),
+	}
+	for i, container := range c.candidates {
+		box := "[x]"
+		if c.keep[container.ID] {
+			box = "[ ]"
+		}
+		line := fmt.Sprintf("%s %s %s %-6d %s ago", box,
+			truncateOrPad(container.Name, 30), truncateOrPad(docker.ShortImage(container.Image), 24),
+			container.ExitCode, model.HumanizeDuration(time.Since(container.FinishedAt)))
+		if i == c.cursor {
+			line = selectedStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+
+	return m.renderPager(title, lines, help)
+}
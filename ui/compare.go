@@ -0,0 +1,176 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/i18n"
+	"github.com/ekinertac/dtop/model"
+)
+
+// Number of containers that can be compared side by side
+const (
+	compareMin = 2
+	compareMax = 4
+)
+
+// toggleMark marks or unmarks the selected container for comparison, or the
+// selected project for batch operations
+func (m *Model) toggleMark() {
+	node := m.tree.GetSelected()
+	if node != nil && node.Type == model.NodeTypeProject {
+		m.toggleProjectMark(node.Name)
+		return
+	}
+	if node == nil || node.Container == nil {
+		return
+	}
+	id := node.Container.ID
+
+	for i, marked := range m.marked {
+		if marked == id {
+			m.marked = append(m.marked[:i], m.marked[i+1:]...)
+			return
+		}
+	}
+	if len(m.marked) >= compareMax {
+		m.status = fmt.Sprintf("At most %d containers can be compared", compareMax)
+		return
+	}
+	m.marked = append(m.marked, id)
+}
+
+// isMarked reports whether the container is marked for comparison
+func (m Model) isMarked(containerID string) bool {
+	for _, id := range m.marked {
+		if id == containerID {
+			return true
+		}
+	}
+	return false
+}
+
+// openCompare shows the marked containers side by side
+func (m *Model) openCompare() {
+	if len(m.marked) < compareMin {
+		m.status = fmt.Sprintf("Mark %d-%d containers with m to compare them", compareMin, compareMax)
+		return
+	}
+	m.viewMode = ViewModeCompare
+}
+
+func (m Model) handleCompareKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.viewMode = ViewModeMain
+	case "x":
+		// Clear the marks and go back to pick new containers
+		m.marked = nil
+		m.viewMode = ViewModeMain
+	}
+	return m, nil
+}
+
+func (m Model) renderCompare() string {
+	var b strings.Builder
+
+	// Title
+	b.WriteString(titleStyle.Render("dtop - Compare"))
+	b.WriteString("\n\n")
+
+	gap := 2
+	width := (m.width - gap*(len(m.marked)-1)) / len(m.marked)
+	if width < 20 {
+		width = 20
+	}
+
+	columns := make([][]string, len(m.marked))
+	graphs := make([]map[int]bool, len(m.marked))
+	rows := 0
+	for i, id := range m.marked {
+		columns[i], graphs[i] = m.compareColumn(id, width)
+		if len(columns[i]) > rows {
+			rows = len(columns[i])
+		}
+	}
+
+	// Columns are padded as plain text, so style whole cells afterwards; graphs come
+	// rendered at the column width
+	for row := 0; row < rows; row++ {
+		cells := make([]string, len(columns))
+		for i, column := range columns {
+			cell := ""
+			if row < len(column) {
+				cell = column[row]
+			}
+			switch {
+			case graphs[i][row]:
+				cells[i] = cell
+			case row == 0:
+				cells[i] = projectStyle.Render(truncateOrPad(cell, width))
+			default:
+				cells[i] = containerStyle.Render(truncateOrPad(cell, width))
+			}
+		}
+		b.WriteString(strings.Join(cells, strings.Repeat(" ", gap)))
+		b.WriteString("\n")
+	}
+
+	// Help text
+	b.WriteString("\n")
+	helpText := "x:clear marks  q/esc:back"
+	b.WriteString(helpStyle.Render(i18n.Help(helpText)))
+
+	return b.String()
+}
+
+// compareColumn renders one container's live stats as plain text lines, with the
+// rows holding graphs, which are already rendered
+func (m Model) compareColumn(containerID string, width int) ([]string, map[int]bool) {
+	node := m.tree.FindContainer(containerID)
+	if node == nil {
+		return []string{containerID, "", "Container is no longer running"}, nil
+	}
+	c := node.Container
+
+	samples := m.history[containerID]
+	cpu := make([]float64, len(samples))
+	mem := make([]float64, len(samples))
+	for i, s := range samples {
+		cpu[i] = s.cpu
+		mem[i] = s.mem
+	}
+	rx, tx := netRates(samples)
+	var lastRx, lastTx float64
+	if len(rx) > 0 {
+		lastRx, lastTx = rx[len(rx)-1], tx[len(tx)-1]
+	}
+
+	// CPU can exceed 100% on multiple cores; keep the scale at least 100%
+	cpuMax := 100.0
+	for _, v := range cpu {
+		if v > cpuMax {
+			cpuMax = v
+		}
+	}
+
+	lines := []string{c.Name, c.Image, c.Status, "", fmt.Sprintf("CPU     %.1f%%", c.CPUPerc)}
+	graphs := map[int]bool{}
+	graph := func(values []float64, scale float64, name string) {
+		graphs[len(lines)] = true
+		lines = append(lines, m.graphs.graph(values, width, scale, "compare/"+containerID+"/"+name))
+	}
+	graph(cpu, cpuMax, "cpu")
+	lines = append(lines, "", fmt.Sprintf("Memory  %.1f%% (%s)", c.MemPerc, formatNetBytes(c.Memory.Usage)))
+	graph(mem, 100, "mem")
+	lines = append(lines, "", fmt.Sprintf("Net     ↓%s/s ↑%s/s", formatNetBytes(uint64(lastRx)), formatNetBytes(uint64(lastTx))))
+	graph(rx, 0, "rx")
+	graph(tx, 0, "tx")
+	lines = append(lines, "",
+		fmt.Sprintf("Disk    R %s/s W %s/s", formatNetBytes(uint64(c.Block.ReadRate)), formatNetBytes(uint64(c.Block.WriteRate))),
+		fmt.Sprintf("PIDs    %s", formatPIDs(c.PIDs, c.PIDsLimit)),
+		fmt.Sprintf("Uptime  %s", model.ContainerUptime(c)),
+	)
+	return lines, graphs
+}
@@ -0,0 +1,201 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/model"
+)
+
+// Labels docker compose sets with the files and directory a project was started from
+const (
+	composeConfigFilesLabel = "com.docker.compose.project.config_files"
+	composeWorkingDirLabel  = "com.docker.compose.project.working_dir"
+
+	// composeDependsOnLabel lists a service's dependencies as "service:condition:restart"
+	// entries separated by commas
+	composeDependsOnLabel = "com.docker.compose.depends_on"
+)
+
+// composeUpTimeout bounds docker compose up, which may have to pull and build images
+const composeUpTimeout = 10 * time.Minute
+
+// composeProject is where a compose project was started from
+type composeProject struct {
+	name       string
+	files      []string
+	workingDir string
+}
+
+// composeEditedMsg reports that the editor for a project's compose file exited
+type composeEditedMsg struct {
+	project composeProject
+	changed bool
+	err     error
+}
+
+// composeProjectOf returns the compose files of the project the containers belong to,
+// read from the labels compose sets; false for projects not started by compose
+func composeProjectOf(name string, nodes []*model.TreeNode) (composeProject, bool) {
+	for _, node := range nodes {
+		if node.Container == nil {
+			if project, ok := composeProjectOf(name, node.Children); ok {
+				return project, true
+			}
+			continue
+		}
+		files := node.Container.Labels[composeConfigFilesLabel]
+		if files == "" {
+			continue
+		}
+		return composeProject{
+			name:       name,
+			files:      strings.Split(files, ","),
+			workingDir: node.Container.Labels[composeWorkingDirLabel],
+		}, true
+	}
+	return composeProject{}, false
+}
+
+// editorCommand returns the user's editor from $VISUAL or $EDITOR, which may include
+// arguments (e.g. "code --wait")
+func editorCommand() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return []string{"notepad"}
+	}
+	return []string{"vi"}
+}
+
+// editComposeFile suspends the TUI and opens the project's main compose file in the
+// user's editor. The file has to be on this machine, so it only works for projects
+// started locally.
+func (m *Model) editComposeFile(project composeProject) tea.Cmd {
+	file := project.files[0]
+	before, err := os.ReadFile(file)
+	if err != nil {
+		return func() tea.Msg {
+			return statusMsg(fmt.Sprintf("Compose file of %s: %v (only projects started on this machine can be edited)", project.name, err))
+		}
+	}
+
+	editor := editorCommand()
+	cmd := exec.Command(editor[0], append(editor[1:], file)...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		if err != nil {
+			return composeEditedMsg{project: project, err: err}
+		}
+		after, err := os.ReadFile(file)
+		return composeEditedMsg{project: project, changed: !bytes.Equal(before, after), err: err}
+	})
+}
+
+// handleComposeEdited offers to redeploy the project after its compose file changed
+func (m Model) handleComposeEdited(msg composeEditedMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.err != nil:
+		m.status = "Editing compose file of " + msg.project.name + ": " + msg.err.Error()
+		return m, nil
+	case !msg.changed:
+		m.status = "Compose file of " + msg.project.name + " unchanged"
+		return m, nil
+	}
+
+	m.audit.Record("edit compose file", "project "+msg.project.name, nil)
+	project := msg.project
+	m.openMenuWith("Compose file of "+project.name+" changed - redeploy?", []MenuItem{
+		{
+			Label:   "Redeploy (docker compose up -d)",
+			Mutates: true,
+			Action: func() tea.Cmd {
+				status := func() tea.Msg {
+					return statusMsg("Redeploying " + project.name + "…")
+				}
+				return tea.Batch(status, m.composeUp(project))
+			},
+		},
+		{
+			Label: "Not now",
+			Action: func() tea.Cmd {
+				return func() tea.Msg {
+					return statusMsg("Compose file of " + project.name + " saved; not redeployed")
+				}
+			},
+		},
+	})
+	return m, nil
+}
+
+// composeArgs returns the docker compose arguments selecting the project's files
+func composeArgs(project composeProject) []string {
+	args := []string{"compose", "--project-name", project.name}
+	for _, file := range project.files {
+		args = append(args, "--file", file)
+	}
+	if project.workingDir != "" {
+		args = append(args, "--project-directory", project.workingDir)
+	}
+	return args
+}
+
+// composeUp runs docker compose up -d for the project, or just the given services,
+// and shows its output
+func (m *Model) composeUp(project composeProject, services ...string) tea.Cmd {
+	args := append(composeArgs(project), "up", "--detach")
+	args = append(args, services...)
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), composeUpTimeout)
+		defer cancel()
+
+		cmd := m.dockerCommand(ctx, args...)
+		cmd.Dir = project.workingDir
+		output, err := cmd.CombinedOutput()
+		m.audit.Record("compose up", "project "+project.name, err)
+
+		lines := []string{headerStyle.Render("$ docker " + strings.Join(args, " ")), ""}
+		lines = append(lines, strings.Split(strings.TrimRight(string(output), "\n"), "\n")...)
+		lines = append(lines, "")
+		if err != nil {
+			lines = append(lines, stoppedStyle.Render("Failed: "+err.Error()))
+		} else {
+			lines = append(lines, runningStyle.Render("Redeployed "+project.name))
+		}
+		return outputMsg{title: "dtop - Redeploy " + project.name, lines: lines}
+	}
+}
+
+// dockerCommand runs the docker CLI against the daemon dtop is connected to
+func (m *Model) dockerCommand(ctx context.Context, args ...string) *exec.Cmd {
+	global := []string{}
+	cfg := m.config
+	if cfg.TLS || cfg.TLSVerify {
+		global = append(global, "--tls")
+	}
+	if cfg.TLSVerify {
+		global = append(global, "--tlsverify")
+	}
+	for flag, path := range map[string]string{"--tlscacert": cfg.TLSCACert, "--tlscert": cfg.TLSCert, "--tlskey": cfg.TLSKey} {
+		if path != "" {
+			global = append(global, flag+"="+path)
+		}
+	}
+
+	cmd := exec.CommandContext(ctx, "docker", append(global, args...)...)
+	cmd.Env = os.Environ()
+	if m.config.DockerHost != "" {
+		cmd.Env = append(cmd.Env, "DOCKER_HOST="+m.config.DockerHost)
+	}
+	return cmd
+}
@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/docker"
+)
+
+// connections is the state of the connections view
+type connections struct {
+	containerID   string
+	name          string
+	list          []docker.Connection // nil until the first read
+	err           error
+	hideListening bool // Show only connected sockets
+}
+
+// openConnectionsMsg asks the model to show the connections view; used by menu actions
+type openConnectionsMsg struct {
+	containerID string
+	name        string
+}
+
+// connectionsMsg carries the container's sockets for the connections view
+type connectionsMsg struct {
+	containerID string
+	list        []docker.Connection
+	err         error
+}
+
+// fetchConnections reads the container's sockets in the background
+func (m Model) fetchConnections(containerID string) tea.Cmd {
+	client := m.dockerClient
+	return func() tea.Msg {
+		list, err := client.ListConnections(containerID)
+		if err == nil && list == nil {
+			list = []docker.Connection{}
+		}
+		return connectionsMsg{containerID: containerID, list: list, err: err}
+	}
+}
+
+// openConnections shows the connections view and starts reading sockets; it is
+// refreshed on every tick while the view is open
+func (m *Model) openConnections(containerID, name string) tea.Cmd {
+	m.connections = &connections{containerID: containerID, name: name}
+	m.pagerScroll = 0
+	m.viewMode = ViewModeConnections
+	return m.fetchConnections(containerID)
+}
+
+func (m Model) handleConnections(msg connectionsMsg) (tea.Model, tea.Cmd) {
+	c := m.connections
+	if c == nil || c.containerID != msg.containerID {
+		return m, nil
+	}
+
+	// Copy so the previous model value keeps its own state
+	next := *c
+	next.err = msg.err
+	if msg.err == nil {
+		next.list = msg.list
+	}
+	m.connections = &next
+	return m, nil
+}
+
+func (m Model) handleConnectionsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.scrollPager(msg.String()) {
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc", "q":
+		m.viewMode = ViewModeMain
+		m.connections = nil
+	case "l":
+		next := *m.connections
+		next.hideListening = !next.hideListening
+		m.connections = &next
+		m.pagerScroll = 0
+	case "r":
+		return m, m.fetchConnections(m.connections.containerID)
+	}
+	return m, nil
+}
+
+func (m Model) renderConnections() string {
+	c := m.connections
+	title := "dtop - Connections of " + c.name
+	help := "↑↓:scroll  l:hide listening  r:refresh  q/esc:back"
+	if c.hideListening {
+		help = "↑↓:scroll  l:show listening  r:refresh  q/esc:back"
+	}
+
+	lines := []string{}
+	switch {
+	case c.err != nil:
+		lines = append(lines, stoppedStyle.Render(fmt.Sprintf("Failed to read connections: %v", c.err)))
+	case c.list == nil:
+		lines = append(lines, "Loading…")
+	}
+	if c.list == nil {
+		return m.renderPager(title, lines, help)
+	}
+
+	// Listening sockets first, then connections grouped by state
+	list := make([]docker.Connection, 0, len(c.list))
+	states := map[string]int{}
+	for _, conn := range c.list {
+		states[conn.State]++
+		if c.hideListening && conn.Listening() {
+			continue
+		}
+		list = append(list, conn)
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Listening() != list[j].Listening() {
+			return list[i].Listening()
+		}
+		if list[i].State != list[j].State {
+			return list[i].State < list[j].State
+		}
+		return list[i].Local < list[j].Local
+	})
+
+	summary := make([]string, 0, len(states))
+	for state, count := range states {
+		summary = append(summary, fmt.Sprintf("%d %s", count, state))
+	}
+	sort.Strings(summary)
+	if len(summary) == 0 {
+		summary = append(summary, "no sockets")
+	}
+	lines = append(lines, headerStyle.Render(strings.Join(summary, "  ")), "")
+
+	lines = append(lines, headerStyle.Render(fmt.Sprintf("%-5s %-12s %-46s %-46s %s",
+		"PROTO", "STATE", "LOCAL", "REMOTE", "SEND-Q/RECV-Q")))
+	for _, conn := range list {
+		line := fmt.Sprintf("%-5s %-12s %-46s %-46s %d/%d", conn.Proto, conn.State,
+			conn.Local, conn.Remote, conn.TxQueue, conn.RxQueue)
+		if conn.State == "ESTABLISHED" {
+			line = runningStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+
+	return m.renderPager(title, lines, help)
+}
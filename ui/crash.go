@@ -0,0 +1,193 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/config"
+	"github.com/ekinertac/dtop/docker"
+	"github.com/ekinertac/dtop/hooks"
+	"github.com/ekinertac/dtop/model"
+)
+
+// bellDuration is how long frames carry the bell; long enough for the renderer to
+// write one, short enough that it rarely writes another
+const bellDuration = 100 * time.Millisecond
+
+// bellDoneMsg stops frames ringing the bell
+type bellDoneMsg struct{}
+
+// flashDuration is how long a crashed container's row (or its project's) stays highlighted
+const flashDuration = 5 * time.Second
+
+// containerHealth is what crash detection remembers about a container between refreshes
+type containerHealth struct {
+	name      string
+	project   string
+	state     string
+	unhealthy bool
+}
+
+// crashMsg reports a container that crashed since the previous refresh
+type crashMsg struct{ event hooks.Event }
+
+// newCrashMsg describes a crash of the named container
+func newCrashMsg(event, containerID, name, project, message string, value float64) crashMsg {
+	return crashMsg{hooks.Event{
+		Event:       event,
+		Time:        time.Now(),
+		ContainerID: containerID,
+		Container:   name,
+		Project:     project,
+		Message:     message,
+		Value:       value,
+	}}
+}
+
+// snapshotHealth records the state of every container in the tree
+func (m Model) snapshotHealth() map[string]containerHealth {
+	health := make(map[string]containerHealth)
+	if m.tree.Root == nil {
+		return health
+	}
+	for _, project := range m.tree.Root.Children {
+		for _, child := range project.Children {
+			if c := child.Container; c != nil {
+				health[c.ID] = containerHealth{
+					name:      c.Name,
+					project:   project.Name,
+					state:     c.State,
+					unhealthy: strings.Contains(c.Status, "(unhealthy)"),
+				}
+			}
+		}
+	}
+	return health
+}
+
+// detectCrashes compares the tree against the previous snapshot. Containers that turned
+// unhealthy or started restarting are reported right away; containers that disappeared
+// while running are inspected to tell crashes from deliberate stops.
+func (m Model) detectCrashes(previous map[string]containerHealth, containers []docker.ContainerInfo) tea.Cmd {
+	cmds := []tea.Cmd{}
+	listed := make(map[string]bool, len(containers))
+
+	for i := range containers {
+		c := &containers[i]
+		listed[c.ID] = true
+		prev, ok := previous[c.ID]
+		if !ok {
+			continue
+		}
+
+		event, reason := "", ""
+		switch {
+		case strings.Contains(c.Status, "(unhealthy)") && !prev.unhealthy:
+			event, reason = config.EventUnhealthy, "became unhealthy"
+		case c.State == "restarting" && prev.state == "running":
+			event, reason = config.EventRestarting, "is restarting"
+		}
+		if event != "" {
+			msg := newCrashMsg(event, c.ID, c.Name, model.ProjectName(c, TreeOptions(m.config)), c.Name+" "+reason, 0)
+			cmds = append(cmds, func() tea.Msg { return msg })
+		}
+
+		// Recoveries aren't crashes; they only resolve notifier alerts
+		switch {
+		case prev.unhealthy && strings.Contains(c.Status, "(healthy)"):
+			m.hooks.Fire(resolvedEvent(config.EventUnhealthy, c, m.config, c.Name+" is healthy again"))
+		case prev.state == "restarting" && c.State == "running":
+			m.hooks.Fire(resolvedEvent(config.EventRestarting, c, m.config, c.Name+" is running again"))
+		}
+	}
+
+	// Telling a crash from a stop needs the daemon, which a replay doesn't have
+	if m.replay != nil {
+		return tea.Batch(cmds...)
+	}
+
+	for id, prev := range previous {
+		if listed[id] || prev.state != "running" {
+			continue
+		}
+		id, prev := id, prev
+		cmds = append(cmds, func() tea.Msg {
+			state, err := m.dockerClient.GetExitState(id)
+			if err != nil || !state.Crashed() {
+				// Removed or stopped on purpose
+				return nil
+			}
+			reason := fmt.Sprintf("%s exited with code %d", prev.name, state.ExitCode)
+			if state.OOMKilled {
+				reason = prev.name + " was killed (out of memory)"
+			}
+			return newCrashMsg(config.EventExited, id, prev.name, prev.project, reason, float64(state.ExitCode))
+		})
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// resolvedEvent describes a container recovering from an earlier event
+func resolvedEvent(event string, c *docker.ContainerInfo, cfg *config.Config, message string) hooks.Event {
+	e := newCrashMsg(event, c.ID, c.Name, model.ProjectName(c, TreeOptions(cfg)), message, 0).event
+	e.Resolved = true
+	return e
+}
+
+// handleCrash flashes the container's row, or its project's row if the container is
+// gone, fires the event's hooks and optionally rings the terminal bell
+func (m Model) handleCrash(msg crashMsg) (tea.Model, tea.Cmd) {
+	e := msg.event
+	m.status = e.Message
+	m.audit.Record("crash detected", e.Message, nil)
+	m.hooks.Fire(e)
+
+	until := time.Now().Add(flashDuration)
+	if m.tree.FindContainer(e.ContainerID) != nil {
+		m.flash[e.ContainerID] = until
+	} else {
+		m.flash[e.Project] = until
+	}
+
+	if !m.config.CrashBell {
+		return m, nil
+	}
+	// The bell goes out with a frame, as writing it directly would race the renderer
+	m.bell = true
+	return m, tea.Tick(bellDuration, func(time.Time) tea.Msg { return bellDoneMsg{} })
+}
+
+// flashing reports whether the row for key (a container ID or project name) is highlighted
+func (m Model) flashing(key string) bool {
+	until, ok := m.flash[key]
+	return ok && time.Now().Before(until)
+}
+
+// checkThresholds fires cpu and memory hooks when a new stats sample rises past their thresholds
+func (m Model) checkThresholds(c *docker.ContainerInfo, previous docker.ContainerStats) {
+	if m.hooks == nil {
+		return
+	}
+	project := model.ProjectName(c, TreeOptions(m.config))
+	m.hooks.Crossed(hooks.Event{
+		Event:       config.EventCPU,
+		Time:        time.Now(),
+		ContainerID: c.ID,
+		Container:   c.Name,
+		Project:     project,
+		Value:       c.CPUPerc,
+	}, previous.CPUPerc)
+	if c.Memory.Reported {
+		m.hooks.Crossed(hooks.Event{
+			Event:       config.EventMemory,
+			Time:        time.Now(),
+			ContainerID: c.ID,
+			Container:   c.Name,
+			Project:     project,
+			Value:       c.MemPerc,
+		}, previous.MemPerc)
+	}
+}
@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/docker"
+)
+
+// openCreateForm opens the "New container" wizard
+func (m *Model) openCreateForm() {
+	fields := []formField{
+		{Label: "Image", Placeholder: "nginx:latest"},
+		{Label: "Name", Placeholder: "(optional)"},
+		{Label: "Ports", Placeholder: "8080:80, 8443:443"},
+		{Label: "Env", Placeholder: "KEY=value, OTHER=value"},
+		{Label: "Volumes", Placeholder: "/host/path:/container/path"},
+		{Label: "Restart policy", Value: "no", Placeholder: "no | on-failure | unless-stopped | always"},
+	}
+
+	m.openForm(newForm("New container", fields, func(values []string) tea.Cmd {
+		opts := docker.CreateOptions{
+			Image:         values[0],
+			Name:          values[1],
+			Ports:         splitList(values[2]),
+			Env:           splitList(values[3]),
+			Volumes:       splitList(values[4]),
+			RestartPolicy: values[5],
+		}
+		if opts.Image == "" {
+			return nil
+		}
+
+		// Pulling a missing image can take a while, so show its progress
+		return m.startPull("Creating "+opts.Image, func(progress func(docker.PullProgress)) error {
+			_, err := m.dockerClient.CreateContainer(opts, progress)
+			m.audit.Record("create", strings.TrimSpace(opts.Image+" "+opts.Name), err)
+			return err
+		})
+	}))
+}
+
+// splitList splits a comma separated form value into trimmed, non-empty items
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,176 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/docker"
+	"github.com/ekinertac/dtop/model"
+)
+
+// openDetailMsg asks the model to show the detail view; used by menu actions
+type openDetailMsg struct{ containerID string }
+
+func showDetail(containerID string) tea.Cmd {
+	return func() tea.Msg {
+		return openDetailMsg{containerID}
+	}
+}
+
+// openDetail shows the detail view for the container with the given ID and looks up
+// the details that aren't part of the container list
+func (m *Model) openDetail(containerID string) tea.Cmd {
+	m.detailID = containerID
+	m.detailPolicy = ""
+	m.detailNetwork = nil
+	m.detailNetErr = nil
+	m.pagerScroll = 0
+	m.viewMode = ViewModeDetail
+	if m.replay != nil {
+		m.detailPolicy = "unknown (replay)"
+		return nil
+	}
+	return tea.Batch(m.fetchRestartPolicy(containerID), m.fetchNetworkInfo(containerID))
+}
+
+func (m Model) handleDetailKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.scrollPager(msg.String()) {
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc", "q":
+		m.viewMode = ViewModeMain
+		m.detailID = ""
+	case "c":
+		m.copyIP()
+	case "p":
+		node := m.tree.FindContainer(m.detailID)
+		switch {
+		case node == nil || m.replay != nil:
+		case m.readOnly:
+			m.status = "Read-only: changing the restart policy is disabled (R to allow changes)"
+		default:
+			m.detailPolicy = ""
+			return m, m.cycleRestartPolicy(m.detailID, node.Container.Name)
+		}
+	}
+	return m, nil
+}
+
+func (m Model) renderDetail() string {
+	// Live data comes from the tree so the view updates on every refresh
+	var c *docker.ContainerInfo
+	if node := m.tree.FindContainer(m.detailID); node != nil {
+		c = node.Container
+	}
+
+	title := "dtop - Details"
+	if c != nil {
+		title = fmt.Sprintf("dtop - Details: %s", c.Name)
+	}
+	lines := []string{}
+	if c == nil {
+		lines = append(lines, "Container is no longer running")
+	} else {
+		policy := m.detailPolicy
+		if policy == "" {
+			policy = "loading…"
+		}
+		lines = append(lines, m.noteDetailLines(c)...)
+		lines = append(lines, detailSection("Container", [][2]string{
+			{"ID", c.ID},
+			{"Name", c.Name},
+			{"Image", c.Image},
+			{"State", c.State},
+			{"Status", c.Status},
+			{"Created", c.CreatedAt.Format(time.RFC1123)},
+			{"Started", startedText(c)},
+			{"Uptime", model.ContainerUptime(c)},
+			{"Restart policy", policy},
+		})...)
+		lines = append(lines, cpuDetailLines(c)...)
+		lines = append(lines, memoryDetailLines(c)...)
+		lines = append(lines, m.eventDetailLines(c.ID)...)
+		lines = append(lines, m.networkDetailLines()...)
+		lines = append(lines, detailSection("Block I/O", [][2]string{
+			{"Read", fmt.Sprintf("%s (%s/s)", formatNetBytes(c.Block.Read), formatNetBytes(uint64(c.Block.ReadRate)))},
+			{"Write", fmt.Sprintf("%s (%s/s)", formatNetBytes(c.Block.Write), formatNetBytes(uint64(c.Block.WriteRate)))},
+		})...)
+	}
+
+	return m.renderPager(title, lines, "↑↓:scroll  p:cycle restart policy  c:copy IP  q/esc:back")
+}
+
+// detailSection renders a titled block of label/value rows followed by a blank line
+func detailSection(title string, rows [][2]string) []string {
+	lines := []string{projectStyle.Render(title)}
+	for _, row := range rows {
+		lines = append(lines, "  "+headerStyle.Render(truncateOrPad(row[0], 20))+row[1])
+	}
+	return append(lines, "")
+}
+
+// cpuDetailLines shows usage, CFS throttling and the per-core breakdown
+func cpuDetailLines(c *docker.ContainerInfo) []string {
+	t := c.Throttling
+	throttled := "never"
+	if t.Periods > 0 {
+		throttled = fmt.Sprintf("%d of %d periods (%.1f%%)",
+			t.ThrottledPeriods, t.Periods, float64(t.ThrottledPeriods)/float64(t.Periods)*100.0)
+	}
+
+	lines := detailSection("CPU", [][2]string{
+		{"Usage", fmt.Sprintf("%.1f%%", c.CPUPerc)},
+		{"Throttled", throttled},
+		{"Throttled time", t.ThrottledTime.Round(time.Millisecond).String()},
+	})
+
+	// Drop the section's trailing blank line to append the per-core rows
+	lines = lines[:len(lines)-1]
+	if len(c.PerCPUPerc) == 0 {
+		lines = append(lines, "  "+headerStyle.Render(truncateOrPad("Per core", 20))+"not reported (cgroup v2)")
+	}
+	for i, perc := range c.PerCPUPerc {
+		label := truncateOrPad(fmt.Sprintf("cpu%d", i), 20)
+		lines = append(lines, fmt.Sprintf("  %s%5.1f%% %s", headerStyle.Render(label), perc, renderProgressBar(perc, 20)))
+	}
+
+	return append(lines, "")
+}
+
+// memoryDetailLines shows usage against the configured limit and the cache/RSS/swap split
+func memoryDetailLines(c *docker.ContainerInfo) []string {
+	mem := c.Memory
+	if !mem.Reported {
+		return detailSection("Memory", [][2]string{
+			{"Usage", "not reported by the daemon (rootless or no memory cgroup controller)"},
+		})
+	}
+
+	host := "unknown"
+	if mem.HostTotal > 0 {
+		host = formatNetBytes(mem.HostTotal)
+	}
+	limit := "none (host " + host + ")"
+	if mem.Limited() {
+		limit = formatNetBytes(mem.Limit) + " (host " + host + ")"
+	}
+
+	return detailSection("Memory", [][2]string{
+		{"Usage", fmt.Sprintf("%s (%.1f%%, excl. cache)", formatNetBytes(mem.Usage), c.MemPerc)},
+		{"Limit", limit},
+		{"RSS", formatNetBytes(mem.RSS)},
+		{"Cache", formatNetBytes(mem.Cache)},
+		{"Swap", formatNetBytes(mem.Swap)},
+	})
+}
+
+// startedText formats when a running container last started
+func startedText(c *docker.ContainerInfo) string {
+	if c.State != "running" || c.StartedAt.IsZero() {
+		return "-"
+	}
+	return c.StartedAt.Format(time.RFC1123)
+}
@@ -0,0 +1,221 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/docker"
+)
+
+// progressMsg reports the state of a long-running transfer
+type progressMsg struct {
+	label string
+	bytes uint64
+	done  bool
+	err   error
+	ch    <-chan progressMsg
+}
+
+// progressWriter counts bytes written and publishes throttled progress updates
+type progressWriter struct {
+	w       io.Writer
+	label   string
+	written uint64
+	last    time.Time
+	ch      chan progressMsg
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.written += uint64(n)
+
+	if time.Since(p.last) > 200*time.Millisecond {
+		p.last = time.Now()
+		// Drop the update if the UI hasn't consumed the previous one yet
+		select {
+		case p.ch <- progressMsg{label: p.label, bytes: p.written, ch: p.ch}:
+		default:
+		}
+	}
+	return n, err
+}
+
+// waitForProgress delivers the next progress update from a transfer
+func waitForProgress(ch <-chan progressMsg) tea.Cmd {
+	return func() tea.Msg {
+		return <-ch
+	}
+}
+
+// writeToFile runs write against a new file at path, reporting progress until done.
+// An existing file is only replaced when overwrite is set.
+func writeToFile(label, path string, overwrite bool, write func(w io.Writer) error) tea.Cmd {
+	ch := make(chan progressMsg, 1)
+
+	go func() {
+		pw := &progressWriter{label: label, ch: ch}
+		err := func() error {
+			flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+			if overwrite {
+				flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+			}
+			f, err := os.OpenFile(path, flags, 0o644)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			pw.w = f
+			if err := write(pw); err != nil {
+				os.Remove(path)
+				return err
+			}
+			return nil
+		}()
+		// Final message always gets through
+		ch <- progressMsg{label: label, bytes: pw.written, done: true, err: err, ch: ch}
+	}()
+
+	return waitForProgress(ch)
+}
+
+// saveToFile calls save with path, or when the file exists asks whether to overwrite
+// it or save under a numbered name next to it instead
+func saveToFile(path string, save func(path string, overwrite bool) tea.Cmd) tea.Cmd {
+	if _, err := os.Stat(path); err != nil {
+		return save(path, false)
+	}
+	alternative := freePath(path)
+	return func() tea.Msg {
+		return openMenuMsg{
+			title: path + " already exists",
+			items: []MenuItem{
+				{Label: "Overwrite it", Action: func() tea.Cmd { return save(path, true) }},
+				{Label: "Save as " + alternative, Action: func() tea.Cmd { return save(alternative, false) }},
+				{Label: "Cancel", Action: func() tea.Cmd { return nil }},
+			},
+		}
+	}
+}
+
+// freePath returns path with the first number before its extension that doesn't
+// name an existing file, e.g. "web.log" -> "web-1.log"
+func freePath(path string) string {
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	for i := 1; ; i++ {
+		candidate := fmt.Sprintf("%s-%d%s", base, i, ext)
+		if _, err := os.Stat(candidate); err != nil {
+			return candidate
+		}
+	}
+}
+
+func (m Model) handleProgress(msg progressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.err != nil:
+		m.status = fmt.Sprintf("%s failed: %v", msg.label, msg.err)
+		return m, nil
+	case msg.done:
+		m.status = fmt.Sprintf("%s done (%s)", msg.label, formatNetBytes(msg.bytes))
+		return m, nil
+	}
+
+	m.status = fmt.Sprintf("%s… %s", msg.label, formatNetBytes(msg.bytes))
+	return m, waitForProgress(msg.ch)
+}
+
+// exportForm prompts for a path and exports the container filesystem to it
+func (m *Model) exportForm(containerID, containerName string) *form {
+	fields := []formField{
+		{Label: "Path", Value: containerName + ".tar"},
+	}
+
+	return newForm("Export filesystem of "+containerName, fields, func(values []string) tea.Cmd {
+		path := values[0]
+		if path == "" {
+			return nil
+		}
+		return saveToFile(path, func(path string, overwrite bool) tea.Cmd {
+			label := fmt.Sprintf("Exporting %s to %s", containerName, path)
+			return writeToFile(label, path, overwrite, func(w io.Writer) error {
+				err := m.dockerClient.ExportContainer(containerID, w)
+				m.audit.Record("export", containerName+" to "+path, err)
+				return err
+			})
+		})
+	})
+}
+
+// saveImageForm prompts for a path and saves the container's image to it
+func (m *Model) saveImageForm(imageRef string) *form {
+	// Image refs contain characters that don't belong in file names
+	defaultPath := strings.NewReplacer("/", "_", ":", "_", "@", "_").Replace(imageRef) + ".tar"
+	fields := []formField{
+		{Label: "Path", Value: defaultPath},
+	}
+
+	return newForm("Save image "+imageRef, fields, func(values []string) tea.Cmd {
+		path := values[0]
+		if path == "" {
+			return nil
+		}
+		return saveToFile(path, func(path string, overwrite bool) tea.Cmd {
+			label := fmt.Sprintf("Saving %s to %s", imageRef, path)
+			return writeToFile(label, path, overwrite, func(w io.Writer) error {
+				err := m.dockerClient.SaveImage(imageRef, w)
+				m.audit.Record("save image", imageRef+" to "+path, err)
+				return err
+			})
+		})
+	})
+}
+
+// saveLogsForm prompts for a path and writes the container's logs to it: the lines
+// loaded in the logs view, the last N lines or the full log from the daemon
+func (m *Model) saveLogsForm(containerID, containerName, loaded string) *form {
+	fields := []formField{
+		{Label: "Path", Value: fmt.Sprintf("%s-%s.log", containerName, time.Now().Format("20060102-150405"))},
+		{Label: "Lines", Value: "all", Placeholder: "all | loaded | number of last lines"},
+	}
+
+	f := newForm("Save logs of "+containerName, fields, func(values []string) tea.Cmd {
+		path := values[0]
+		if path == "" {
+			return nil
+		}
+		opts := docker.LogOptions{}
+		switch values[1] {
+		case "all", "", "loaded":
+		default:
+			tail, err := strconv.Atoi(values[1])
+			if err != nil || tail <= 0 {
+				return func() tea.Msg {
+					return statusMsg(fmt.Sprintf("Invalid lines %q (all, loaded or a number)", values[1]))
+				}
+			}
+			opts.Tail = tail
+		}
+		return saveToFile(path, func(path string, overwrite bool) tea.Cmd {
+			label := fmt.Sprintf("Saving logs of %s to %s", containerName, path)
+			return writeToFile(label, path, overwrite, func(w io.Writer) error {
+				var err error
+				if values[1] == "loaded" {
+					_, err = io.WriteString(w, loaded)
+				} else {
+					err = m.dockerClient.StreamLogs(containerID, opts, w, w)
+				}
+				m.audit.Record("save logs", containerName+" to "+path, err)
+				return err
+			})
+		})
+	})
+	f.back = ViewModeLogs
+	return f
+}
@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"github.com/ekinertac/dtop/docker"
+	"github.com/ekinertac/dtop/model"
+)
+
+// toggleFavorite pins or unpins the selected container or project and saves the
+// favorites right away, so they survive a crash
+func (m *Model) toggleFavorite() {
+	node := m.tree.GetSelected()
+	if node == nil {
+		return
+	}
+	if node.Container == nil && node.Name == model.FavoritesProject {
+		m.status = "Select a container in Favorites to unpin it"
+		return
+	}
+
+	key := model.FavoriteKey(node)
+	if m.favorites[key] {
+		delete(m.favorites, key)
+		m.status = "Unpinned " + node.Name
+	} else {
+		m.favorites[key] = true
+		m.status = "Pinned " + node.Name
+	}
+
+	// Regroup now rather than on the next refresh; nodes keep their identity, so the
+	// selection follows the pinned node
+	containers := make([]docker.ContainerInfo, 0)
+	for _, c := range m.tree.Containers() {
+		containers = append(containers, *c)
+	}
+	m.tree.Update(containers, m.treeOptions())
+	if node.Parent != nil {
+		m.tree.Select(node)
+	}
+	m.adjustViewport()
+	m.saveState()
+}
+
+// isFavorite reports whether the node is pinned
+func (m Model) isFavorite(node *model.TreeNode) bool {
+	return m.favorites[model.FavoriteKey(node)]
+}
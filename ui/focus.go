@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// poll refreshes the container list and whatever the current view shows
+func (m Model) poll() tea.Cmd {
+	cmds := []tea.Cmd{m.refreshContainers(), m.fetchLogStrip()}
+	if m.viewMode == ViewModeHost {
+		cmds = append(cmds, m.fetchHostInfo())
+	}
+	if m.viewMode == ViewModeProcesses && m.processes.previous != nil {
+		cmds = append(cmds, m.fetchProcesses(m.processes.containerID, 0))
+	}
+	if m.viewMode == ViewModeConnections {
+		cmds = append(cmds, m.fetchConnections(m.connections.containerID))
+	}
+	return tea.Batch(cmds...)
+}
+
+// pollDue reports whether a tick at now should refresh: on every tick while the
+// terminal has focus, and once per unfocused interval while it doesn't
+func (m Model) pollDue(now time.Time) bool {
+	interval := time.Duration(m.config.UnfocusedInterval) * time.Second
+	if !m.blurred || interval <= refreshInterval {
+		return true
+	}
+	return now.Sub(m.lastPoll) >= interval
+}
+
+// handleFocus slows refreshes down while the terminal is unfocused, saving daemon and
+// CPU load, and catches up right away when focus returns. Terminals report focus
+// changes when asked to; tmux passes them on with `set -g focus-events on`.
+func (m Model) handleFocus(focused bool) (tea.Model, tea.Cmd) {
+	wasBlurred := m.blurred
+	m.blurred = !focused
+	if !focused || !wasBlurred || m.replay != nil {
+		return m, nil
+	}
+	m.lastPoll = time.Now()
+	return m, m.poll()
+}
@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/i18n"
+)
+
+// formField is a single labelled text input
+type formField struct {
+	Label       string
+	Value       string
+	Placeholder string
+	Secret      bool // Mask the value, e.g. for passwords
+}
+
+// form is a minimal multi-field text form used for wizards and prompts
+type form struct {
+	title    string
+	fields   []formField
+	focused  int
+	onSubmit func(values []string) tea.Cmd
+	back     ViewMode                       // View to return to when the form closes
+	preview  func(values []string) []string // Optional lines shown below the fields, redrawn as values change
+}
+
+func newForm(title string, fields []formField, onSubmit func(values []string) tea.Cmd) *form {
+	return &form{
+		title:    title,
+		fields:   fields,
+		onSubmit: onSubmit,
+	}
+}
+
+// values returns the trimmed value of every field in order
+func (f *form) values() []string {
+	values := make([]string, len(f.fields))
+	for i, field := range f.fields {
+		values[i] = strings.TrimSpace(field.Value)
+	}
+	return values
+}
+
+// openFormMsg asks the model to show a form; used by menu actions
+type openFormMsg struct{ form *form }
+
+func showForm(f *form) tea.Cmd {
+	return func() tea.Msg {
+		return openFormMsg{f}
+	}
+}
+
+func (m *Model) openForm(f *form) {
+	m.form = f
+	m.viewMode = ViewModeForm
+}
+
+func (m Model) handleFormKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	f := m.form
+	if f == nil {
+		m.viewMode = ViewModeMain
+		return m, nil
+	}
+
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.form = nil
+		m.viewMode = f.back
+	case tea.KeyEnter:
+		values := f.values()
+		m.form = nil
+		m.viewMode = f.back
+		return m, f.onSubmit(values)
+	case tea.KeyTab, tea.KeyDown:
+		f.focused = (f.focused + 1) % len(f.fields)
+	case tea.KeyShiftTab, tea.KeyUp:
+		f.focused = (f.focused - 1 + len(f.fields)) % len(f.fields)
+	case tea.KeyBackspace:
+		runes := []rune(f.fields[f.focused].Value)
+		if len(runes) > 0 {
+			f.fields[f.focused].Value = string(runes[:len(runes)-1])
+		}
+	case tea.KeyCtrlU:
+		f.fields[f.focused].Value = ""
+	case tea.KeySpace:
+		f.fields[f.focused].Value += " "
+	case tea.KeyRunes:
+		f.fields[f.focused].Value += string(msg.Runes)
+	}
+
+	return m, nil
+}
+
+func (m Model) renderForm() string {
+	var b strings.Builder
+
+	// Title
+	b.WriteString(titleStyle.Render(i18n.T("dtop - Docker Container Monitor")))
+	b.WriteString("\n\n")
+
+	if m.form == nil {
+		return b.String()
+	}
+
+	b.WriteString(projectStyle.Render(m.form.title))
+	b.WriteString("\n\n")
+
+	// Fields
+	for i, field := range m.form.fields {
+		label := truncateOrPad(i18n.T(field.Label), 16)
+		value := field.Value
+		if field.Secret {
+			value = strings.Repeat("•", len([]rune(value)))
+		}
+		if i == m.form.focused {
+			b.WriteString(menuSelectedStyle.Render("> " + label + value + "█"))
+		} else {
+			if value == "" {
+				value = headerStyle.Render(field.Placeholder)
+			}
+			b.WriteString(menuItemStyle.Render("  " + label + value))
+		}
+		b.WriteString("\n")
+	}
+
+	// Live preview of the entered values
+	if m.form.preview != nil {
+		b.WriteString("\n")
+		for _, line := range m.form.preview(m.form.values()) {
+			b.WriteString("  " + line + "\n")
+		}
+	}
+
+	// Help text
+	b.WriteString("\n")
+	helpText := "tab/↑↓:field  enter:submit  ctrl+u:clear  esc:cancel"
+	b.WriteString(helpStyle.Render(i18n.Help(helpText)))
+
+	return b.String()
+}
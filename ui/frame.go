@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ekinertac/dtop/docker"
+)
+
+// frameCache holds the last rendered frame. It is shared by every copy of the model,
+// so messages that change nothing on screen reuse the frame instead of rebuilding it.
+type frameCache struct {
+	view  string
+	valid bool
+}
+
+func (f *frameCache) invalidate() {
+	f.valid = false
+}
+
+// statsSignature is what a container row shows of a stats sample: the gauge labels
+// and bars with their threshold colors and the I/O and PIDS columns. A sample with
+// the same signature as the previous one doesn't need a new frame. Project summaries
+// and the host bar sum unrounded values and catch up on the next container list.
+func (m Model) statsSignature(c *docker.ContainerInfo) string {
+	gauge := m.layout().gauge
+	cpuLabel, memLabel := m.gaugeTexts(c)
+	cpuColor, memColor := m.usageColors(c)
+	return strings.Join([]string{
+		cpuLabel, renderProgressBar(c.CPUPerc, gauge-len(cpuLabel)-2), string(cpuColor),
+		memLabel, renderProgressBar(c.MemPerc, gauge-len(memLabel)-2), string(memColor),
+		fmt.Sprint(c.MemUsage == "N/A"),
+		formatNetBytes(c.NetRx), formatNetBytes(c.NetTx),
+		formatNetBytes(uint64(c.Block.ReadRate)), formatNetBytes(uint64(c.Block.WriteRate)),
+		formatPIDs(c.PIDs, c.PIDsLimit), formatGPU(c),
+	}, "|")
+}
+
+// rowCache keeps the container rows of the last frames rendered, keyed by what they
+// show, so a frame only styles the rows that changed since. Rows not drawn by a frame
+// are dropped when the next one starts, which bounds it to about two screens.
+type rowCache struct {
+	current  map[containerRow]string
+	previous map[containerRow]string
+}
+
+func newRowCache() *rowCache {
+	return &rowCache{current: make(map[containerRow]string), previous: make(map[containerRow]string)}
+}
+
+// next starts a frame; rows the last frame drew stay available to it
+func (r *rowCache) next() {
+	r.previous, r.current = r.current, make(map[containerRow]string, len(r.current))
+}
+
+// render returns the styled row, rendering it only if neither frame drew it
+func (r *rowCache) render(row containerRow) string {
+	if line, ok := r.current[row]; ok {
+		return line
+	}
+	line, ok := r.previous[row]
+	if !ok {
+		line = row.render()
+	}
+	r.current[row] = line
+	return line
+}
@@ -0,0 +1,291 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// graphStyle is how history graphs are drawn
+type graphStyle string
+
+const (
+	graphBlocks  graphStyle = "blocks"  // Eighth blocks, one sample per cell
+	graphBraille graphStyle = "braille" // Braille dots, two samples per cell
+	graphKitty   graphStyle = "kitty"   // Kitty graphics protocol with Unicode placeholders
+	graphITerm   graphStyle = "iterm2"  // iTerm2 inline images
+	graphSixel   graphStyle = "sixel"
+)
+
+// Assumed pixel size of a terminal cell. Kitty and iTerm2 scale images to the cells
+// they cover, so this only sets their resolution; sixel images are drawn at it.
+const (
+	graphCellWidth  = 10
+	graphCellHeight = 20
+)
+
+// kittyChunk is the largest payload the kitty protocol accepts per escape sequence
+const kittyChunk = 4096
+
+// resolveGraphStyle turns the graphs setting into a style, detecting the terminal for auto
+func resolveGraphStyle(setting string) graphStyle {
+	switch setting {
+	case "":
+		return graphBlocks
+	case "auto":
+		return detectGraphics()
+	}
+	return graphStyle(setting)
+}
+
+// detectGraphics picks the graphics protocol of a terminal recognized from the
+// environment it sets, or braille for the rest. Graphics don't pass through tmux.
+func detectGraphics() graphStyle {
+	term, program := os.Getenv("TERM"), os.Getenv("TERM_PROGRAM")
+	switch {
+	case os.Getenv("TMUX") != "":
+		return graphBraille
+	case os.Getenv("KITTY_WINDOW_ID") != "", term == "xterm-kitty", program == "ghostty":
+		return graphKitty
+	case program == "iTerm.app", program == "WezTerm", os.Getenv("LC_TERMINAL") == "iTerm2":
+		return graphITerm
+	}
+	return graphBraille
+}
+
+// graph renders values as a chart one row high and width cells wide in the accent
+// color; a zero scale fits the largest value. key names the graph on screen, so a
+// redrawn kitty image replaces its previous version.
+func (style graphStyle) graph(values []float64, width int, scale float64, key string) string {
+	// The numbers next to graphs say what screen readers can use
+	if accessibleMode {
+		return ""
+	}
+	accent := lipgloss.NewStyle().Foreground(primaryColor)
+	if style == graphBraille {
+		return accent.Render(braille(values, width, scale))
+	}
+
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+	if scale <= 0 {
+		for _, v := range values {
+			scale = max(scale, v)
+		}
+	}
+	switch style {
+	case graphKitty:
+		return kittyImage(graphPNG(values, width, scale), width, key)
+	case graphITerm:
+		return itermImage(graphPNG(values, width, scale), width)
+	case graphSixel:
+		return sixelImage(values, width, scale)
+	}
+	return accent.Render(sparkline(values, width, scale))
+}
+
+// Braille dots filling a cell's left and right column from the bottom, by level
+var (
+	brailleLeft  = [5]rune{0, 0x40, 0x44, 0x46, 0x47}
+	brailleRight = [5]rune{0, 0x80, 0xA0, 0xB0, 0xB8}
+)
+
+// braille renders the last 2×width values as braille dots, two per cell, with four
+// levels each; cells before the first value stay blank
+func braille(values []float64, width int, scale float64) string {
+	if len(values) > 2*width {
+		values = values[len(values)-2*width:]
+	}
+	if scale <= 0 {
+		for _, v := range values {
+			scale = max(scale, v)
+		}
+	}
+
+	// Level per dot column, right-aligned; 0 is no sample, samples show at least one dot
+	levels := make([]int, 2*width)
+	offset := 2*width - len(values)
+	for i, v := range values {
+		level := 1
+		if scale > 0 {
+			level = 1 + int(v/scale*3+0.5)
+		}
+		levels[offset+i] = min(level, 4)
+	}
+
+	var b strings.Builder
+	for cell := 0; cell < width; cell++ {
+		left, right := levels[2*cell], levels[2*cell+1]
+		if left == 0 && right == 0 {
+			b.WriteByte(' ')
+			continue
+		}
+		b.WriteRune(0x2800 | brailleLeft[left] | brailleRight[right])
+	}
+	return b.String()
+}
+
+// graphTops rasterizes values as an area chart w×h pixels large, giving the top
+// filled row of each pixel column, or -1 before the first sample. Values are
+// interpolated between samples so the outline is smooth.
+func graphTops(values []float64, width int, scale float64, w, h int) []int {
+	tops := make([]int, w)
+	offset := float64(width - len(values))
+	cell := float64(w) / float64(width)
+	for x := range tops {
+		tops[x] = -1
+		pos := (float64(x)+0.5)/cell - offset - 0.5 // In samples
+		if len(values) == 0 || pos < -0.5 {
+			continue
+		}
+		pos = min(max(pos, 0), float64(len(values)-1))
+		i := int(pos)
+		v := values[i]
+		if i+1 < len(values) {
+			v += (values[i+1] - v) * (pos - float64(i))
+		}
+		level := 0.0
+		if scale > 0 {
+			level = min(v/scale, 1)
+		}
+		tops[x] = h - 1 - int(level*float64(h-1))
+	}
+	return tops
+}
+
+// accentRGB is the accent color as 8-bit RGB
+func accentRGB() (r, g, b uint8) {
+	if _, err := fmt.Sscanf(string(primaryColor), "#%02x%02x%02x", &r, &g, &b); err == nil {
+		return r, g, b
+	}
+	r32, g32, b32, _ := primaryColor.RGBA()
+	return uint8(r32 >> 8), uint8(g32 >> 8), uint8(b32 >> 8)
+}
+
+// graphPNG draws the chart as a PNG with a solid outline over a translucent fill
+func graphPNG(values []float64, width int, scale float64) []byte {
+	w, h := width*graphCellWidth, graphCellHeight
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	r, g, b := accentRGB()
+	for x, top := range graphTops(values, width, scale, w, h) {
+		if top < 0 {
+			continue
+		}
+		for y := top; y < h; y++ {
+			alpha := uint8(110)
+			if y < top+2 {
+				alpha = 255
+			}
+			img.SetNRGBA(x, y, color.NRGBA{r, g, b, alpha})
+		}
+	}
+	var buf bytes.Buffer
+	// Encoding an in-memory image can't fail
+	png.Encode(&buf, img)
+	return buf.Bytes()
+}
+
+// graphID derives the kitty image ID of a graph from its key; IDs are 24-bit so
+// they fit the placeholder color
+func graphID(key string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return max(h.Sum32()&0xFFFFFF, 1)
+}
+
+// kittyImage transmits the image as a virtual placement and shows it through Unicode
+// placeholder cells. The placeholders are ordinary text to the renderer, so the image
+// goes away when the cells are redrawn with something else.
+func kittyImage(data []byte, width int, key string) string {
+	id := graphID(key)
+	payload := base64.StdEncoding.EncodeToString(data)
+
+	var b strings.Builder
+	for first := true; ; first = false {
+		chunk := payload[:min(len(payload), kittyChunk)]
+		payload = payload[len(chunk):]
+		more := 0
+		if payload != "" {
+			more = 1
+		}
+		if first {
+			fmt.Fprintf(&b, "\x1b_Ga=T,U=1,f=100,i=%d,c=%d,r=1,q=2,m=%d;%s\x1b\\", id, width, more, chunk)
+		} else {
+			fmt.Fprintf(&b, "\x1b_Gm=%d;%s\x1b\\", more, chunk)
+		}
+		if more == 0 {
+			break
+		}
+	}
+
+	// The foreground color carries the image ID; the first cell names row and column
+	// 0 and the following ones continue the row
+	fmt.Fprintf(&b, "\x1b[38;2;%d;%d;%dm", id>>16&0xFF, id>>8&0xFF, id&0xFF)
+	b.WriteString("\U0010EEEE\u0305\u0305")
+	b.WriteString(strings.Repeat("\U0010EEEE", width-1))
+	b.WriteString("\x1b[39m")
+	return b.String()
+}
+
+// itermImage draws the image at the cursor and moves the cursor past it; text written
+// over the cells later replaces the image
+func itermImage(data []byte, width int) string {
+	return fmt.Sprintf("\x1b7\x1b]1337;File=inline=1;size=%d;width=%d;height=1;preserveAspectRatio=0;doNotMoveCursor=1:%s\a\x1b8\x1b[%dC",
+		len(data), width, base64.StdEncoding.EncodeToString(data), width)
+}
+
+// sixelImage draws the chart as a sixel image on a transparent background and moves
+// the cursor past it
+func sixelImage(values []float64, width int, scale float64) string {
+	w, h := width*graphCellWidth, graphCellHeight
+	tops := graphTops(values, width, scale, w, h)
+	r, g, b := accentRGB()
+
+	var s strings.Builder
+	s.WriteString("\x1b7\x1bP0;1;0q")
+	fmt.Fprintf(&s, "\"1;1;%d;%d#0;2;%d;%d;%d", w, h, int(r)*100/255, int(g)*100/255, int(b)*100/255)
+	for band := 0; band < h; band += 6 {
+		if band > 0 {
+			s.WriteByte('-')
+		}
+		s.WriteString("#0")
+
+		// Columns of six pixels, run-length encoded
+		var prev byte
+		run := 0
+		flush := func() {
+			if run > 3 {
+				fmt.Fprintf(&s, "!%d%c", run, prev)
+			} else {
+				s.WriteString(strings.Repeat(string(prev), run))
+			}
+		}
+		for x := 0; x < w; x++ {
+			bits := 0
+			for dy := 0; dy < 6 && band+dy < h; dy++ {
+				if tops[x] >= 0 && band+dy >= tops[x] {
+					bits |= 1 << dy
+				}
+			}
+			if c := byte(63 + bits); c == prev {
+				run++
+			} else {
+				flush()
+				prev, run = c, 1
+			}
+		}
+		flush()
+	}
+	s.WriteString("\x1b\\\x1b8")
+	fmt.Fprintf(&s, "\x1b[%dC", width)
+	return s.String()
+}
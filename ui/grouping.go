@@ -0,0 +1,86 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/config"
+	"github.com/ekinertac/dtop/docker"
+	"github.com/ekinertac/dtop/model"
+)
+
+// startGrouping is the grouping the config asks for, by project when unset
+func startGrouping(cfg *config.Config) model.Grouping {
+	if cfg.GroupBy == "" {
+		return model.GroupByProject
+	}
+	return model.Grouping(cfg.GroupBy)
+}
+
+// cycleGrouping switches the tree to the next grouping, regrouping the listed
+// containers right away and refreshing to bring back compose placeholders. The
+// next frame of a replay regroups the recording.
+func (m *Model) cycleGrouping() tea.Cmd {
+	next := model.Groupings[0]
+	for i, g := range model.Groupings {
+		if g == m.grouping && i+1 < len(model.Groupings) {
+			next = model.Groupings[i+1]
+		}
+	}
+	m.grouping = next
+	m.tree.Zoom = ""
+	m.status = "Grouping by " + string(next)
+
+	if m.tree.Root == nil {
+		return nil
+	}
+	node := m.tree.GetSelected()
+	containers := make([]docker.ContainerInfo, 0)
+	for _, c := range m.tree.Containers() {
+		if !isPlaceholder(c) {
+			containers = append(containers, *c)
+		}
+	}
+	m.tree.Update(containers, m.treeOptions())
+	if node != nil && node.Container != nil && !isPlaceholder(node.Container) {
+		m.tree.Select(node)
+	}
+	m.adjustViewport()
+	if m.replay != nil {
+		return nil
+	}
+	return m.refreshContainers()
+}
+
+// startOrder is the order the config asks for, by name when unset
+func startOrder(cfg *config.Config) model.Order {
+	if cfg.SortBy == "" {
+		return model.OrderByName
+	}
+	return model.Order(cfg.SortBy)
+}
+
+// cycleOrder switches to the next order within groups and re-sorts right away;
+// afterwards rows are re-sorted on every refresh, not on every stats sample
+func (m *Model) cycleOrder() {
+	next := model.Orders[0]
+	for i, o := range model.Orders {
+		if o == m.order && i+1 < len(model.Orders) {
+			next = model.Orders[i+1]
+		}
+	}
+	m.order = next
+	m.status = "Sorting by " + string(next)
+
+	if m.tree.Root == nil {
+		return
+	}
+	node := m.tree.GetSelected()
+	containers := make([]docker.ContainerInfo, 0)
+	for _, c := range m.tree.Containers() {
+		containers = append(containers, *c)
+	}
+	m.tree.Update(containers, m.treeOptions())
+	if node != nil {
+		m.tree.Select(node)
+	}
+	m.adjustViewport()
+}
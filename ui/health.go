@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// runHealthcheck runs the container's healthcheck now and shows its output and exit code
+func (m *Model) runHealthcheck(containerID, containerName string) tea.Cmd {
+	return func() tea.Msg {
+		result, err := m.dockerClient.RunHealthcheck(containerID)
+		m.audit.Record("healthcheck", containerName, err)
+		if err != nil {
+			return outputMsg{
+				title: "dtop - Healthcheck: " + containerName,
+				lines: []string{stoppedStyle.Render(err.Error())},
+			}
+		}
+
+		verdict := runningStyle.Render("healthy")
+		if result.ExitCode != 0 {
+			verdict = stoppedStyle.Render("unhealthy")
+		}
+		lines := detailSection("Healthcheck", [][2]string{
+			{"Command", strings.Join(result.Command, " ")},
+			{"Exit code", fmt.Sprintf("%d (%s)", result.ExitCode, verdict)},
+			{"Duration", result.Duration.Round(time.Millisecond).String()},
+		})
+		lines = append(lines, projectStyle.Render("Output"))
+		output := strings.TrimRight(result.Output, "\n")
+		if output == "" {
+			lines = append(lines, headerStyle.Render("  (no output)"))
+		} else {
+			for _, line := range strings.Split(output, "\n") {
+				lines = append(lines, "  "+sanitizeLogLine(line, false))
+			}
+		}
+
+		return outputMsg{
+			title: "dtop - Healthcheck: " + containerName,
+			lines: lines,
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/docker"
+)
+
+// ignored reports whether a container name matches one of the config's ignore patterns
+func (m Model) ignored(name string) bool {
+	for _, pattern := range m.config.Ignore {
+		// Patterns are validated when the config is loaded
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isHidden reports whether a container is hidden with x or by an ignore pattern
+func (m Model) isHidden(c *docker.ContainerInfo) bool {
+	return m.hidden[c.Name] || m.ignored(c.Name)
+}
+
+// visibleContainers drops hidden containers unless they are revealed, and returns how
+// many are hidden
+func (m Model) visibleContainers(containers containersMsg) (containersMsg, int) {
+	visible := make(containersMsg, 0, len(containers))
+	count := 0
+	for i := range containers {
+		if m.isHidden(&containers[i]) {
+			count++
+			if !m.showHidden {
+				continue
+			}
+		}
+		visible = append(visible, containers[i])
+	}
+	return visible, count
+}
+
+// toggleHidden hides the selected container, or unhides it while hidden containers
+// are revealed. Hidden names are saved in the state file.
+func (m *Model) toggleHidden() tea.Cmd {
+	node := m.tree.GetSelected()
+	if node == nil || node.Container == nil {
+		m.status = "Select a container to hide"
+		return nil
+	}
+	name := node.Container.Name
+
+	switch {
+	case m.hidden[name]:
+		delete(m.hidden, name)
+		m.status = "Unhid " + name
+	case m.ignored(name):
+		m.status = name + " is hidden by an ignore pattern in the config"
+		return nil
+	default:
+		m.hidden[name] = true
+		m.status = fmt.Sprintf("Hid %s (X to reveal hidden containers)", name)
+		if !m.showHidden {
+			m.removeFromTree(node.Container.ID)
+		}
+	}
+	m.saveState()
+	return m.refreshHidden()
+}
+
+// toggleShowHidden reveals or hides again the hidden containers
+func (m *Model) toggleShowHidden() tea.Cmd {
+	m.showHidden = !m.showHidden
+	if m.showHidden {
+		m.status = fmt.Sprintf("Showing %d hidden containers (x to unhide)", m.hiddenCount)
+	} else {
+		m.status = "Hiding ignored containers"
+	}
+	return m.refreshHidden()
+}
+
+// refreshHidden reloads the containers so the tree reflects what's hidden; a replay
+// catches up on its next frame
+func (m *Model) refreshHidden() tea.Cmd {
+	if m.replay != nil {
+		return nil
+	}
+	return m.refreshContainers()
+}
+
+// removeFromTree drops a container from the tree right away instead of on the next refresh
+func (m *Model) removeFromTree(containerID string) {
+	containers := make([]docker.ContainerInfo, 0)
+	for _, c := range m.tree.Containers() {
+		if c.ID != containerID {
+			containers = append(containers, *c)
+		}
+	}
+	m.tree.Update(containers, m.treeOptions())
+	m.adjustViewport()
+}
@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ekinertac/dtop/docker"
+)
+
+// historyLength is how many stats samples are kept per container for graphs
+const historyLength = 60
+
+// statsSample is the part of a stats sample kept for graphs and rates
+type statsSample struct {
+	at    time.Time
+	cpu   float64
+	mem   float64
+	netRx uint64
+	netTx uint64
+}
+
+// recordHistory appends a sample for the container, dropping the oldest beyond historyLength
+func (m *Model) recordHistory(containerID string, stats docker.ContainerStats) {
+	samples := append(m.history[containerID], statsSample{
+		at:    m.now(),
+		cpu:   stats.CPUPerc,
+		mem:   stats.MemPerc,
+		netRx: stats.NetRx,
+		netTx: stats.NetTx,
+	})
+	if len(samples) > historyLength {
+		samples = samples[len(samples)-historyLength:]
+	}
+	m.history[containerID] = samples
+}
+
+// pruneHistory forgets the samples, peaks and events of containers that are no longer listed
+func (m *Model) pruneHistory(containers []docker.ContainerInfo) {
+	listed := make(map[string]bool, len(containers))
+	for _, c := range containers {
+		listed[c.ID] = true
+	}
+	for id := range m.history {
+		if !listed[id] {
+			delete(m.history, id)
+		}
+	}
+	for id := range m.peaks {
+		if !listed[id] {
+			delete(m.peaks, id)
+		}
+	}
+	for id := range m.events {
+		if !listed[id] {
+			delete(m.events, id)
+		}
+	}
+}
+
+// netRates returns the receive and transmit rates in bytes/s between each pair of
+// consecutive samples
+func netRates(samples []statsSample) (rx, tx []float64) {
+	for i := 1; i < len(samples); i++ {
+		prev, cur := samples[i-1], samples[i]
+		elapsed := cur.at.Sub(prev.at).Seconds()
+		if elapsed <= 0 || cur.netRx < prev.netRx || cur.netTx < prev.netTx {
+			// Counters reset when the container restarts
+			rx = append(rx, 0)
+			tx = append(tx, 0)
+			continue
+		}
+		rx = append(rx, float64(cur.netRx-prev.netRx)/elapsed)
+		tx = append(tx, float64(cur.netTx-prev.netTx)/elapsed)
+	}
+	return rx, tx
+}
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline renders the last width values as block characters scaled to max; a max
+// of 0 scales to the largest value
+func sparkline(values []float64, width int, max float64) string {
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+	if max <= 0 {
+		for _, v := range values {
+			if v > max {
+				max = v
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.Repeat(" ", width-len(values)))
+	for _, v := range values {
+		level := 0
+		if max > 0 {
+			level = int(v / max * float64(len(sparkBlocks)-1))
+		}
+		if level < 0 {
+			level = 0
+		}
+		if level >= len(sparkBlocks) {
+			level = len(sparkBlocks) - 1
+		}
+		b.WriteRune(sparkBlocks[level])
+	}
+	return b.String()
+}
@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ekinertac/dtop/docker"
+)
+
+// hostInfoMsg carries a fresh daemon info sample for the host view and host bar
+type hostInfoMsg struct {
+	info *docker.HostInfo
+	err  error
+}
+
+// fetchHostInfo queries daemon info in the background
+func (m Model) fetchHostInfo() tea.Cmd {
+	return func() tea.Msg {
+		info, err := m.dockerClient.HostInfo()
+		return hostInfoMsg{info: info, err: err}
+	}
+}
+
+// openHost shows the host view and starts loading daemon info; it is refreshed on
+// every tick while the view is open
+func (m *Model) openHost() tea.Cmd {
+	m.pagerScroll = 0
+	m.viewMode = ViewModeHost
+	return m.fetchHostInfo()
+}
+
+func (m Model) handleHostKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.scrollPager(msg.String()) {
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc", "q":
+		m.viewMode = ViewModeMain
+	case "r":
+		return m, m.fetchHostInfo()
+	}
+	return m, nil
+}
+
+func (m Model) renderHost() string {
+	lines := []string{}
+	switch {
+	case m.hostErr != nil:
+		lines = append(lines, stoppedStyle.Render(fmt.Sprintf("Failed to query daemon: %v", m.hostErr)))
+	case m.hostInfo == nil:
+		lines = append(lines, "Loading…")
+	default:
+		info := m.hostInfo
+
+		rootless := "no"
+		if info.Rootless {
+			rootless = "yes"
+		}
+		experimental := "no"
+		if info.Experimental {
+			experimental = "yes"
+		}
+		swarm := info.SwarmState
+		if info.SwarmManager {
+			swarm += " (manager)"
+		}
+
+		lines = append(lines, detailSection("Daemon", [][2]string{
+			{"Name", info.Name},
+			{"Server version", info.ServerVersion},
+			{"API version", info.APIVersion},
+			{"Root dir", info.DockerRootDir},
+			{"Rootless", rootless},
+			{"Experimental", experimental},
+			{"Swarm", swarm},
+		})...)
+		lines = append(lines, detailSection("Host", [][2]string{
+			{"OS", fmt.Sprintf("%s (%s/%s)", info.OperatingSystem, info.OSType, info.Architecture)},
+			{"Kernel", info.KernelVersion},
+			{"CPUs", fmt.Sprintf("%d", info.NCPU)},
+			{"Memory", formatNetBytes(info.MemTotal)},
+		})...)
+		lines = append(lines, detailSection("Drivers", [][2]string{
+			{"Storage", info.StorageDriver},
+			{"Logging", info.LoggingDriver},
+			{"Cgroup", fmt.Sprintf("%s (v%s)", info.CgroupDriver, info.CgroupVersion)},
+		})...)
+		lines = append(lines, detailSection("Objects", [][2]string{
+			{"Images", fmt.Sprintf("%d", info.Images)},
+			{"Containers", fmt.Sprintf("%d (%d running, %d paused, %d stopped)",
+				info.Containers, info.ContainersRunning, info.ContainersPaused, info.ContainersStopped)},
+		})...)
+
+		lines = append(lines, projectStyle.Render("Warnings"))
+		if len(info.Warnings) == 0 {
+			lines = append(lines, "  none")
+		}
+		for _, warning := range info.Warnings {
+			lines = append(lines, "  "+statusStyle.Render(warning))
+		}
+	}
+
+	return m.renderPager("dtop - Host", lines, "↑↓:scroll  r:refresh  q/esc:back")
+}
+
+// hostBarGaugeWidth is the width of each gauge in the host bar above the table
+const hostBarGaugeWidth = 20
+
+// hostBarHeight is the number of rows the host bar takes
+func (m Model) hostBarHeight() int {
+	if !m.showHostBar || m.hostInfo == nil {
+		return 0
+	}
+	return 1
+}
+
+// renderHostBar renders the host's capacity and how much of it the listed containers
+// use, like htop's header. The daemon doesn't report host-wide usage, so processes
+// outside containers aren't counted.
+func (m Model) renderHostBar() string {
+	info := m.hostInfo
+	var cpuPerc float64
+	var memUsage uint64
+	for _, c := range m.tree.Containers() {
+		cpuPerc += c.CPUPerc
+		memUsage += c.Memory.Usage
+	}
+
+	// Container CPU percentages are per core, like docker stats
+	cpu := 0.0
+	if info.NCPU > 0 {
+		cpu = cpuPerc / float64(info.NCPU)
+	}
+	mem := 0.0
+	if info.MemTotal > 0 {
+		mem = float64(memUsage) / float64(info.MemTotal) * 100
+	}
+
+	return headerStyle.Render("HOST CPU ") + renderGauge(cpu, hostBarGaugeWidth) +
+		containerStyle.Render(fmt.Sprintf(" %3.0f%% of %d cores   ", cpu, info.NCPU)) +
+		headerStyle.Render("MEM ") + renderGauge(mem, hostBarGaugeWidth) +
+		containerStyle.Render(fmt.Sprintf(" %s / %s (%.0f%%)", formatNetBytes(memUsage), formatNetBytes(info.MemTotal), mem)) +
+		lipgloss.NewStyle().Foreground(mutedColor).Render("   used by containers")
+}
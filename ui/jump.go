@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/i18n"
+	"github.com/ekinertac/dtop/model"
+)
+
+// jumpMatch is a tree node matching the jump query
+type jumpMatch struct {
+	node  *model.TreeNode
+	label string // Project name, or project/container for containers
+	score int
+}
+
+// openJump shows the jump palette with an empty query
+func (m *Model) openJump() {
+	m.jumpQuery = ""
+	m.jumpSelected = 0
+	m.viewMode = ViewModeJump
+}
+
+// jumpMatches fuzzy-matches the query against every project and container,
+// including those inside collapsed projects, best match first
+func (m Model) jumpMatches() []jumpMatch {
+	matches := []jumpMatch{}
+	if m.tree.Root == nil {
+		return matches
+	}
+
+	add := func(node *model.TreeNode, label string) {
+		if score, ok := fuzzyScore(m.jumpQuery, label); ok {
+			matches = append(matches, jumpMatch{node: node, label: label, score: score})
+		}
+	}
+	for _, project := range m.tree.Root.Children {
+		add(project, project.Name)
+		for _, child := range project.Children {
+			add(child, project.Name+"/"+child.Name)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+	return matches
+}
+
+// fuzzyScore reports whether every character of pattern appears in s in order,
+// ignoring case. Consecutive characters and matches at word starts score higher,
+// shorter candidates break ties.
+func fuzzyScore(pattern, s string) (int, bool) {
+	pattern = strings.ToLower(pattern)
+	target := []rune(strings.ToLower(s))
+
+	score := 0
+	pos := 0
+	prevMatch := -2
+	for _, p := range pattern {
+		found := false
+		for ; pos < len(target); pos++ {
+			if target[pos] != p {
+				continue
+			}
+			score++
+			if pos == prevMatch+1 {
+				score += 5
+			}
+			if pos == 0 || !unicode.IsLetter(target[pos-1]) && !unicode.IsDigit(target[pos-1]) {
+				score += 3
+			}
+			prevMatch = pos
+			pos++
+			found = true
+			break
+		}
+		if !found {
+			return 0, false
+		}
+	}
+
+	return score*100 - len(target), true
+}
+
+func (m Model) handleJumpKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	matches := m.jumpMatches()
+
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.viewMode = ViewModeMain
+	case tea.KeyEnter:
+		if m.jumpSelected < len(matches) {
+			m.tree.Select(matches[m.jumpSelected].node)
+			m.adjustViewport()
+		}
+		m.viewMode = ViewModeMain
+	case tea.KeyUp, tea.KeyCtrlP:
+		if m.jumpSelected > 0 {
+			m.jumpSelected--
+		}
+	case tea.KeyDown, tea.KeyCtrlN, tea.KeyTab:
+		if m.jumpSelected < len(matches)-1 {
+			m.jumpSelected++
+		}
+	case tea.KeyBackspace:
+		runes := []rune(m.jumpQuery)
+		if len(runes) > 0 {
+			m.jumpQuery = string(runes[:len(runes)-1])
+			m.jumpSelected = 0
+		}
+	case tea.KeyCtrlU:
+		m.jumpQuery = ""
+		m.jumpSelected = 0
+	case tea.KeySpace:
+		m.jumpQuery += " "
+		m.jumpSelected = 0
+	case tea.KeyRunes:
+		m.jumpQuery += string(msg.Runes)
+		m.jumpSelected = 0
+	}
+	return m, nil
+}
+
+func (m Model) renderJump() string {
+	var b strings.Builder
+
+	// Title
+	b.WriteString(titleStyle.Render(i18n.T("dtop - Docker Container Monitor")))
+	b.WriteString("\n\n")
+
+	b.WriteString(projectStyle.Render("Jump to: "))
+	b.WriteString(m.jumpQuery + "█")
+	b.WriteString("\n\n")
+
+	// Title + query + help take 6 lines
+	visible := m.height - 6
+	if visible < 1 {
+		visible = 1
+	}
+
+	matches := m.jumpMatches()
+	if len(matches) == 0 {
+		b.WriteString(menuItemStyle.Render("  No matches"))
+		b.WriteString("\n")
+	}
+
+	// Keep the highlighted match in view
+	start := 0
+	if m.jumpSelected >= visible {
+		start = m.jumpSelected - visible + 1
+	}
+	for i := start; i < len(matches) && i < start+visible; i++ {
+		if i == m.jumpSelected {
+			b.WriteString(menuSelectedStyle.Render("> " + matches[i].label))
+		} else {
+			b.WriteString(menuItemStyle.Render("  " + matches[i].label))
+		}
+		b.WriteString("\n")
+	}
+
+	// Help text
+	b.WriteString("\n")
+	helpText := "type to filter  ↑↓:select  enter:jump  esc:cancel"
+	b.WriteString(helpStyle.Render(i18n.Help(helpText)))
+
+	return b.String()
+}
@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/docker"
+)
+
+// showLabels lists the container's labels, sorted by key, in the output view
+func showLabels(c *docker.ContainerInfo) tea.Cmd {
+	keys := make([]string, 0, len(c.Labels))
+	width := 0
+	for key := range c.Labels {
+		keys = append(keys, key)
+		if len(key) > width {
+			width = len(key)
+		}
+	}
+	sort.Strings(keys)
+
+	lines := []string{}
+	if len(keys) == 0 {
+		lines = append(lines, "No labels")
+	}
+	for _, key := range keys {
+		lines = append(lines, headerStyle.Render(truncateOrPad(key, width+2))+c.Labels[key])
+	}
+
+	msg := outputMsg{
+		title: "dtop - Labels: " + c.Name,
+		lines: lines,
+	}
+	return func() tea.Msg {
+		return msg
+	}
+}
@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/mattn/go-runewidth"
+)
+
+const (
+	// wideWidth is the terminal width from which the table adds the ID and ports
+	// columns, and the image column unless I turned it off
+	wideWidth = 200
+
+	// minNameWidth is how far the name column shrinks before the table drops to
+	// name, status and CPU only
+	minNameWidth = 20
+
+	// minStatusWidth is how far the status column shrinks on very narrow terminals
+	minStatusWidth = 10
+
+	colIDWidth    = 12
+	colPortsWidth = 24
+)
+
+// layout is which columns the container table shows and how wide the flexible ones
+// are. It follows the terminal width: between the breakpoints the name and gauge
+// columns take up the slack.
+type layout struct {
+	narrow  bool // Name, status and CPU only
+	compact bool // Disk and PIDS dropped
+	wide    bool // ID, image and ports added
+
+	name, status, gauge int
+
+	node, id, image, ports, gpu, logs bool
+}
+
+// layout picks the table layout for the terminal width
+func (m Model) layout() layout {
+	l := layout{
+		wide:   m.width >= wideWidth,
+		name:   colNameWidth,
+		status: colStatusWidth,
+		gauge:  colCPUWidth,
+		node:   len(m.nodes) > 0,
+		image:  m.showImage,
+		gpu:    m.showGPU,
+		logs:   m.showLogRate,
+	}
+	if l.wide {
+		// The image column only follows the width until it's toggled
+		l.id, l.ports = true, true
+		l.image = l.image || !m.imageToggled
+	}
+	if m.width == 0 {
+		// Not sized yet
+		return l
+	}
+
+	// Width left for the name and the two gauges once the other columns and the
+	// separators are placed; disk and PIDS go first when it runs short
+	spare := m.width - colStatusWidth - colNetWidth - colDiskWidth - colPIDsWidth - colUptimeWidth - l.extraWidth() - 8
+	if spare < minNameWidth+2*colCPUWidth {
+		l.compact = true
+		spare += colDiskWidth + colPIDsWidth + 2
+	}
+	if spare < minNameWidth+2*colCPUWidth {
+		return narrowLayout(m.width)
+	}
+	l.name = min(spare-2*colCPUWidth, colNameWidth)
+	l.gauge = min((spare-l.name)/2, maxGaugeWidth)
+	return l
+}
+
+// narrowLayout fits name, status and CPU into width, shrinking the status column
+// once the name is at its minimum
+func narrowLayout(width int) layout {
+	l := layout{narrow: true, gauge: colCPUWidth}
+	spare := width - colCPUWidth - 3
+	l.status = max(min(colStatusWidth, spare-minNameWidth), minStatusWidth)
+	l.name = max(min(spare-l.status, colNameWidth), 1)
+	return l
+}
+
+// extraWidth is the width of the optional columns shown, with their separators
+func (l layout) extraWidth() int {
+	width := 0
+	for _, col := range []struct {
+		shown bool
+		width int
+	}{
+		{l.node, colNodeWidth}, {l.id, colIDWidth}, {l.image, colImageWidth},
+		{l.ports, colPortsWidth}, {l.gpu, colGPUWidth}, {l.logs, colLogsWidth},
+	} {
+		if col.shown {
+			width += col.width + 1
+		}
+	}
+	return width
+}
+
+// rowWidth is the width of a full table row
+func (l layout) rowWidth() int {
+	if l.narrow {
+		return l.name + 1 + l.status + 1 + l.gauge
+	}
+	width := l.name + 1 + l.status + 1 + l.gauge + 1 + l.gauge + 1 + colNetWidth + 1 + l.extraWidth() + colUptimeWidth
+	if !l.compact {
+		width += colDiskWidth + 1 + colPIDsWidth + 1
+	}
+	return width
+}
+
+// truncateMiddle shortens s to width by cutting out its middle, keeping the end
+// that tells replicas and similar names apart
+func truncateMiddle(s string, width int) string {
+	if runewidth.StringWidth(s) <= width {
+		return s
+	}
+	if width < 5 {
+		return runewidth.Truncate(s, width, "")
+	}
+	tail := (width - 1) / 3
+	head := runewidth.Truncate(s, width-1-tail, "")
+	runes := []rune(s)
+	end := ""
+	for i := len(runes) - 1; i >= 0 && runewidth.StringWidth(string(runes[i:])) <= tail; i-- {
+		end = string(runes[i:])
+	}
+	return head + "…" + end
+}
+
+// formatPorts lists as many ports as fit in width, counting the rest, e.g.
+// "5432->5432/tcp +2"
+func formatPorts(ports []string, width int) string {
+	if len(ports) == 0 {
+		return "-"
+	}
+	text := ports[0]
+	for i := 1; i < len(ports); i++ {
+		next := text + " " + ports[i]
+		rest := ""
+		if i < len(ports)-1 {
+			rest = fmt.Sprintf(" +%d", len(ports)-i-1)
+		}
+		if runewidth.StringWidth(next+rest) > width {
+			return text + fmt.Sprintf(" +%d", len(ports)-i)
+		}
+		text = next
+	}
+	return text
+}
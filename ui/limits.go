@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/go-units"
+	"github.com/ekinertac/dtop/docker"
+)
+
+// minMemoryLimit is the smallest memory limit the daemon accepts
+const minMemoryLimit = 6 * 1024 * 1024
+
+// parseMemoryLimit parses a size like 512m or 1.5g; empty means unchanged (0)
+func parseMemoryLimit(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	bytes, err := units.RAMInBytes(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid memory limit %q (e.g. 512m, 2g)", s)
+	}
+	if bytes < minMemoryLimit {
+		return 0, fmt.Errorf("memory limit must be at least 6MiB")
+	}
+	return bytes, nil
+}
+
+// parseCPULimit parses a number of cores like 1.5; empty means unchanged (0)
+func parseCPULimit(s string) (float64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	cpus, err := strconv.ParseFloat(s, 64)
+	if err != nil || cpus < 0.01 {
+		return 0, fmt.Errorf("invalid CPU limit %q (e.g. 0.5, 2)", s)
+	}
+	return cpus, nil
+}
+
+// openLimitsForm looks up the container's current limits and opens the form to change them
+func (m *Model) openLimitsForm(containerID, containerName string) tea.Cmd {
+	return func() tea.Msg {
+		current, err := m.dockerClient.GetResourceLimits(containerID)
+		if err != nil {
+			return statusMsg(fmt.Sprintf("Limits of %s: %v", containerName, err))
+		}
+		return openFormMsg{m.limitsForm(containerID, containerName, current)}
+	}
+}
+
+// limitsForm edits memory and CPU limits, previewing the container's live usage
+// against the proposed limits before they are applied
+func (m *Model) limitsForm(containerID, containerName string, current docker.ResourceLimits) *form {
+	memory, cpus := "", ""
+	if current.Memory > 0 {
+		memory = units.BytesSize(float64(current.Memory))
+	}
+	if current.CPUs > 0 {
+		cpus = strconv.FormatFloat(current.CPUs, 'f', -1, 64)
+	}
+	fields := []formField{
+		{Label: "Memory", Value: memory, Placeholder: "unlimited (e.g. 512m, 2g)"},
+		{Label: "CPUs", Value: cpus, Placeholder: "unlimited (e.g. 0.5, 2)"},
+	}
+
+	f := newForm("Resource limits of "+containerName, fields, func(values []string) tea.Cmd {
+		return func() tea.Msg {
+			memory, err := parseMemoryLimit(values[0])
+			if err != nil {
+				return statusMsg(err.Error())
+			}
+			cpus, err := parseCPULimit(values[1])
+			if err != nil {
+				return statusMsg(err.Error())
+			}
+			if memory == 0 && cpus == 0 {
+				return statusMsg("Limits of " + containerName + " unchanged")
+			}
+
+			err = m.dockerClient.SetResourceLimits(containerID, current, memory, cpus)
+			m.audit.Record("update limits", containerName, err)
+			if err != nil {
+				return statusMsg(fmt.Sprintf("Limits of %s: %v", containerName, err))
+			}
+			return statusMsg(fmt.Sprintf("Limits of %s: memory %s, CPUs %s", containerName,
+				orUnchanged(values[0]), orUnchanged(values[1])))
+		}
+	})
+
+	// The tree is shared, so the preview follows live usage while the form is open
+	tree := m.tree
+	f.preview = func(values []string) []string {
+		node := tree.FindContainer(containerID)
+		if node == nil {
+			return []string{stoppedStyle.Render("Container is no longer running")}
+		}
+		return limitsPreview(node.Container, current, values)
+	}
+	return f
+}
+
+// limitsPreview compares current usage with the proposed limits
+func limitsPreview(c *docker.ContainerInfo, current docker.ResourceLimits, values []string) []string {
+	lines := []string{projectStyle.Render("Current usage vs new limits")}
+
+	memory, err := parseMemoryLimit(values[0])
+	switch {
+	case err != nil:
+		lines = append(lines, stoppedStyle.Render(err.Error()))
+	case !c.Memory.Reported:
+		lines = append(lines, headerStyle.Render(truncateOrPad("Memory", 8))+"usage not reported by the daemon")
+	default:
+		if memory == 0 {
+			memory = current.Memory
+		}
+		usage := int64(c.Memory.Usage)
+		if memory == 0 {
+			lines = append(lines, headerStyle.Render(truncateOrPad("Memory", 8))+units.BytesSize(float64(usage))+" / unlimited")
+			break
+		}
+		perc := float64(usage) / float64(memory) * 100
+		lines = append(lines, fmt.Sprintf("%s%s %s / %s (%.0f%%)", headerStyle.Render(truncateOrPad("Memory", 8)),
+			renderGauge(perc, 20), units.BytesSize(float64(usage)), units.BytesSize(float64(memory)), perc))
+		if usage > memory {
+			lines = append(lines, stoppedStyle.Render("⚠ Current usage exceeds the new limit; the container may be OOM-killed"))
+		}
+	}
+
+	cpus, err := parseCPULimit(values[1])
+	switch {
+	case err != nil:
+		lines = append(lines, stoppedStyle.Render(err.Error()))
+	default:
+		if cpus == 0 {
+			cpus = current.CPUs
+		}
+		if cpus == 0 {
+			lines = append(lines, headerStyle.Render(truncateOrPad("CPU", 8))+fmt.Sprintf("%.0f%% / unlimited", c.CPUPerc))
+			break
+		}
+		// CPUPerc is relative to one core, like docker stats
+		perc := c.CPUPerc / cpus
+		lines = append(lines, fmt.Sprintf("%s%s %.0f%% of %s cores (%.0f%%)", headerStyle.Render(truncateOrPad("CPU", 8)),
+			renderGauge(perc, 20), c.CPUPerc, strconv.FormatFloat(cpus, 'f', -1, 64), perc))
+		if perc > 100 {
+			lines = append(lines, stoppedStyle.Render("⚠ Current usage exceeds the new limit; the container will be throttled"))
+		}
+	}
+
+	lines = append(lines, "", headerStyle.Render("Leave a field empty to keep its limit; removing a limit needs the container to be recreated"))
+	return lines
+}
+
+// orUnchanged describes an optional form value
+func orUnchanged(value string) string {
+	if value == "" {
+		return "unchanged"
+	}
+	return value
+}
@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ekinertac/dtop/config"
+)
+
+// logHighlight is a rule coloring the parts of log lines its pattern matches
+type logHighlight struct {
+	re    *regexp.Regexp
+	style lipgloss.Style
+}
+
+// logHighlights returns the highlight rules from the config
+func logHighlights(cfg *config.Config) []logHighlight {
+	if cfg == nil {
+		return nil
+	}
+	rules := make([]logHighlight, 0, len(cfg.LogHighlights))
+	for _, h := range cfg.LogHighlights {
+		if h.Regexp() == nil {
+			continue
+		}
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color(h.TermColor())).Bold(h.Bold)
+		rules = append(rules, logHighlight{re: h.Regexp(), style: style})
+	}
+	return rules
+}
+
+// logSpan is a highlighted byte range of a log line
+type logSpan struct {
+	start, end int
+	style      lipgloss.Style
+}
+
+// highlightSpans finds the parts of a line the rules match, in order; where matches
+// overlap, the earlier rule wins
+func highlightSpans(rules []logHighlight, line string) []logSpan {
+	spans := []logSpan{}
+	for _, rule := range rules {
+		for _, loc := range rule.re.FindAllStringIndex(line, -1) {
+			if loc[0] == loc[1] {
+				continue
+			}
+			overlaps := false
+			for _, s := range spans {
+				if loc[0] < s.end && s.start < loc[1] {
+					overlaps = true
+					break
+				}
+			}
+			if !overlaps {
+				spans = append(spans, logSpan{start: loc[0], end: loc[1], style: rule.style})
+			}
+		}
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	return spans
+}
+
+// styleLogRow colors the spans within row, the part of a line starting at byte
+// offset, and renders the rest with base if given
+func styleLogRow(row string, offset int, spans []logSpan, base *lipgloss.Style) string {
+	plain := func(text string) string {
+		if base == nil || text == "" {
+			return text
+		}
+		return base.Render(text)
+	}
+	if len(spans) == 0 {
+		return plain(row)
+	}
+
+	out := ""
+	pos := 0
+	for _, s := range spans {
+		start, end := max(s.start-offset, pos), min(s.end-offset, len(row))
+		if start >= end {
+			continue
+		}
+		out += plain(row[pos:start]) + s.style.Render(row[start:end])
+		pos = end
+	}
+	return out + plain(row[pos:])
+}
+
+// highlightLogRows colors the rows a line was split into, the first of which starts
+// at byte offset of the line. Once a row isn't a plain cut of the line (e.g. with
+// escape sequences carried over), rows are matched on their own.
+func highlightLogRows(rules []logHighlight, line string, rows []string, offset int, base *lipgloss.Style) {
+	if len(rules) == 0 && base == nil {
+		return
+	}
+	spans := highlightSpans(rules, line)
+	for i, row := range rows {
+		if offset < 0 || offset+len(row) > len(line) || line[offset:offset+len(row)] != row {
+			rows[i] = styleLogRow(row, 0, highlightSpans(rules, row), base)
+			offset = -1
+			continue
+		}
+		rows[i] = styleLogRow(row, offset, spans, base)
+		offset += len(row)
+	}
+}
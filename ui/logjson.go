@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// jsonToken matches the parts of indented JSON that get colors: strings (with the
+// colon that makes them keys), numbers and literals
+var jsonToken = regexp.MustCompile(`("(?:[^"\\]|\\.)*")(\s*:)?|(-?\d+(?:\.\d+)?(?:[eE][+-]?\d+)?)|\b(true|false|null)\b`)
+
+// prettyJSON indents a single-line JSON log entry, keeping its key order. It
+// returns false for lines that aren't a JSON object or array.
+func prettyJSON(line string) ([]string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return nil, false
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, []byte(trimmed), "", "  "); err != nil {
+		return nil, false
+	}
+	return strings.Split(out.String(), "\n"), true
+}
+
+// highlightJSON colors keys, strings, numbers and literals of indented JSON lines
+func highlightJSON(lines []string) []string {
+	highlighted := make([]string, len(lines))
+	for i, line := range lines {
+		highlighted[i] = jsonToken.ReplaceAllStringFunc(line, func(token string) string {
+			match := jsonToken.FindStringSubmatch(token)
+			switch {
+			case match[1] != "" && match[2] != "":
+				return projectStyle.Render(match[1]) + match[2]
+			case match[1] != "":
+				return runningStyle.Render(token)
+			case match[3] != "":
+				return statusStyle.Render(token)
+			default:
+				return stoppedStyle.Render(token)
+			}
+		})
+	}
+	return highlighted
+}
+
+// openLogJSON expands the log line under the cursor in a popup, pretty-printed and
+// highlighted
+func (m *Model) openLogJSON() {
+	_, sources := m.logRows()
+	if m.logsCursor >= len(sources) {
+		return
+	}
+	lines := strings.Split(m.logsContent, "\n")
+	line := sanitizeLogLine(lines[sources[m.logsCursor]], false)
+	pretty, ok := prettyJSON(line)
+	if !ok {
+		m.status = "Not a JSON log line"
+		return
+	}
+	m.openOutput(outputMsg{
+		title: "dtop - Log entry of " + m.logsContainer,
+		lines: highlightJSON(pretty),
+		back:  ViewModeLogs,
+	})
+}
+
+// moveLogsCursor moves the JSON mode cursor by delta rows and scrolls to keep it visible
+func (m *Model) moveLogsCursor(delta int) {
+	rows, _ := m.logRows()
+	m.logsCursor += delta
+	if m.logsCursor >= len(rows) {
+		m.logsCursor = len(rows) - 1
+	}
+	if m.logsCursor < 0 {
+		m.logsCursor = 0
+	}
+
+	visibleHeight := m.height - 4
+	if m.logsCursor < m.logsScroll {
+		m.logsScroll = m.logsCursor
+	} else if m.logsCursor >= m.logsScroll+visibleHeight {
+		m.logsScroll = m.logsCursor - visibleHeight + 1
+	}
+}
@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Log levels, from least to most severe
+const (
+	levelUnknown = iota
+	levelTrace
+	levelDebug
+	levelInfo
+	levelWarn
+	levelError
+	levelFatal
+)
+
+// logLevelNames maps the spellings of levels found in logs to a level
+var logLevelNames = map[string]int{
+	"trace": levelTrace, "debug": levelDebug, "dbg": levelDebug,
+	"info": levelInfo, "information": levelInfo, "notice": levelInfo,
+	"warn": levelWarn, "warning": levelWarn,
+	"error": levelError, "err": levelError,
+	"fatal": levelFatal, "panic": levelFatal, "critical": levelFatal, "crit": levelFatal,
+	"alert": levelFatal, "emerg": levelFatal,
+}
+
+// logLevelFilters are the minimum levels the logs view cycles through
+var logLevelFilters = []struct {
+	level int
+	name  string
+}{
+	{levelUnknown, "all"},
+	{levelInfo, "info+"},
+	{levelWarn, "warn+"},
+	{levelError, "errors"},
+}
+
+var (
+	// logfmtLevel matches level=warn or lvl="error" in logfmt lines
+	logfmtLevel = regexp.MustCompile(`(?:^|\s)(?:level|lvl|severity)="?([A-Za-z]+)"?(?:\s|$)`)
+	// textLevel matches an upper-case level word ("ERROR", "WARN:") or a bracketed
+	// one in any case ("[error]"), the usual plain-text conventions
+	textLevel = regexp.MustCompile(`\b(TRACE|DEBUG|INFO|NOTICE|WARN|WARNING|ERROR|ERR|FATAL|PANIC|CRITICAL|CRIT)\b|\[([A-Za-z]+)\]`)
+)
+
+// jsonLevelKeys are the fields JSON loggers put the level in
+var jsonLevelKeys = []string{"level", "lvl", "severity", "log.level", "levelname", "loglevel"}
+
+// detectLogLevel finds the level of a log line written as JSON, logfmt or plain
+// text. Lines without one (e.g. stack traces) return levelUnknown.
+func detectLogLevel(line string) int {
+	trimmed := strings.TrimSpace(line)
+	if strings.HasPrefix(trimmed, "{") {
+		var fields map[string]any
+		if json.Unmarshal([]byte(trimmed), &fields) == nil {
+			return jsonLogLevel(fields)
+		}
+	}
+	if match := logfmtLevel.FindStringSubmatch(line); match != nil {
+		if level, ok := logLevelNames[strings.ToLower(match[1])]; ok {
+			return level
+		}
+	}
+	for _, match := range textLevel.FindAllStringSubmatch(line, -1) {
+		if level, ok := logLevelNames[strings.ToLower(match[1]+match[2])]; ok {
+			return level
+		}
+	}
+	return levelUnknown
+}
+
+// jsonLogLevel reads the level of a JSON log line, as a name or a pino/bunyan number
+func jsonLogLevel(fields map[string]any) int {
+	for _, key := range jsonLevelKeys {
+		switch value := fields[key].(type) {
+		case string:
+			if level, ok := logLevelNames[strings.ToLower(value)]; ok {
+				return level
+			}
+		case float64:
+			// pino and bunyan: 10 trace, 20 debug, 30 info, 40 warn, 50 error, 60 fatal
+			switch {
+			case value >= 60:
+				return levelFatal
+			case value >= 50:
+				return levelError
+			case value >= 40:
+				return levelWarn
+			case value >= 30:
+				return levelInfo
+			case value >= 20:
+				return levelDebug
+			case value >= 10:
+				return levelTrace
+			}
+		}
+	}
+	return levelUnknown
+}
+
+var debugLogStyle = lipgloss.NewStyle().Foreground(mutedColor)
+
+// logLevelStyle colors log rows by level: errors red, warnings yellow, debug dim
+func logLevelStyle(level int) (lipgloss.Style, bool) {
+	switch level {
+	case levelFatal, levelError:
+		return stoppedStyle, true
+	case levelWarn:
+		return statusStyle, true
+	case levelTrace, levelDebug:
+		return debugLogStyle, true
+	}
+	return lipgloss.Style{}, false
+}
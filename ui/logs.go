@@ -2,25 +2,143 @@ package ui
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ekinertac/dtop/i18n"
 )
 
+const ansiReset = "\x1b[0m"
+
+// escapeSequence matches CSI, OSC and two-character escape sequences
+var escapeSequence = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)|\x1b[@-Z\\-_]`)
+
+// colorSequence matches SGR sequences, the only escapes that are safe to pass through
+var colorSequence = regexp.MustCompile(`^\x1b\[[0-9;]*m$`)
+
+// sanitizeLogLine removes escape sequences that would corrupt the screen (cursor
+// movement, clears, titles) and carriage returns, keeping colors if requested
+func sanitizeLogLine(line string, keepColors bool) string {
+	line = strings.ReplaceAll(line, "\r", "")
+	return escapeSequence.ReplaceAllStringFunc(line, func(seq string) string {
+		if keepColors && colorSequence.MatchString(seq) {
+			return seq
+		}
+		return ""
+	})
+}
+
+// logsHScrollStep is how many columns left/right scroll when wrapping is off
+const logsHScrollStep = 8
+
+// logRows turns the loaded logs into screen rows: wrapped to the terminal width, or
+// clipped to the horizontally scrolled window when wrapping is off. sources holds
+// the index of the log line each row comes from.
+func (m Model) logRows() (rows []string, sources []int) {
+	lines := strings.Split(m.logsContent, "\n")
+	rows = make([]string, 0, len(lines))
+	sources = make([]int, 0, len(lines))
+	minLevel := logLevelFilters[m.logsLevel].level
+	level := levelUnknown
+	rules := logHighlights(m.config)
+	stampWidth := m.logStampWidth()
+	width := max(m.width-stampWidth, 10)
+	var prev time.Time
+	for index, line := range lines {
+		// Gaps are measured from the line before, shown or not
+		var at, before time.Time
+		if stampWidth > 0 && index < len(m.logsTimes) {
+			at, before = m.logsTimes[index], prev
+			if !at.IsZero() {
+				prev = at
+			}
+		}
+
+		plain := sanitizeLogLine(line, false)
+		// Lines without a level (stack traces, wrapped messages) belong to the line above
+		if detected := detectLogLevel(plain); detected != levelUnknown {
+			level = detected
+		}
+		if level < minLevel {
+			continue
+		}
+
+		line = sanitizeLogLine(line, m.logsColors)
+		var lineRows []string
+		offset := 0
+		switch {
+		case m.width <= 0:
+			lineRows = []string{line}
+		case m.logsWrap:
+			lineRows = strings.Split(ansi.HardwrapWc(line, width, true), "\n")
+		default:
+			// Measured by display width so wide characters and escape sequences
+			// don't break the window
+			window := ansi.TruncateWc(line, m.logsHScroll+width, "")
+			lineRows = []string{ansi.TruncateLeftWc(window, m.logsHScroll, "")}
+			offset = len(window) - len(lineRows[0])
+		}
+
+		// Lines with their own colors keep them
+		var base *lipgloss.Style
+		if style, ok := logLevelStyle(level); ok && line == plain {
+			base = &style
+		}
+		highlightLogRows(rules, line, lineRows, offset, base)
+
+		// The time goes before the first row, wrapped rows are indented under it
+		if stampWidth > 0 {
+			for i := range lineRows {
+				if i == 0 {
+					lineRows[i] = m.logStamp(at, before) + lineRows[i]
+				} else {
+					lineRows[i] = strings.Repeat(" ", stampWidth) + lineRows[i]
+				}
+			}
+		}
+		rows = append(rows, lineRows...)
+		for range lineRows {
+			sources = append(sources, index)
+		}
+	}
+	return rows, sources
+}
+
+// logsMaxWidth returns the display width of the widest log line, with its time
+func (m Model) logsMaxWidth() int {
+	widest := 0
+	for _, line := range strings.Split(m.logsContent, "\n") {
+		if w := ansi.StringWidthWc(sanitizeLogLine(line, false)); w > widest {
+			widest = w
+		}
+	}
+	return widest + m.logStampWidth()
+}
+
 func (m Model) renderLogs() string {
 	var b strings.Builder
 
 	// Title
 	title := fmt.Sprintf("dtop - Logs: %s", m.logsContainer)
+	if m.logsLevel > 0 {
+		title += " (" + logLevelFilters[m.logsLevel].name + ")"
+	}
+	if m.logStampWidth() > 0 {
+		title += " · " + m.logZone()
+	}
 	b.WriteString(titleStyle.Render(title))
 	b.WriteString("\n\n")
 
-	// Split logs into lines
-	lines := strings.Split(m.logsContent, "\n")
-	
+	rows, _ := m.logRows()
+
 	// Calculate visible height
 	visibleHeight := m.height - 4 // Title + blank + footer + blank
 
 	// Clamp scroll position
-	maxScroll := len(lines) - visibleHeight
+	maxScroll := len(rows) - visibleHeight
 	if maxScroll < 0 {
 		maxScroll = 0
 	}
@@ -28,14 +146,23 @@ func (m Model) renderLogs() string {
 		m.logsScroll = maxScroll
 	}
 
-	// Render visible lines
+	// Render visible rows
 	end := m.logsScroll + visibleHeight
-	if end > len(lines) {
-		end = len(lines)
+	if end > len(rows) {
+		end = len(rows)
 	}
 
 	for i := m.logsScroll; i < end; i++ {
-		b.WriteString(lines[i])
+		if m.logsJSON && i == m.logsCursor {
+			b.WriteString(selectedStyle.Render(ansi.Strip(rows[i])))
+			b.WriteString("\n")
+			continue
+		}
+		b.WriteString(rows[i])
+		if m.logsColors {
+			// Don't let an unterminated color bleed into the next row
+			b.WriteString(ansiReset)
+		}
 		b.WriteString("\n")
 	}
 
@@ -45,12 +172,37 @@ func (m Model) renderLogs() string {
 		b.WriteString("\n")
 	}
 
-	// Footer with scroll indicator
-	footer := fmt.Sprintf("Lines %d-%d of %d", m.logsScroll+1, end, len(lines))
+	// Footer with action results (e.g. saving the logs) and scroll indicator
+	if m.status != "" {
+		b.WriteString(statusStyle.Render(m.status))
+		b.WriteString("  ")
+	}
+	footer := fmt.Sprintf("Rows %d-%d of %d", m.logsScroll+1, end, len(rows))
+	if !m.logsWrap && m.logsHScroll > 0 {
+		footer += fmt.Sprintf("  col %d", m.logsHScroll+1)
+	}
 	b.WriteString(helpStyle.Render(footer))
 	b.WriteString("  ")
-	b.WriteString(helpStyle.Render("↑↓/PgUp/PgDn/g/G:scroll  q/esc:back"))
+	help := "↑↓/PgUp/PgDn/g/G:scroll  "
+	if !m.logsWrap {
+		help += "←→:pan  "
+	}
+	if m.logsJSON {
+		help += "enter:expand JSON  "
+	}
+	help = i18n.Help(help)
+	help += "J:" + i18n.T("JSON") + " " + onOff(m.logsJSON) + "  L:" + i18n.T("level") + " " + logLevelFilters[m.logsLevel].name +
+		"  w:" + i18n.T("wrap") + " " + onOff(m.logsWrap) + "  c:" + i18n.T("colors") + " " + onOff(m.logsColors) +
+		"  T:" + i18n.T("time") + " " + i18n.T(logStampModes[m.logsStamps]) + "  q/esc:" + i18n.T("back")
+	b.WriteString(helpStyle.Render(help))
 
 	return b.String()
 }
 
+// onOff formats a toggle state for help text
+func onOff(enabled bool) string {
+	if enabled {
+		return i18n.T("on")
+	}
+	return i18n.T("off")
+}
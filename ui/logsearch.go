@@ -0,0 +1,319 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ekinertac/dtop/docker"
+	"github.com/ekinertac/dtop/i18n"
+	"github.com/ekinertac/dtop/model"
+)
+
+const (
+	// logSearchTail is how many of the latest lines in the time range are searched
+	// per container, and logSearchLimit how many matches are kept, newest first
+	logSearchTail  = 10000
+	logSearchLimit = 1000
+
+	// logSearchWorkers is how many containers' logs are read at once
+	logSearchWorkers = 8
+)
+
+// logMatch is a log line matching the search
+type logMatch struct {
+	containerID string
+	container   string
+	time        time.Time
+	line        string
+	index       int // Line number in the container's searched logs
+}
+
+// logSearch is a search across the logs of many containers and its results
+type logSearch struct {
+	pattern    string
+	since      string // Time range as entered, e.g. "1h"
+	containers string // Container filter as entered
+	re         *regexp.Regexp
+	matches    []logMatch
+	logs       map[string]string      // Container ID -> searched logs, for the logs view
+	times      map[string][]time.Time // Container ID -> time of each line of the logs
+	searched   int
+	failed     []string
+	truncated  bool // More than logSearchLimit lines matched
+	cursor     int
+}
+
+// logSearchMsg carries finished search results
+type logSearchMsg struct{ search *logSearch }
+
+// openLogSearch asks for a pattern, a time range and optionally which containers to
+// search, filled in with the previous search
+func (m *Model) openLogSearch() {
+	if m.replay != nil {
+		m.status = "Not available while replaying a recording"
+		return
+	}
+	prev := m.logSearch
+	if prev == nil {
+		prev = &logSearch{since: "1h"}
+	}
+	fields := []formField{
+		{Label: "Pattern", Value: prev.pattern, Placeholder: "regular expression; ignores case unless it has capitals"},
+		{Label: "Since", Value: prev.since, Placeholder: "how far back, e.g. 15m, 1h or 24h"},
+		{Label: "Containers", Value: prev.containers, Placeholder: "names, projects or globs, comma-separated; empty for all"},
+	}
+	tree := m.tree
+	opts := TreeOptions(m.config)
+	client := m.dockerClient
+	m.openForm(newForm("Search logs", fields, func(values []string) tea.Cmd {
+		search := &logSearch{pattern: values[0], since: values[1], containers: values[2]}
+		if search.pattern == "" {
+			return func() tea.Msg { return statusMsg("Log search needs a pattern") }
+		}
+		expr := search.pattern
+		if strings.ToLower(expr) == expr {
+			expr = "(?i)" + expr
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return func() tea.Msg { return statusMsg(fmt.Sprintf("Invalid pattern: %v", err)) }
+		}
+		search.re = re
+		since, err := time.ParseDuration(search.since)
+		if err != nil || since <= 0 {
+			return func() tea.Msg { return statusMsg("Since must be a duration such as 15m, 1h or 24h") }
+		}
+
+		targets := searchTargets(tree, opts, search.containers)
+		if len(targets) == 0 {
+			return func() tea.Msg { return statusMsg("No containers match " + search.containers) }
+		}
+		status := func() tea.Msg {
+			return statusMsg(fmt.Sprintf("Searching the logs of %d containers…", len(targets)))
+		}
+		return tea.Sequence(status, search.run(client, targets, time.Now().Add(-since)))
+	}))
+}
+
+// searchTargets picks the local containers whose name contains, or whose name or
+// project matches, one of the comma-separated terms; all of them without terms
+func searchTargets(tree *model.Tree, opts model.TreeOptions, filter string) []*docker.ContainerInfo {
+	terms := []string{}
+	for _, term := range strings.Split(filter, ",") {
+		if term = strings.TrimSpace(term); term != "" {
+			terms = append(terms, term)
+		}
+	}
+	matches := func(c *docker.ContainerInfo) bool {
+		if len(terms) == 0 {
+			return true
+		}
+		project := model.ProjectName(c, opts)
+		for _, term := range terms {
+			if strings.Contains(c.Name, term) || term == project {
+				return true
+			}
+			if ok, _ := path.Match(term, c.Name); ok {
+				return true
+			}
+			if ok, _ := path.Match(term, project); ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	targets := []*docker.ContainerInfo{}
+	seen := make(map[string]bool)
+	for _, c := range tree.Containers() {
+		// Pinned containers appear twice in the tree
+		if seen[c.ID] || isPlaceholder(c) || c.Remote || !matches(c) {
+			continue
+		}
+		seen[c.ID] = true
+		targets = append(targets, c)
+	}
+	return targets
+}
+
+// run reads the logs of the targets since the given time in parallel and collects
+// the matching lines
+func (s *logSearch) run(client *docker.Client, targets []*docker.ContainerInfo, since time.Time) tea.Cmd {
+	return func() tea.Msg {
+		s.logs = make(map[string]string)
+		s.times = make(map[string][]time.Time)
+		var (
+			mu  sync.Mutex
+			wg  sync.WaitGroup
+			sem = make(chan struct{}, logSearchWorkers)
+		)
+		for _, c := range targets {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(c *docker.ContainerInfo) {
+				defer func() { <-sem; wg.Done() }()
+				var buf bytes.Buffer
+				err := client.StreamLogs(c.ID, docker.LogOptions{Tail: logSearchTail, Since: since, Timestamps: true}, &buf, &buf)
+				logs, times := splitLogTimestamps(buf.String())
+				matches := s.grep(c, logs, times)
+
+				mu.Lock()
+				defer mu.Unlock()
+				s.searched++
+				if err != nil {
+					s.failed = append(s.failed, c.Name)
+					return
+				}
+				if len(matches) > 0 {
+					s.matches = append(s.matches, matches...)
+					s.logs[c.ID] = logs
+					s.times[c.ID] = times
+				}
+			}(c)
+		}
+		wg.Wait()
+
+		sort.SliceStable(s.matches, func(i, j int) bool { return s.matches[i].time.After(s.matches[j].time) })
+		if len(s.matches) > logSearchLimit {
+			s.matches = s.matches[:logSearchLimit]
+			s.truncated = true
+		}
+		sort.Strings(s.failed)
+		return logSearchMsg{search: s}
+	}
+}
+
+// grep returns the lines of a container's logs that match
+func (s *logSearch) grep(c *docker.ContainerInfo, logs string, times []time.Time) []logMatch {
+	matches := []logMatch{}
+	for i, line := range strings.Split(logs, "\n") {
+		if s.re.MatchString(sanitizeLogLine(line, false)) {
+			matches = append(matches, logMatch{containerID: c.ID, container: c.Name, time: times[i], line: line, index: i})
+		}
+	}
+	return matches
+}
+
+func (m Model) handleLogSearch(msg logSearchMsg) (tea.Model, tea.Cmd) {
+	s := msg.search
+	m.logSearch = s
+	m.status = ""
+	if len(s.failed) > 0 {
+		m.status = "Couldn't read the logs of " + strings.Join(s.failed, ", ")
+	}
+	m.pagerScroll = 0
+	m.viewMode = ViewModeLogSearch
+	return m, nil
+}
+
+func (m Model) handleLogSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	next := *m.logSearch
+	m.logSearch = &next
+
+	switch msg.String() {
+	case "esc", "q":
+		m.viewMode = ViewModeMain
+	case "/":
+		m.openLogSearch()
+	case "up", "k":
+		if next.cursor > 0 {
+			next.cursor--
+		}
+	case "down", "j":
+		if next.cursor < len(next.matches)-1 {
+			next.cursor++
+		}
+	case "pgup":
+		next.cursor = max(next.cursor-(m.height-5), 0)
+	case "pgdown":
+		next.cursor = max(min(next.cursor+(m.height-5), len(next.matches)-1), 0)
+	case "home", "g":
+		next.cursor = 0
+	case "end", "G":
+		next.cursor = max(len(next.matches)-1, 0)
+	case "enter":
+		if len(next.matches) > 0 {
+			m.openLogMatch(next.matches[next.cursor])
+		}
+		return m, nil
+	}
+
+	// Keep the cursor in view below the summary and blank line
+	visibleHeight := m.height - 4
+	if row := next.cursor + 2; row < m.pagerScroll {
+		m.pagerScroll = row
+	} else if row >= m.pagerScroll+visibleHeight {
+		m.pagerScroll = row - visibleHeight + 1
+	}
+	return m, nil
+}
+
+// openLogMatch shows the searched logs of the match's container in the logs view,
+// scrolled to the matching line; leaving the logs view returns to the results
+func (m *Model) openLogMatch(match logMatch) {
+	m.logsContainerID = match.containerID
+	m.logsContainer = match.container
+	m.logsContent = m.logSearch.logs[match.containerID]
+	m.logsTimes = m.logSearch.times[match.containerID]
+	m.logsBack = ViewModeLogSearch
+	m.logsHScroll = 0
+	m.logsScroll = 0
+	_, sources := m.logRows()
+	for row, source := range sources {
+		if source >= match.index {
+			m.logsScroll = row
+			break
+		}
+	}
+	m.logsCursor = m.logsScroll
+	m.viewMode = ViewModeLogs
+}
+
+func (m Model) renderLogSearch() string {
+	s := m.logSearch
+	title := fmt.Sprintf("dtop - Log search: %s (last %s)", s.pattern, s.since)
+	help := "↑↓:select  enter:open in logs  /:new search  q/esc:back"
+
+	summary := fmt.Sprintf("%d matching lines in %d of %d containers, newest first", len(s.matches), len(s.logs), s.searched)
+	if s.truncated {
+		summary = fmt.Sprintf("More than %d matching lines in %d of %d containers; showing the newest %d", logSearchLimit, len(s.logs), s.searched, logSearchLimit)
+	}
+	lines := []string{headerStyle.Render(summary), ""}
+	if len(s.matches) == 0 {
+		lines = append(lines, "No lines match")
+	}
+
+	rules := append([]logHighlight{{re: s.re, style: statusStyle}}, logHighlights(m.config)...)
+	for i, match := range s.matches {
+		stamp := "-"
+		if !match.time.IsZero() {
+			t := match.time.In(m.config.LogLocation())
+			stamp = i18n.ShortDate(t) + " " + t.Format("15:04:05")
+		}
+		prefix := fmt.Sprintf("%-15s %s ", stamp, truncateOrPad(match.container, 24))
+		text := sanitizeLogLine(match.line, false)
+		if m.width > 0 {
+			text = ansi.TruncateWc(text, max(m.width-len(prefix), 10), "…")
+		}
+
+		if i == s.cursor {
+			lines = append(lines, selectedStyle.Render(prefix+text))
+			continue
+		}
+		// Highlight what matched, then the configured highlights
+		row := []string{text}
+		highlightLogRows(rules, text, row, 0, nil)
+		lines = append(lines, lipgloss.NewStyle().Foreground(mutedColor).Render(prefix)+row[0])
+	}
+
+	return m.renderPager(title, lines, help)
+}
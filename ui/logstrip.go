@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// logStripLines is how many log lines the strip below the tree shows
+const logStripLines = 5
+
+// logStrip holds the latest lines of the selected container's logs
+type logStrip struct {
+	containerID string
+	name        string
+	lines       []string
+	err         error
+}
+
+// logStripMsg delivers the tail of a container's logs for the strip
+type logStripMsg logStrip
+
+// logStripHeight is the number of rows the strip takes, its title included
+func (m Model) logStripHeight() int {
+	if !m.showLogStrip {
+		return 0
+	}
+	return logStripLines + 1
+}
+
+// treeHeight is the number of tree rows that fit between the header and the footer
+func (m Model) treeHeight() int {
+	// Title + blank = 2, Header = 1, Footer + blank = 2, Total overhead = 5
+	visibleHeight := m.height - 5 - m.hostBarHeight() - m.logStripHeight()
+	if visibleHeight < 1 {
+		visibleHeight = 1
+	}
+	return visibleHeight
+}
+
+// selectedContainerID is the ID and name of the selected container, if a container is selected
+func (m Model) selectedContainerID() (string, string) {
+	if m.tree == nil {
+		return "", ""
+	}
+	node := m.tree.GetSelected()
+	if node == nil || node.Container == nil {
+		return "", ""
+	}
+	return node.Container.ID, node.Container.Name
+}
+
+// fetchLogStrip loads the last lines of the selected container's logs
+func (m Model) fetchLogStrip() tea.Cmd {
+	if !m.showLogStrip || m.replay != nil {
+		return nil
+	}
+	containerID, name := m.selectedContainerID()
+	if containerID == "" {
+		return nil
+	}
+	switch c := m.tree.GetSelected().Container; {
+	case c.Remote:
+		return func() tea.Msg {
+			return logStripMsg{containerID: containerID, name: name, err: fmt.Errorf("runs on node %s", c.Node)}
+		}
+	case isPlaceholder(c):
+		return func() tea.Msg {
+			return logStripMsg{containerID: containerID, name: name, err: fmt.Errorf("not created yet")}
+		}
+	}
+	return func() tea.Msg {
+		content, err := m.dockerClient.GetContainerLogs(containerID, logStripLines)
+		lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+		if content == "" {
+			lines = nil
+		}
+		return logStripMsg{containerID: containerID, name: name, lines: lines, err: err}
+	}
+}
+
+// followLogStrip fetches the strip again when the selection moved to another container
+func (m Model) followLogStrip() tea.Cmd {
+	containerID, _ := m.selectedContainerID()
+	if m.logStrip != nil && m.logStrip.containerID == containerID {
+		return nil
+	}
+	return m.fetchLogStrip()
+}
+
+func (m Model) handleLogStrip(msg logStripMsg) (tea.Model, tea.Cmd) {
+	// Drop results for a container that is no longer selected
+	if containerID, _ := m.selectedContainerID(); !m.showLogStrip || containerID != msg.containerID {
+		return m, nil
+	}
+	strip := logStrip(msg)
+	m.logStrip = &strip
+	return m, nil
+}
+
+// toggleLogStrip shows or hides the log strip below the tree
+func (m *Model) toggleLogStrip() tea.Cmd {
+	m.showLogStrip = !m.showLogStrip
+	m.logStrip = nil
+	m.adjustViewport()
+	return m.fetchLogStrip()
+}
+
+// renderLogStrip renders the strip's title and lines, padded to its full height
+func (m Model) renderLogStrip() string {
+	var b strings.Builder
+	containerID, name := m.selectedContainerID()
+
+	title := "─ logs "
+	switch {
+	case containerID == "":
+		title += "(select a container) "
+	case m.logStrip == nil || m.logStrip.containerID != containerID:
+		title += "of " + name + " (loading…) "
+	default:
+		title += "of " + name + " "
+	}
+	if pad := m.width - len([]rune(title)); pad > 0 {
+		title += strings.Repeat("─", pad)
+	}
+	b.WriteString(lipgloss.NewStyle().Foreground(mutedColor).Render(truncateOrPad(title, m.width)))
+	b.WriteString("\n")
+
+	var lines []string
+	if m.logStrip != nil && m.logStrip.containerID == containerID {
+		lines = m.logStrip.lines
+		if m.logStrip.err != nil {
+			lines = []string{"Error: " + m.logStrip.err.Error()}
+		}
+	}
+	for i := 0; i < logStripLines; i++ {
+		if i < len(lines) {
+			line := truncateOrPad(sanitizeLogLine(lines[i], false), m.width)
+			var base *lipgloss.Style
+			if style, ok := logLevelStyle(detectLogLevel(line)); ok {
+				base = &style
+			}
+			row := []string{line}
+			highlightLogRows(logHighlights(m.config), line, row, 0, base)
+			b.WriteString(row[0])
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// What the logs view shows before each line
+const (
+	logStampsOff   = iota
+	logStampsTime  // The line's time
+	logStampsDelta // The line's time and the gap since the line before
+)
+
+// logStampModes names the timestamp modes, as in the log_timestamps setting
+var logStampModes = []string{"off", "time", "delta"}
+
+// logStampFormat is how log times are shown, in the configured time zone
+const logStampFormat = "2006-01-02 15:04:05.000"
+
+// Gaps between consecutive lines at least this long are highlighted
+const (
+	logGapWarn = time.Second
+	logGapSlow = 10 * time.Second
+)
+
+// logStampMode returns the timestamp mode a setting names; off when unset
+func logStampMode(setting string) int {
+	for i, name := range logStampModes {
+		if name == setting {
+			return i
+		}
+	}
+	return logStampsOff
+}
+
+// splitLogTimestamps removes the RFC 3339 timestamps docker puts before each log
+// line, returning the lines and their times; zero for lines without one
+func splitLogTimestamps(logs string) (string, []time.Time) {
+	lines := strings.Split(logs, "\n")
+	times := make([]time.Time, len(lines))
+	for i, line := range lines {
+		if stamp, rest, ok := strings.Cut(line, " "); ok {
+			if t, err := time.Parse(time.RFC3339Nano, stamp); err == nil {
+				times[i], lines[i] = t, rest
+			}
+		}
+	}
+	return strings.Join(lines, "\n"), times
+}
+
+// logStampWidth is the display width of the timestamp column, 0 when it's off
+func (m Model) logStampWidth() int {
+	switch {
+	case m.logsStamps == logStampsOff || len(m.logsTimes) == 0:
+		return 0
+	case m.logsStamps == logStampsDelta:
+		return len(logStampFormat) + 12
+	}
+	return len(logStampFormat) + 1
+}
+
+// logStamp renders the timestamp column for a log line, given the time of the
+// last line before it that had one
+func (m Model) logStamp(at, prev time.Time) string {
+	width := m.logStampWidth()
+	if at.IsZero() {
+		return strings.Repeat(" ", width)
+	}
+	muted := lipgloss.NewStyle().Foreground(mutedColor)
+	stamp := muted.Render(at.In(m.config.LogLocation()).Format(logStampFormat))
+	if m.logsStamps != logStampsDelta {
+		return stamp + " "
+	}
+
+	delta := strings.Repeat(" ", 11)
+	if !prev.IsZero() {
+		gap := at.Sub(prev)
+		style := muted
+		switch {
+		case gap >= logGapSlow:
+			style = stoppedStyle
+		case gap >= logGapWarn:
+			style = statusStyle
+		}
+		delta = style.Render(fmt.Sprintf(" %10s", formatLogGap(gap)))
+	}
+	return stamp + delta + " "
+}
+
+// formatLogGap formats the time between two log lines, in milliseconds below a
+// minute
+func formatLogGap(gap time.Duration) string {
+	if gap < time.Minute && gap > -time.Minute {
+		return fmt.Sprintf("%+.3fs", gap.Seconds())
+	}
+	text := gap.Truncate(time.Second).String()
+	if gap > 0 {
+		text = "+" + text
+	}
+	return text
+}
+
+// logZone names the time zone log times are shown in
+func (m Model) logZone() string {
+	loc := m.config.LogLocation()
+	if loc == time.Local {
+		return "local time, " + time.Now().Format("MST")
+	}
+	return loc.String()
+}
@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// metricsSamples is how many stats fetch durations the p95 is computed over
+const metricsSamples = 200
+
+// frameBudget is the time a render has at bubbletea's 60 frames per second; slower
+// renders make the renderer skip frames
+const frameBudget = time.Second / 60
+
+// selfMetrics measures dtop itself for the metrics overlay (ctrl+d), so performance
+// bugs can be reported with real numbers. It is shared by every copy of the model and
+// updated from commands, hence the lock.
+type selfMetrics struct {
+	mu         sync.Mutex
+	refresh    time.Duration   // Duration of the last container list
+	stats      []time.Duration // Recent stats fetch durations, oldest first
+	lastRender time.Duration
+	rendered   int // Frames rendered
+	reused     int // Frames reused from the frame cache
+	dropped    int // Renders over the frame budget
+}
+
+func (s *selfMetrics) recordRefresh(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.refresh = d
+}
+
+func (s *selfMetrics) recordStats(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats = append(s.stats, d)
+	if len(s.stats) > metricsSamples {
+		s.stats = s.stats[len(s.stats)-metricsSamples:]
+	}
+}
+
+func (s *selfMetrics) recordRender(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastRender = d
+	s.rendered++
+	if d > frameBudget {
+		s.dropped++
+	}
+}
+
+func (s *selfMetrics) recordReuse() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reused++
+}
+
+// statsP95 is the 95th percentile of the recent stats fetch durations
+func (s *selfMetrics) statsP95() time.Duration {
+	if len(s.stats) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), s.stats...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[(len(sorted)-1)*95/100]
+}
+
+// renderMetrics renders the overlay box with dtop's own numbers
+func (m Model) renderMetrics() string {
+	s := m.metrics
+	s.mu.Lock()
+	rows := [][2]string{
+		{"refresh", formatLatency(s.refresh)},
+		{"stats p95", fmt.Sprintf("%s (%d samples)", formatLatency(s.statsP95()), len(s.stats))},
+		{"render", formatLatency(s.lastRender)},
+		{"frames", fmt.Sprintf("%d drawn, %d reused", s.rendered, s.reused)},
+		{"dropped", fmt.Sprintf("%d over %s", s.dropped, formatLatency(frameBudget))},
+	}
+	s.mu.Unlock()
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	rows = append(rows,
+		[2]string{"goroutines", fmt.Sprint(runtime.NumGoroutine())},
+		[2]string{"heap", formatNetBytes(mem.HeapAlloc)},
+	)
+
+	lines := []string{headerStyle.Render("dtop metrics")}
+	for _, row := range rows {
+		lines = append(lines, headerStyle.Render(fmt.Sprintf("%-11s", row[0]))+containerStyle.Render(row[1]))
+	}
+	return lipgloss.NewStyle().
+		Border(modalBorder()).
+		BorderForeground(mutedColor).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+}
+
+// formatLatency rounds a duration for display, e.g. "12ms" or "850µs"
+func formatLatency(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(10 * time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(100 * time.Microsecond).String()
+	}
+	return d.Round(time.Microsecond).String()
+}
+
+// overlay draws box over the right edge of frame, starting at line top
+func overlay(frame, box string, width, top int) string {
+	lines := strings.Split(frame, "\n")
+	left := width - lipgloss.Width(box)
+	if left < 0 {
+		return frame
+	}
+	for i, boxLine := range strings.Split(box, "\n") {
+		row := top + i
+		if row >= len(lines) {
+			break
+		}
+		line := ansi.Truncate(lines[row], left, "")
+		lines[row] = line + ansi.ResetStyle + strings.Repeat(" ", left-ansi.StringWidth(line)) + boxLine
+	}
+	return strings.Join(lines, "\n")
+}
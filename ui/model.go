@@ -1,11 +1,20 @@
 package ui
 
 import (
+	"bytes"
+	"sort"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/audit"
+	"github.com/ekinertac/dtop/config"
+	"github.com/ekinertac/dtop/debuglog"
 	"github.com/ekinertac/dtop/docker"
+	"github.com/ekinertac/dtop/hooks"
+	"github.com/ekinertac/dtop/i18n"
 	"github.com/ekinertac/dtop/model"
+	"github.com/ekinertac/dtop/record"
 )
 
 type ViewMode int
@@ -14,77 +23,302 @@ const (
 	ViewModeMain ViewMode = iota
 	ViewModeMenu
 	ViewModeLogs
+	ViewModeForm
+	ViewModeDetail
+	ViewModeAudit
+	ViewModeJump
+	ViewModeCompare
+	ViewModeOutput
+	ViewModeHost
+	ViewModePull
+	ViewModeProcesses
+	ViewModeConnections
+	ViewModeBuild
+	ViewModeCleanup
+	ViewModeBatch
+	ViewModeLogSearch
 )
 
 type Model struct {
-	dockerClient   *docker.Client
-	tree           *model.Tree
-	viewMode       ViewMode
-	menuItems      []MenuItem
-	menuSelected   int
-	logsContent    string
-	logsScroll     int
-	logsContainer  string
-	width          int
-	height         int
-	viewportTop    int // First visible line in the tree
-	err            error
+	dockerClient    *docker.Client
+	config          *config.Config
+	savedState      *config.State // Layout from the last session, applied on first load
+	audit           *audit.Log    // Record of every action performed
+	tree            *model.Tree
+	viewMode        ViewMode
+	menuItems       []MenuItem
+	menuSelected    int
+	logsContent     string
+	logsScroll      int
+	logsContainer   string
+	logsContainerID string
+	logsColors      bool                     // Render ANSI colors in logs instead of stripping them
+	logsWrap        bool                     // Wrap long log lines instead of scrolling horizontally
+	logsHScroll     int                      // First visible column when wrapping is off
+	logsLevel       int                      // Minimum level shown in the logs view (index into logLevelFilters)
+	absoluteUnits   bool                     // Show CPU as cores and memory as bytes used instead of percentages
+	showLogStrip    bool                     // Show the selected container's latest logs below the tree
+	logStrip        *logStrip                // Latest lines for the log strip
+	logsJSON        bool                     // Select log lines with a cursor to expand JSON entries
+	logsCursor      int                      // Selected row in JSON mode
+	logsBack        ViewMode                 // View the logs view returns to
+	logsTimes       []time.Time              // Time of each log line; zero where unknown
+	logsStamps      int                      // What goes before each log line (logStamps* mode)
+	logSearch       *logSearch               // Last search across the logs of all containers
+	form            *form                    // Active form for wizards and prompts
+	status          string                   // Status bar message (last action result, progress)
+	showGPU         bool                     // Show the GPU column (collecting it costs an exec per container)
+	showImage       bool                     // Show the image column
+	imageToggled    bool                     // I was pressed, so showImage applies on wide terminals too
+	showLogRate     bool                     // Show the log rate column (keeps a log stream open per container)
+	absoluteTimes   bool                     // Show start/exit times instead of uptime
+	detailID        string                   // Container shown in the detail view
+	detailPolicy    string                   // Restart policy of the detail view's container; empty while loading
+	detailNetwork   *docker.NetworkInfo      // Networks of the detail view's container; nil while loading
+	detailNetErr    error                    // Error from looking up the detail view's networks
+	pagerScroll     int                      // Scroll position of pager-style views (details, audit log)
+	jumpQuery       string                   // Filter typed into the jump palette
+	jumpSelected    int                      // Highlighted match in the jump palette
+	marked          []string                 // Container IDs marked for comparison, in marking order
+	projectMarks    map[string]bool          // Projects marked for batch operations
+	batch           *batch                   // Running or last batch operation across projects
+	stopping        map[string]time.Time     // Containers being stopped with a custom timeout and when they get killed
+	actions         *actionQueue             // Serializes actions per container; shared by all model copies
+	projectOps      map[string]*projectOp    // Project-wide actions in progress, by project name
+	history         map[string][]statsSample // Recent stats samples per container ID
+	graphs          graphStyle               // How history graphs are drawn
+	peaks           map[string]*usagePeak    // Highest usage this session per container ID
+	events          containerEvents          // Daemon events this session per container ID
+	output          *outputMsg               // Content of the output view
+	hostInfo        *docker.HostInfo         // Last daemon info, for the host view and host bar
+	hostErr         error                    // Error from the last daemon info query
+	nodes           []string                 // Swarm nodes of the listed containers; empty outside swarm
+	compose         map[string]composeCheck  // Compose project -> services defined vs created
+	nodeFilter      string                   // Only list containers on this swarm node
+	frame           *frameCache              // Last rendered frame, reused while nothing changed
+	rows            *rowCache                // Rendered container rows of the last frames
+	panicked        *panicReport             // Panic shown on the error screen
+	metrics         *selfMetrics             // dtop's own timings for the metrics overlay
+	showMetrics     bool                     // Show the metrics overlay on the main view
+	quickTarget     string                   // Container selected with a number key, awaiting an action key
+	undo            []undoAction             // Recent stops and removals ctrl+z reverts, oldest first
+	grouping        model.Grouping           // What the tree groups containers by; g cycles
+	order           model.Order              // How containers are sorted within groups; o cycles
+	showHostBar     bool                     // Show host CPU/memory gauges above the table
+	blurred         bool                     // The terminal lost focus; refreshes slow down
+	lastPoll        time.Time                // When the last tick refreshed containers
+	pull            *pullMsg                 // Latest update from the running image pull
+	processes       *processes               // State of the processes view
+	connections     *connections             // State of the connections view
+	build           *build                   // Running or last image build
+	flash           map[string]time.Time     // Rows highlighted after a crash (container ID or project name) and until when
+	bell            bool                     // Frames ring the terminal bell until bellDoneMsg
+	recorder        *record.Recorder         // Records updates for --record; nil when not recording
+	hooks           *hooks.Runner            // Runs configured hooks on events; nil when none are configured
+	replay          *replay                  // Recording being played back; nil when monitoring the daemon
+	readOnly        bool                     // Hide actions that change containers or images
+	connectProfile  ProfileSwitcher          // Reconnects for the profile switcher; nil disables it
+	menuTitle       string                   // Heading of menus that aren't about the selected row
+	favorites       map[string]bool          // Pinned containers and projects (model.FavoriteKey)
+	hidden          map[string]bool          // Names of containers hidden with x
+	notes           map[string]string        // model.FavoriteKey -> note, saved in the state file
+	showHidden      bool                     // Reveal containers hidden with x or by ignore patterns
+	hiddenCount     int                      // Containers hidden in the last refresh
+	cleanup         *cleanup                 // State of the cleanup review screen
+	cleaning        map[string]bool          // Container IDs already being removed by auto-cleanup
+	stale           []docker.ContainerInfo   // Containers flagged by the cleanup policy at the last check
+	staleAt         time.Time                // Last check for stale containers
+	stopped         map[string]int           // Stopped containers per project at the last count
+	stoppedAt       time.Time                // Last count of stopped containers
+	version         string                   // Running dtop version, set to check for updates on start
+	newVersion      string                   // Newer release found by the update check, noted in the status bar
+	width           int
+	height          int
+	viewportTop     int // First visible line in the tree
+	err             error
 }
 
 type MenuItem struct {
-	Label  string
-	Action func() tea.Cmd
+	Label   string
+	Action  func() tea.Cmd
+	Mutates bool // Changes containers or images; hidden in read-only mode
 }
 
 type tickMsg time.Time
 
-func NewModel(dockerClient *docker.Client) Model {
+// TreeOptions derives tree grouping options from the config
+func TreeOptions(cfg *config.Config) model.TreeOptions {
+	return model.TreeOptions{
+		GroupStandalone: cfg.StandaloneGroup,
+		GroupLabel:      cfg.GroupByLabel,
+		ProjectFor:      cfg.ProjectFor,
+	}
+}
+
+// treeOptions adds the session's grouping, order and favorites to the config's grouping options
+func (m Model) treeOptions() model.TreeOptions {
+	opts := TreeOptions(m.config)
+	opts.GroupBy = m.grouping
+	opts.Order = m.order
+	opts.Favorites = m.favorites
+	return opts
+}
+
+func NewModel(dockerClient *docker.Client, cfg *config.Config) Model {
+	// A missing or unreadable state file just means starting with the default layout
+	state, err := config.LoadState(cfg.StateFile)
+	if err != nil {
+		state = nil
+	}
+
+	favorites := make(map[string]bool)
+	hidden := make(map[string]bool)
+	notes := make(map[string]string)
+	if state != nil {
+		for _, key := range state.Favorites {
+			favorites[key] = true
+		}
+		for _, name := range state.Hidden {
+			hidden[name] = true
+		}
+		for key, note := range state.Notes {
+			notes[key] = note
+		}
+	}
+
+	applyAccent(cfg.Accent)
+	applyAccessible(cfg.Accessible)
+	i18n.Set(cfg.Language)
+	log := audit.New(cfg.AuditLogFile)
 	return Model{
-		dockerClient:  dockerClient,
-		tree:          &model.Tree{},
-		viewMode:      ViewModeMain,
-		menuSelected:  0,
-		logsScroll:    0,
+		dockerClient: dockerClient,
+		config:       cfg,
+		savedState:   state,
+		audit:        log,
+		hooks:        hooks.New(cfg.Hooks, log),
+		readOnly:     cfg.ReadOnly,
+		grouping:     startGrouping(cfg),
+		order:        startOrder(cfg),
+		tree:         &model.Tree{},
+		viewMode:     ViewModeMain,
+		menuSelected: 0,
+		logsScroll:   0,
+		logsColors:   cfg.LogColors,
+		logsWrap:     true,
+		logsStamps:   logStampMode(cfg.LogTimestamps),
+		history:      make(map[string][]statsSample),
+		graphs:       resolveGraphStyle(cfg.Graphs),
+		peaks:        make(map[string]*usagePeak),
+		events:       make(containerEvents),
+		flash:        make(map[string]time.Time),
+		favorites:    favorites,
+		hidden:       hidden,
+		notes:        notes,
+		cleaning:     make(map[string]bool),
+		projectMarks: make(map[string]bool),
+		stopping:     make(map[string]time.Time),
+		actions:      newActionQueue(),
+		showHostBar:  true,
+		projectOps:   make(map[string]*projectOp),
+		compose:      make(map[string]composeCheck),
+		frame:        &frameCache{},
+		rows:         newRowCache(),
+		panicked:     &panicReport{},
+		metrics:      &selfMetrics{},
 	}
 }
 
 func (m Model) Init() tea.Cmd {
+	if m.replay != nil {
+		return m.scheduleFrame()
+	}
 	return tea.Batch(
 		m.refreshContainersWithStats(false), // First load without stats (instant)
+		m.fetchHostInfo(),                   // Host capacity for the host bar
+		m.watchEvents(),                     // Daemon events for the detail view's timeline
 		tickCmd(),
+		m.checkUpdate(),
 	)
 }
 
+// refreshInterval is how often the container list and stats are refreshed
+const refreshInterval = 2 * time.Second
+
 func tickCmd() tea.Cmd {
-	return tea.Tick(2*time.Second, func(t time.Time) tea.Msg {
+	return tea.Tick(refreshInterval, func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
 
+// refreshContainers lists containers without waiting for stats; stats for each
+// running container are fetched separately once the list arrives
 func (m Model) refreshContainers() tea.Cmd {
-	return m.refreshContainersWithStats(true)
+	return m.refreshContainersWithStats(false)
 }
 
 func (m Model) refreshContainersWithStats(includeStats bool) tea.Cmd {
 	return func() tea.Msg {
+		start := time.Now()
 		containers, err := m.dockerClient.ListContainersWithStats(includeStats)
 		if err != nil {
+			debuglog.Error("refresh", err, "duration", time.Since(start))
 			return errMsg{err}
 		}
+		m.metrics.recordRefresh(time.Since(start))
+		debuglog.Debug("refresh", "containers", len(containers), "stats", includeStats, "duration", time.Since(start))
 		return containersMsg(containers)
 	}
 }
 
 type containersMsg []docker.ContainerInfo
 type logsMsg struct {
+	containerID   string
 	containerName string
 	content       string
+	times         []time.Time
 }
 type errMsg struct{ err error }
 
+// statusMsg shows a message in the status bar, e.g. the result of a background action
+type statusMsg string
+
 func (e errMsg) Error() string { return e.err.Error() }
 
-func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+// Update invalidates the cached frame, unless the message can't change what is on
+// screen, and handles the message. A panic while handling it brings up the error
+// screen and drops the model's copy made for the message; what its handler already
+// changed through shared maps, slices and pointers (e.g. the tree) stays changed.
+func (m Model) Update(msg tea.Msg) (next tea.Model, cmd tea.Cmd) {
+	defer func() {
+		if r := recover(); r != nil {
+			m.panicked.record("update", r)
+			m.frame.invalidate()
+			m.pagerScroll = 0
+			next, cmd = m, nil
+		}
+	}()
+
+	switch msg.(type) {
+	case tickMsg:
+		// Only starts refreshes, but the metrics overlay shows live numbers
+		if m.showMetrics {
+			m.frame.invalidate()
+		}
+	case statsMsg:
+		// Invalidated when the sample changes a row; other views show more detail
+		if m.viewMode != ViewModeMain {
+			m.frame.invalidate()
+		}
+	default:
+		m.frame.invalidate()
+	}
+	if key, ok := msg.(tea.KeyMsg); ok && m.panicked.value != nil {
+		return m.handlePanicKey(key)
+	}
+	return m.update(msg)
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -93,90 +327,354 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case containersMsg:
-		// Preserve selection and expand/collapse state across refresh
-		var selectedPath string
-		expandedProjects := make(map[string]bool)
-		
-		if m.tree != nil {
-			selectedNode := m.tree.GetSelected()
-			if selectedNode != nil {
-				selectedPath = m.tree.GetNodePath(selectedNode)
-			}
-			
-			// Save expand/collapse state for each project
-			for _, node := range m.tree.Flat {
-				if node.Type == model.NodeTypeProject {
-					expandedProjects[node.Name] = node.Expanded
-				}
-			}
-		}
-		
-		m.tree = model.BuildTree(msg)
-		
-		// Restore expand/collapse state
-		for _, node := range m.tree.Root.Children {
-			if node.Type == model.NodeTypeProject {
-				if expanded, exists := expandedProjects[node.Name]; exists {
+		// Merge into the existing tree; expansion, selection and stats are preserved
+		firstLoad := m.tree.Root == nil
+		previous := m.snapshotHealth()
+		m.recorder.Containers(msg)
+		checkCompose := m.checkComposeServices(msg)
+		m.nodes = swarmNodes(msg)
+		msg = m.onNode(msg)
+		msg = append(msg, m.placeholders(msg)...)
+		msg, m.hiddenCount = m.visibleContainers(msg)
+		m.tree.Update(msg, m.treeOptions())
+		m.pruneHistory(msg)
+
+		// On first load, restore the layout saved by the previous session
+		if m.savedState != nil {
+			for _, node := range m.tree.Root.Children {
+				if expanded, exists := m.savedState.Expanded[node.Name]; exists {
 					node.Expanded = expanded
 				}
 			}
+			m.tree.UpdateFlatView()
+			m.tree.RestoreSelection(m.savedState.Selected)
+			m.savedState = nil
 		}
-		m.tree.UpdateFlatView()
-		
-		// Restore selection if possible
-		if selectedPath != "" {
-			m.tree.RestoreSelection(selectedPath)
-		}
-		
+
 		// Adjust viewport to ensure selection is visible
 		m.adjustViewport()
-		
+
+		// First load fetches stats right away; after that they're jittered across the interval
+		spread := refreshInterval
+		if firstLoad {
+			spread = 0
+		}
+		if m.replay != nil {
+			// Stats come from the recording
+			return m, m.detectCrashes(previous, msg)
+		}
+		staleCheck, stoppedCheck := m.staleCheckDue(), m.stoppedCheckDue()
+		return m, tea.Batch(m.fetchAllStats(msg, spread), m.detectCrashes(previous, msg), staleCheck, stoppedCheck, checkCompose)
+
+	case replayFrameMsg:
+		return m.playFrame(msg)
+
+	case profileMsg:
+		return m.handleProfile(msg)
+
+	case crashMsg:
+		return m.handleCrash(msg)
+
+	case bellDoneMsg:
+		m.bell = false
+		return m, nil
+
+	case statsMsg:
+		m.recorder.Stats(msg.containerID, msg.stats)
+		if m.applyStats(msg) {
+			m.frame.invalidate()
+		}
 		return m, nil
 
 	case tickMsg:
-		return m, tea.Batch(
-			m.refreshContainers(),
-			tickCmd(),
-		)
+		if !m.pollDue(time.Time(msg)) {
+			// Unfocused; keep ticking so the normal rate resumes with focus
+			return m, tickCmd()
+		}
+		m.lastPoll = time.Time(msg)
+		return m, tea.Batch(tickCmd(), m.poll())
+
+	case tea.FocusMsg:
+		return m.handleFocus(true)
+
+	case tea.BlurMsg:
+		return m.handleFocus(false)
+
+	case openProcessesMsg:
+		return m, m.openProcesses(msg.containerID, msg.name)
+
+	case processesMsg:
+		return m.handleProcesses(msg)
+
+	case openConnectionsMsg:
+		return m, m.openConnections(msg.containerID, msg.name)
+
+	case connectionsMsg:
+		return m.handleConnections(msg)
+
+	case noteMsg:
+		return m.handleNote(msg)
+
+	case composeCheckMsg:
+		return m.handleComposeServices(msg)
+
+	case hostInfoMsg:
+		m.hostInfo = msg.info
+		m.hostErr = msg.err
+		return m, nil
+
+	case updateMsg:
+		m.newVersion = string(msg)
+		return m, nil
 
 	case logsMsg:
+		m.logsContainerID = msg.containerID
 		m.logsContainer = msg.containerName
 		m.logsContent = msg.content
+		m.logsTimes = msg.times
 		m.logsScroll = 0
+		m.logsBack = ViewModeMain
 		m.viewMode = ViewModeLogs
 		return m, nil
 
+	case logSearchMsg:
+		return m.handleLogSearch(msg)
+
+	case openDetailMsg:
+		return m, m.openDetail(msg.containerID)
+
+	case openBuildFormMsg:
+		m.openBuildForm(msg.image, msg.contextDir)
+		return m, nil
+
+	case startBuildMsg:
+		return m, m.startBuild(msg.tag, msg.args, msg.targets)
+
+	case startBatchMsg:
+		return m, m.startBatch(msg)
+
+	case batchMsg:
+		return m.handleBatch(msg)
+
+	case startStopMsg:
+		return m, m.startStop(msg)
+
+	case stopDoneMsg:
+		return m.handleStopDone(msg)
+
+	case actionDoneMsg:
+		return m.handleActionDone(msg)
+
+	case projectStepMsg:
+		return m.handleProjectStep(msg)
+
+	case projectWaitMsg:
+		return m.handleProjectWait(msg)
+
+	case orderedStartMsg:
+		return m.handleOrderedStart(msg)
+
+	case staleMsg:
+		return m.handleStale(msg)
+
+	case stoppedMsg:
+		return m.handleStopped(msg)
+
+	case actionTickMsg:
+		// The spinner redraws with the model; keep ticking while actions are in flight
+		if m.actions.keepTicking() {
+			return m, actionTick()
+		}
+		return m, nil
+
+	case stopTickMsg:
+		// The countdown redraws with the model; keep ticking while stops are running
+		if len(m.stopping) > 0 {
+			return m, stopCountdown()
+		}
+		return m, nil
+
+	case clearProjectMarksMsg:
+		m.projectMarks = make(map[string]bool)
+		return m, nil
+
+	case buildMsg:
+		return m.handleBuild(msg)
+
+	case composeEditedMsg:
+		return m.handleComposeEdited(msg)
+
+	case networkInfoMsg:
+		return m.handleNetworkInfo(msg)
+
+	case restartPolicyMsg:
+		return m.handleRestartPolicy(msg)
+
+	case openFormMsg:
+		m.openForm(msg.form)
+		return m, nil
+
+	case outputMsg:
+		m.openOutput(msg)
+		return m, nil
+
+	case progressMsg:
+		return m.handleProgress(msg)
+
+	case pullMsg:
+		return m.handlePull(msg)
+
 	case errMsg:
 		m.err = msg.err
 		return m, nil
 
+	case statusMsg:
+		m.status = string(msg)
+		return m, nil
+
+	case csvSavedMsg:
+		return m.handleCSVSaved(msg)
+
+	case containerEventMsg:
+		return m.handleContainerEvent(msg)
+
+	case watchEventsMsg:
+		return m, m.watchEvents()
+
+	case openMenuMsg:
+		m.openMenuWith(msg.title, msg.items)
+		return m, nil
+
+	case logStripMsg:
+		return m.handleLogStrip(msg)
+
 	case tea.KeyMsg:
-		return m.handleKeyPress(msg)
+		updated, cmd := m.handleKeyPress(msg)
+		if next, ok := updated.(Model); ok && next.showLogStrip {
+			return next, tea.Batch(cmd, next.followLogStrip())
+		}
+		return updated, cmd
 	}
 
 	return m, nil
 }
 
 func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Handle form input
+	if m.viewMode == ViewModeForm {
+		return m.handleFormKey(msg)
+	}
+
+	// Handle detail view
+	if m.viewMode == ViewModeDetail {
+		return m.handleDetailKey(msg)
+	}
+
+	// Handle jump palette
+	if m.viewMode == ViewModeJump {
+		return m.handleJumpKey(msg)
+	}
+
+	// Handle comparison view
+	if m.viewMode == ViewModeCompare {
+		return m.handleCompareKey(msg)
+	}
+
+	// Handle command output view
+	if m.viewMode == ViewModeOutput {
+		return m.handleOutputKey(msg)
+	}
+
+	// Handle host view
+	if m.viewMode == ViewModeHost {
+		return m.handleHostKey(msg)
+	}
+
+	// Handle processes view
+	if m.viewMode == ViewModeProcesses {
+		return m.handleProcessesKey(msg)
+	}
+
+	// Handle connections view
+	if m.viewMode == ViewModeConnections {
+		return m.handleConnectionsKey(msg)
+	}
+
+	// Handle batch progress
+	if m.viewMode == ViewModeBatch {
+		return m.handleBatchKey(msg)
+	}
+
+	// Handle log search results
+	if m.viewMode == ViewModeLogSearch {
+		return m.handleLogSearchKey(msg)
+	}
+
+	// Handle cleanup review
+	if m.viewMode == ViewModeCleanup {
+		return m.handleCleanupKey(msg)
+	}
+
+	// Handle build view
+	if m.viewMode == ViewModeBuild {
+		return m.handleBuildKey(msg)
+	}
+
+	// Handle pull progress view
+	if m.viewMode == ViewModePull {
+		return m.handlePullKey(msg)
+	}
+
+	// Handle audit log view
+	if m.viewMode == ViewModeAudit {
+		return m.handleAuditKey(msg)
+	}
+
 	// Handle logs view
 	if m.viewMode == ViewModeLogs {
 		switch msg.String() {
 		case "esc", "q":
-			m.viewMode = ViewModeMain
+			m.viewMode = m.logsBack
 			m.logsContent = ""
+			m.logsTimes = nil
 			m.logsScroll = 0
+			m.logsHScroll = 0
+			m.logsCursor = 0
+		case "J":
+			m.logsJSON = !m.logsJSON
+			m.logsCursor = m.logsScroll
+		case "enter":
+			if m.logsJSON {
+				m.openLogJSON()
+			}
 		case "up", "k":
+			if m.logsJSON {
+				m.moveLogsCursor(-1)
+				break
+			}
 			if m.logsScroll > 0 {
 				m.logsScroll--
 			}
 		case "down", "j":
+			if m.logsJSON {
+				m.moveLogsCursor(1)
+				break
+			}
 			m.logsScroll++
 		case "pgup":
+			if m.logsJSON {
+				m.moveLogsCursor(-(m.height - 5))
+				break
+			}
 			m.logsScroll -= m.height - 5
 			if m.logsScroll < 0 {
 				m.logsScroll = 0
 			}
 		case "pgdown":
+			if m.logsJSON {
+				m.moveLogsCursor(m.height - 5)
+				break
+			}
 			m.logsScroll += m.height - 5
 		case "home":
 			m.logsScroll = 0
@@ -185,6 +683,33 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case "G":
 			// Go to end
 			m.logsScroll = 999999 // Will be clamped in view
+		case "c":
+			m.logsColors = !m.logsColors
+		case "T":
+			m.logsStamps = (m.logsStamps + 1) % len(logStampModes)
+			m.logsHScroll = 0
+		case "w":
+			m.logsWrap = !m.logsWrap
+			m.logsScroll = 0
+			m.logsHScroll = 0
+		case "left", "h":
+			if !m.logsWrap {
+				m.logsHScroll -= logsHScrollStep
+				if m.logsHScroll < 0 {
+					m.logsHScroll = 0
+				}
+			}
+		case "right", "l":
+			if !m.logsWrap && m.logsHScroll+m.width < m.logsMaxWidth() {
+				m.logsHScroll += logsHScrollStep
+			}
+		case "0":
+			m.logsHScroll = 0
+		case "L":
+			m.logsLevel = (m.logsLevel + 1) % len(logLevelFilters)
+			m.logsScroll = 0
+		case "s":
+			m.openForm(m.saveLogsForm(m.logsContainerID, m.logsContainer, m.logsContent))
 		}
 		return m, nil
 	}
@@ -213,9 +738,26 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// Playback controls when replaying a recording
+	if m.replay != nil {
+		if updated, cmd, handled := m.handleReplayKey(msg); handled {
+			return updated, cmd
+		}
+	}
+
+	// The key after a number key may be a quick action for that container
+	if m.quickTarget != "" {
+		updated, cmd, handled := m.handleQuickAction(msg)
+		if handled {
+			return updated, cmd
+		}
+		m = updated
+	}
+
 	// Handle tree navigation
 	switch msg.String() {
 	case "q", "ctrl+c":
+		m.saveState()
 		return m, tea.Quit
 
 	case "up", "k":
@@ -228,10 +770,7 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "pgup":
 		// Page up - move up by viewport height
-		visibleHeight := m.height - 5
-		if visibleHeight < 1 {
-			visibleHeight = 1
-		}
+		visibleHeight := m.treeHeight()
 		for i := 0; i < visibleHeight && m.tree.Selected > 0; i++ {
 			m.tree.MoveUp()
 		}
@@ -239,10 +778,7 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "pgdown":
 		// Page down - move down by viewport height
-		visibleHeight := m.height - 5
-		if visibleHeight < 1 {
-			visibleHeight = 1
-		}
+		visibleHeight := m.treeHeight()
 		for i := 0; i < visibleHeight && m.tree.Selected < len(m.tree.Flat)-1; i++ {
 			m.tree.MoveDown()
 		}
@@ -276,105 +812,276 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.adjustViewport()
 		}
 
+	case "E":
+		m.tree.SetAllExpanded(true)
+		m.adjustViewport()
+
+	case "C":
+		m.tree.SetAllExpanded(false)
+		m.adjustViewport()
+
 	case "enter":
 		m.openMenu()
+
+	case "d":
+		node := m.tree.GetSelected()
+		if node != nil && node.Container != nil && isPlaceholder(node.Container) {
+			m.status = node.Container.Name + " is defined in the compose file but has no container yet"
+		} else if node != nil && node.Container != nil && !m.remoteContainer(node.Container) {
+			return m, m.openDetail(node.Container.ID)
+		}
+
+	case "n":
+		if m.readOnly {
+			m.status = "Read-only: creating containers is disabled (R to allow changes)"
+			break
+		}
+		m.openCreateForm()
+
+	case "b":
+		if m.readOnly {
+			m.status = "Read-only: building images is disabled (R to allow changes)"
+			break
+		}
+		m.openBuildForm("", "")
+
+	case "D":
+		return m, m.openCleanup()
+
+	case "R":
+		m.readOnly = !m.readOnly
+		m.status = "Read-only " + onOff(m.readOnly)
+
+	case "P":
+		m.openProfiles()
+
+	case "a":
+		m.pagerScroll = 0
+		m.viewMode = ViewModeAudit
+
+	case "ctrl+f", ":":
+		m.openJump()
+
+	case "m":
+		m.toggleMark()
+
+	case "f":
+		m.toggleFavorite()
+
+	case "x":
+		return m, m.toggleHidden()
+
+	case "X":
+		return m, m.toggleShowHidden()
+
+	case "z":
+		if m.tree.Zoom == "" {
+			m.tree.ZoomIn()
+		} else {
+			m.tree.ZoomOut()
+		}
+		m.adjustViewport()
+
+	case "esc":
+		if m.tree.Zoom != "" {
+			m.tree.ZoomOut()
+			m.adjustViewport()
+		}
+
+	case "i":
+		return m, m.openHost()
+
+	case "c":
+		m.openCompare()
+
+	case "G":
+		m.showGPU = !m.showGPU
+		m.dockerClient.SetGPUStats(m.showGPU)
+
+	case "I":
+		m.showImage = !m.layout().image
+		m.imageToggled = true
+
+	case "L":
+		m.showLogRate = !m.showLogRate
+		m.dockerClient.SetLogRates(m.showLogRate)
+
+	case "u":
+		m.absoluteTimes = !m.absoluteTimes
+
+	case "t":
+		return m, m.toggleLogStrip()
+
+	case "U":
+		m.absoluteUnits = !m.absoluteUnits
+
+	case "H":
+		m.showHostBar = !m.showHostBar
+		m.adjustViewport()
+
+	case "ctrl+z":
+		return m.undoLast()
+
+	case "g":
+		return m, m.cycleGrouping()
+
+	case "S":
+		return m, m.openSizingReport()
+
+	case "Q":
+		return m, m.openQuotaReport()
+
+	case "p":
+		return m, m.openPortsReport()
+
+	case "/":
+		m.openLogSearch()
+
+	case "o":
+		m.cycleOrder()
+
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		m.selectQuickRow(int(msg.String()[0] - '0'))
+
+	case "ctrl+d":
+		// Not in the help text; for performance bug reports
+		m.showMetrics = !m.showMetrics
+
+	case "N":
+		return m, m.cycleNodeFilter()
+
+	case "e":
+		m.editNote()
+
+	case "?":
+		m.status = m.describeSelected()
 	}
 
 	return m, nil
 }
 
+// saveState persists the expanded projects and selection for the next session
+func (m *Model) saveState() {
+	// The layout of a replayed recording isn't this host's
+	if m.replay != nil || m.tree == nil || m.tree.Root == nil {
+		return
+	}
+
+	state := &config.State{Expanded: make(map[string]bool), Favorites: []string{}}
+	for key := range m.favorites {
+		state.Favorites = append(state.Favorites, key)
+	}
+	sort.Strings(state.Favorites)
+	state.Hidden = []string{}
+	for name := range m.hidden {
+		state.Hidden = append(state.Hidden, name)
+	}
+	sort.Strings(state.Hidden)
+	state.Notes = m.notes
+	for _, node := range m.tree.Root.Children {
+		if node.Type == model.NodeTypeProject {
+			state.Expanded[node.Name] = node.Expanded
+		}
+	}
+	if selected := m.tree.GetSelected(); selected != nil {
+		state.Selected = m.tree.GetNodePath(selected)
+	}
+
+	// Best effort: failing to save layout shouldn't block quitting
+	config.SaveState(m.config.StateFile, state)
+}
+
 func (m *Model) openMenu() {
 	node := m.tree.GetSelected()
 	if node == nil {
 		return
 	}
 
-	m.menuSelected = 0
-	m.viewMode = ViewModeMenu
-
+	var items []MenuItem
 	switch node.Type {
 	case model.NodeTypeProject:
-		m.menuItems = m.getProjectMenuItems(node)
+		items = append(m.batchMenuItems(), m.getProjectMenuItems(node)...)
 	case model.NodeTypeContainer:
-		m.menuItems = m.getContainerMenuItems(node)
+		if m.remoteContainer(node.Container) {
+			return
+		}
+		items = m.getContainerMenuItems(node)
+		if isPlaceholder(node.Container) {
+			items = m.placeholderMenuItems(node.Container)
+		}
+	}
+
+	if m.readOnly {
+		allowed := []MenuItem{}
+		for _, item := range items {
+			if !item.Mutates {
+				allowed = append(allowed, item)
+			}
+		}
+		items = allowed
 	}
+	if len(items) == 0 {
+		m.status = "Read-only: no actions available (R to allow changes)"
+		return
+	}
+
+	m.menuItems = items
+	m.menuSelected = 0
+	m.menuTitle = ""
+	m.viewMode = ViewModeMenu
 }
 
 func (m *Model) getProjectMenuItems(node *model.TreeNode) []MenuItem {
 	// Capture the children slice to avoid closure issues
 	children := node.Children
-	
-	return []MenuItem{
-		{
-			Label: "Restart All",
-			Action: func() tea.Cmd {
-				return func() tea.Msg {
-					// Run in background
-					go func() {
-						for _, child := range children {
-							if child.Container != nil && child.Container.State == "running" {
-								m.dockerClient.RestartContainer(child.Container.ID)
-							}
-						}
-					}()
-					// Immediately refresh to show operation started
-					return m.refreshContainers()()
-				}
-			},
-		},
-		{
-			Label: "Stop All",
+	project := node.Name
+
+	items := []MenuItem{}
+	for _, op := range []struct {
+		label string
+		op    batchOp
+	}{
+		{"Restart All", restartOp},
+		{"Stop All", stopOp},
+		{"Down (stop & remove, keeps volumes)", downOp},
+		{"Start All", startOp},
+	} {
+		items = append(items, MenuItem{
+			Label:   op.label,
+			Mutates: true,
 			Action: func() tea.Cmd {
-				return func() tea.Msg {
-					// Run in background
-					go func() {
-						for _, child := range children {
-							if child.Container != nil && child.Container.State == "running" {
-								m.dockerClient.StopContainer(child.Container.ID)
-							}
-						}
-					}()
-					// Immediately refresh to show operation started
-					return m.refreshContainers()()
-				}
+				return m.projectAction(project, children, op.op)
 			},
+		})
+	}
+
+	items = append(items, MenuItem{
+		Label:   "Rolling Restart",
+		Mutates: true,
+		Action: func() tea.Cmd {
+			return m.rollingRestart(project, children)
 		},
-		{
-			Label: "Down (stop & remove, keeps volumes)",
+	})
+	if isComposeProject(project, children) {
+		items = append(items, MenuItem{
+			Label:   "Start All in Dependency Order",
+			Mutates: true,
 			Action: func() tea.Cmd {
-				return func() tea.Msg {
-					// Run in background
-					go func() {
-						for _, child := range children {
-							if child.Container != nil {
-								// Stop and remove containers (volumes are preserved)
-								m.dockerClient.RemoveContainer(child.Container.ID)
-							}
-						}
-					}()
-					// Immediately refresh to show operation started
-					return m.refreshContainers()()
-				}
+				return m.startInOrder(project)
 			},
-		},
-		{
-			Label: "Start All",
+		})
+	}
+
+	if compose, ok := composeProjectOf(project, children); ok && project != model.FavoritesProject {
+		items = append(items, MenuItem{
+			Label:   "Edit compose file & redeploy",
+			Mutates: true,
 			Action: func() tea.Cmd {
-				return func() tea.Msg {
-					// Run in background
-					go func() {
-						for _, child := range children {
-							if child.Container != nil && child.Container.State != "running" {
-								m.dockerClient.StartContainer(child.Container.ID)
-							}
-						}
-					}()
-					// Immediately refresh to show operation started
-					return m.refreshContainers()()
-				}
+				return m.editComposeFile(compose)
 			},
-		},
+		})
 	}
+	return items
 }
 
 func (m *Model) getContainerMenuItems(node *model.TreeNode) []MenuItem {
@@ -385,62 +1092,80 @@ func (m *Model) getContainerMenuItems(node *model.TreeNode) []MenuItem {
 
 	// Capture container ID to avoid closure issues
 	containerID := container.ID
+	containerName := container.Name
 	containerState := container.State
 
 	items := []MenuItem{}
 
 	if containerState == "running" {
 		items = append(items, MenuItem{
-			Label: "Restart",
+			Label:   "Restart",
+			Mutates: true,
 			Action: func() tea.Cmd {
-				return func() tea.Msg {
-					// Run in background
-					go func() {
-						m.dockerClient.RestartContainer(containerID)
-					}()
-					// Immediately refresh to show operation started
-					return m.refreshContainers()()
-				}
+				return m.containerAction(containerID, containerName, "restart", "restarting", func() error {
+					return m.dockerClient.RestartContainer(containerID)
+				})
 			},
 		})
 		items = append(items, MenuItem{
-			Label: "Stop",
+			Label:   "Stop",
+			Mutates: true,
+			Action: func() tea.Cmd {
+				return m.stopContainer(containerID, containerName)
+			},
+		})
+		items = append(items, MenuItem{
+			Label:   "Stop (custom timeout)…",
+			Mutates: true,
+			Action: func() tea.Cmd {
+				return showForm(m.stopForm(containerID, containerName))
+			},
+		})
+		items = append(items, MenuItem{
+			Label: "Processes",
 			Action: func() tea.Cmd {
 				return func() tea.Msg {
-					// Run in background
-					go func() {
-						m.dockerClient.StopContainer(containerID)
-					}()
-					// Immediately refresh to show operation started
-					return m.refreshContainers()()
+					return openProcessesMsg{containerID: containerID, name: containerName}
 				}
 			},
 		})
 		items = append(items, MenuItem{
-			Label: "Remove (keeps volumes)",
+			Label: "Connections",
 			Action: func() tea.Cmd {
 				return func() tea.Msg {
-					// Run in background
-					go func() {
-						m.dockerClient.RemoveContainer(containerID)
-					}()
-					// Immediately refresh to show operation started
-					return m.refreshContainers()()
+					return openConnectionsMsg{containerID: containerID, name: containerName}
 				}
 			},
 		})
+		items = append(items, MenuItem{
+			Label:   "Send signal…",
+			Mutates: true,
+			Action: func() tea.Cmd {
+				return m.signalMenu(containerID, containerName)
+			},
+		})
+		items = append(items, MenuItem{
+			Label:   "Attach",
+			Mutates: true,
+			Action: func() tea.Cmd {
+				return m.attachContainer(containerID, container.Name)
+			},
+		})
+		items = append(items, MenuItem{
+			Label:   "Remove (keeps volumes)",
+			Mutates: true,
+			Action: func() tea.Cmd {
+				return m.removeContainer(containerID, containerName)
+			},
+		})
 	} else {
 		items = append(items, MenuItem{
-			Label: "Start",
+			Label:   "Start",
+			Mutates: true,
 			Action: func() tea.Cmd {
-				return func() tea.Msg {
-					// Run in background
-					go func() {
-						m.dockerClient.StartContainer(containerID)
-					}()
-					// Immediately refresh to show operation started
-					return m.refreshContainers()()
-				}
+				return m.containerAction(containerID, containerName, "start", "starting", func() error {
+					return m.dockerClient.StartContainer(containerID)
+				})
 			},
 		})
 	}
@@ -449,18 +1174,107 @@ func (m *Model) getContainerMenuItems(node *model.TreeNode) []MenuItem {
 		Label: "Logs",
 		Action: func() tea.Cmd {
 			return func() tea.Msg {
-				logs, err := m.dockerClient.GetContainerLogs(containerID, 1000)
+				var buf bytes.Buffer
+				err := m.dockerClient.StreamLogs(containerID, docker.LogOptions{Tail: 1000, Timestamps: true}, &buf, &buf)
 				if err != nil {
 					return errMsg{err}
 				}
+				logs, times := splitLogTimestamps(buf.String())
 				return logsMsg{
+					containerID:   containerID,
 					containerName: container.Name,
 					content:       logs,
+					times:         times,
 				}
 			}
 		},
 	})
 
+	// The list API reports health in the status, e.g. "Up 5 minutes (healthy)"
+	if strings.Contains(container.Status, "health") {
+		items = append(items, MenuItem{
+			Label: "Run healthcheck",
+			Action: func() tea.Cmd {
+				return m.runHealthcheck(containerID, container.Name)
+			},
+		})
+	}
+
+	items = append(items, MenuItem{
+		Label: "Details",
+		Action: func() tea.Cmd {
+			return showDetail(containerID)
+		},
+	})
+	items = append(items, MenuItem{
+		Label:   "Checkpoints…",
+		Mutates: true,
+		Action: func() tea.Cmd {
+			return m.checkpointMenu(containerID, containerName, containerState == "running")
+		},
+	})
+	items = append(items, MenuItem{
+		Label:   "Resource limits…",
+		Mutates: true,
+		Action: func() tea.Cmd {
+			return m.openLimitsForm(containerID, containerName)
+		},
+	})
+	items = append(items, MenuItem{
+		Label:   "Cycle restart policy",
+		Mutates: true,
+		Action: func() tea.Cmd {
+			return m.cycleRestartPolicy(containerID, containerName)
+		},
+	})
+	items = append(items, MenuItem{
+		Label: "Security",
+		Action: func() tea.Cmd {
+			return m.showSecurity(containerID, containerName)
+		},
+	})
+	items = append(items, MenuItem{
+		Label: "Labels",
+		Action: func() tea.Cmd {
+			return showLabels(container)
+		},
+	})
+	items = append(items, MenuItem{
+		Label: "Export filesystem…",
+		Action: func() tea.Cmd {
+			return showForm(m.exportForm(containerID, container.Name))
+		},
+	})
+	items = append(items, MenuItem{
+		Label:   "Build image…",
+		Mutates: true,
+		Action: func() tea.Cmd {
+			return func() tea.Msg {
+				return openBuildFormMsg{image: container.Image, contextDir: container.Labels[composeWorkingDirLabel]}
+			}
+		},
+	})
+	items = append(items, MenuItem{
+		Label:   "Pull image",
+		Mutates: true,
+		Action: func() tea.Cmd {
+			return m.pullImage(container.Image)
+		},
+	})
+	items = append(items, MenuItem{
+		Label:   "Tag & push image…",
+		Mutates: true,
+		Action: func() tea.Cmd {
+			return showForm(m.pushForm(container.Image))
+		},
+	})
+	items = append(items, MenuItem{
+		Label: "Save image…",
+		Action: func() tea.Cmd {
+			return showForm(m.saveImageForm(container.Image))
+		},
+	})
+
 	// TODO: Add inspect when implemented
 	// items = append(items, MenuItem{
 	// 	Label:  "Inspect",
@@ -470,8 +1284,38 @@ func (m *Model) getContainerMenuItems(node *model.TreeNode) []MenuItem {
 	return items
 }
 
-func (m Model) View() string {
-	return m.renderView()
+// View renders the screen, reusing the last frame while nothing changed, or the error
+// screen after a panic
+func (m Model) View() (view string) {
+	if m.panicked.value != nil {
+		return m.renderPanic()
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			m.panicked.record("render", r)
+			m.frame.invalidate()
+			view = m.renderPanic()
+		}
+	}()
+
+	if !m.frame.valid {
+		start := time.Now()
+		m.frame.view = m.renderView()
+		if m.showMetrics && m.viewMode == ViewModeMain {
+			m.frame.view = overlay(m.frame.view, m.renderMetrics(), m.width, 2)
+		}
+		m.frame.valid = true
+		m.metrics.recordRender(time.Since(start))
+		if debuglog.Enabled() {
+			debuglog.Debug("render", "view", int(m.viewMode), "duration", time.Since(start))
+		}
+	} else {
+		m.metrics.recordReuse()
+	}
+	if m.bell {
+		return "\a" + m.frame.view
+	}
+	return m.frame.view
 }
 
 // adjustViewport ensures the selected item is visible in the viewport
@@ -480,13 +1324,7 @@ func (m *Model) adjustViewport() {
 		return
 	}
 
-	// Calculate visible height (total - title/header - footer)
-	// Title + blank line = 2, Header = 1, Footer + blank = 2, Total overhead = 5
-	visibleHeight := m.height - 5
-	if visibleHeight < 1 {
-		visibleHeight = 1
-	}
-
+	visibleHeight := m.treeHeight()
 	selected := m.tree.Selected
 
 	// Scroll down if selected is below viewport
@@ -504,4 +1342,3 @@ func (m *Model) adjustViewport() {
 		m.viewportTop = 0
 	}
 }
-
@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ekinertac/dtop/docker"
+)
+
+// networkInfoMsg carries a container's networks and DNS settings for the detail view
+type networkInfoMsg struct {
+	containerID string
+	info        *docker.NetworkInfo
+	err         error
+}
+
+// fetchNetworkInfo looks up the networks shown in the detail view
+func (m Model) fetchNetworkInfo(containerID string) tea.Cmd {
+	return func() tea.Msg {
+		info, err := m.dockerClient.GetNetworkInfo(containerID)
+		return networkInfoMsg{containerID: containerID, info: info, err: err}
+	}
+}
+
+func (m Model) handleNetworkInfo(msg networkInfoMsg) (tea.Model, tea.Cmd) {
+	if msg.containerID == m.detailID {
+		m.detailNetwork = msg.info
+		m.detailNetErr = msg.err
+	}
+	return m, nil
+}
+
+// copyIP puts the detail view's container IP on the clipboard. It uses OSC 52, so
+// it works over SSH in terminals that support it.
+func (m *Model) copyIP() {
+	ip := ""
+	if m.detailNetwork != nil {
+		ip = m.detailNetwork.PrimaryIP()
+	}
+	if ip == "" {
+		m.status = "No IP address to copy"
+		return
+	}
+	os.Stdout.WriteString(ansi.SetSystemClipboard(ip))
+	m.status = "Copied " + ip + " to the clipboard"
+}
+
+// networkDetailLines shows every attached network and the DNS configuration
+func (m Model) networkDetailLines() []string {
+	switch {
+	case m.replay != nil:
+		return detailSection("Networks", [][2]string{{"Networks", "unknown (replay)"}})
+	case m.detailNetErr != nil:
+		return detailSection("Networks", [][2]string{{"Networks", "unknown (" + m.detailNetErr.Error() + ")"}})
+	case m.detailNetwork == nil:
+		return detailSection("Networks", [][2]string{{"Networks", "loading…"}})
+	}
+
+	info := m.detailNetwork
+	lines := []string{}
+	if len(info.Networks) == 0 {
+		lines = append(lines, detailSection("Networks", [][2]string{{"Mode", orNone(info.Mode)}})...)
+	}
+	for _, network := range info.Networks {
+		rows := [][2]string{}
+		if network.IPAddress != "" {
+			rows = append(rows, [2]string{"IP", fmt.Sprintf("%s/%d", network.IPAddress, network.PrefixLen)})
+		}
+		if network.Gateway != "" {
+			rows = append(rows, [2]string{"Gateway", network.Gateway})
+		}
+		if network.IPv6Address != "" {
+			rows = append(rows, [2]string{"IPv6", fmt.Sprintf("%s/%d", network.IPv6Address, network.IPv6Prefix)})
+		}
+		if network.IPv6Gateway != "" {
+			rows = append(rows, [2]string{"IPv6 gateway", network.IPv6Gateway})
+		}
+		rows = append(rows,
+			[2]string{"MAC", orNone(network.MacAddress)},
+			[2]string{"Aliases", orNone(strings.Join(network.Aliases, ", "))},
+		)
+		if len(network.DNSNames) > 0 {
+			rows = append(rows, [2]string{"DNS names", strings.Join(network.DNSNames, ", ")})
+		}
+		lines = append(lines, detailSection("Network: "+network.Name, rows)...)
+	}
+
+	nameservers := strings.Join(info.DNS, ", ")
+	switch {
+	case info.EmbeddedDNS() && nameservers != "":
+		nameservers = docker.EmbeddedDNSServer + " (embedded, forwarding to " + nameservers + ")"
+	case info.EmbeddedDNS():
+		nameservers = docker.EmbeddedDNSServer + " (embedded)"
+	case nameservers == "":
+		nameservers = "daemon default"
+	}
+	hostname := info.Hostname
+	if info.Domainname != "" {
+		hostname += "." + info.Domainname
+	}
+	lines = append(lines, detailSection("DNS", [][2]string{
+		{"Hostname", orNone(hostname)},
+		{"Nameservers", nameservers},
+		{"Search", orNone(strings.Join(info.DNSSearch, ", "))},
+		{"Options", orNone(strings.Join(info.DNSOptions, ", "))},
+		{"Extra hosts", orNone(strings.Join(info.ExtraHosts, ", "))},
+	})...)
+	return lines
+}
+
+// orNone shows empty values as "none"
+func orNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
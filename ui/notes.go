@@ -0,0 +1,84 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/docker"
+	"github.com/ekinertac/dtop/model"
+)
+
+// maxNoteLength keeps notes short enough to read at a glance
+const maxNoteLength = 200
+
+// noteMarker follows the name of containers and projects that have a note
+const noteMarker = " ✎"
+
+// noteMsg sets the note of a container or project; an empty text removes it
+type noteMsg struct {
+	key  string // model.FavoriteKey of the node
+	name string
+	text string
+}
+
+// editNote opens a form to write the note of the selected container or project.
+// Notes are keyed by name, like favorites, so they survive containers being recreated.
+func (m *Model) editNote() {
+	node := m.tree.GetSelected()
+	if node == nil {
+		return
+	}
+	if node.Container == nil && node.Name == model.FavoritesProject {
+		m.status = "Select a container or project to annotate"
+		return
+	}
+	key, name := model.FavoriteKey(node), node.Name
+	if node.Container != nil {
+		name = node.Container.Name
+	}
+
+	fields := []formField{
+		{Label: "Note", Value: m.notes[key], Placeholder: "e.g. don't restart during business hours (empty removes it)"},
+	}
+	m.openForm(newForm("Note for "+name, fields, func(values []string) tea.Cmd {
+		return func() tea.Msg {
+			return noteMsg{key: key, name: name, text: values[0]}
+		}
+	}))
+}
+
+func (m Model) handleNote(msg noteMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case len([]rune(msg.text)) > maxNoteLength:
+		m.status = "Note too long (keep it under 200 characters)"
+		return m, nil
+	case msg.text == "":
+		delete(m.notes, msg.key)
+		m.status = "Removed note of " + msg.name
+	default:
+		m.notes[msg.key] = msg.text
+		m.status = "Saved note of " + msg.name
+	}
+	m.saveState()
+	return m, nil
+}
+
+// noteOf returns the note of a tree node, or "" if it has none
+func (m Model) noteOf(node *model.TreeNode) string {
+	return m.notes[model.FavoriteKey(node)]
+}
+
+// noteDetailLines shows the notes of a container and its project at the top of the
+// detail view; none when neither has one
+func (m Model) noteDetailLines(c *docker.ContainerInfo) []string {
+	rows := [][2]string{}
+	if note := m.notes[model.FavoriteKey(&model.TreeNode{Container: c})]; note != "" {
+		rows = append(rows, [2]string{"Container", statusStyle.Render(note)})
+	}
+	project := &model.TreeNode{Type: model.NodeTypeProject, Name: model.ProjectName(c, m.treeOptions())}
+	if note := m.notes[model.FavoriteKey(project)]; note != "" {
+		rows = append(rows, [2]string{"Project", statusStyle.Render(note)})
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return detailSection("Notes", rows)
+}
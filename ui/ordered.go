@@ -0,0 +1,282 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/debuglog"
+	"github.com/ekinertac/dtop/docker"
+	"github.com/ekinertac/dtop/model"
+)
+
+// orderedStartTimeout bounds the wait for a service to become ready for the
+// services depending on it
+const orderedStartTimeout = 2 * time.Minute
+
+// startService is a compose service of a project started in dependency order
+type startService struct {
+	name       string
+	containers []*docker.ContainerInfo
+	deps       map[string]string // Services it depends on -> condition it waits for
+	wait       string            // Strongest condition a dependent waits for; "" when none does
+}
+
+// conditionRank orders the depends_on conditions from weakest to strongest
+var conditionRank = map[string]int{
+	docker.ConditionStarted:   1,
+	docker.ConditionHealthy:   2,
+	docker.ConditionCompleted: 3,
+}
+
+// conditionWords describe what is awaited, e.g. "waiting for db healthy"
+var conditionWords = map[string]string{
+	docker.ConditionStarted:   "running",
+	docker.ConditionHealthy:   "healthy",
+	docker.ConditionCompleted: "completed",
+}
+
+// parseDependsOn reads the depends_on label compose sets, "service:condition:restart"
+// entries separated by commas, into service -> condition
+func parseDependsOn(label string) map[string]string {
+	deps := make(map[string]string)
+	for _, entry := range strings.Split(label, ",") {
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		if parts[0] == "" {
+			continue
+		}
+		condition := docker.ConditionStarted
+		if len(parts) > 1 && conditionRank[parts[1]] > 0 {
+			condition = parts[1]
+		}
+		deps[parts[0]] = condition
+	}
+	return deps
+}
+
+// orderedStartMsg delivers a project's containers, running or not, to start in
+// dependency order
+type orderedStartMsg struct {
+	project    string
+	containers []docker.ContainerInfo
+	err        error
+}
+
+// isComposeProject reports whether a tree project is a compose project, as opposed
+// to e.g. favorites or a grouping by label
+func isComposeProject(project string, children []*model.TreeNode) bool {
+	for _, child := range children {
+		if c := child.Container; c != nil && c.Labels[model.ComposeProjectLabel] == project {
+			return true
+		}
+	}
+	return false
+}
+
+// startStages groups a project's containers by compose service into stages that
+// start together, each depending only on services in earlier stages. Dependencies
+// on services without containers here are ignored.
+func startStages(containers []*docker.ContainerInfo) ([][]*startService, error) {
+	services := make(map[string]*startService)
+	for _, c := range containers {
+		if !actionable(c) {
+			continue
+		}
+		name := c.Labels[model.ComposeServiceLabel]
+		if name == "" {
+			name = c.Name
+		}
+		s, ok := services[name]
+		if !ok {
+			s = &startService{name: name, deps: parseDependsOn(c.Labels[composeDependsOnLabel])}
+			services[name] = s
+		}
+		s.containers = append(s.containers, c)
+	}
+	for _, s := range services {
+		for dep, condition := range s.deps {
+			target, ok := services[dep]
+			if !ok || dep == s.name {
+				delete(s.deps, dep)
+				continue
+			}
+			if conditionRank[condition] > conditionRank[target.wait] {
+				target.wait = condition
+			}
+		}
+	}
+
+	stages := [][]*startService{}
+	placed := make(map[string]bool)
+	for len(placed) < len(services) {
+		stage := []*startService{}
+		for name, s := range services {
+			if placed[name] {
+				continue
+			}
+			ready := true
+			for dep := range s.deps {
+				if !placed[dep] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				stage = append(stage, s)
+			}
+		}
+		if len(stage) == 0 {
+			cycle := []string{}
+			for name := range services {
+				if !placed[name] {
+					cycle = append(cycle, name)
+				}
+			}
+			sort.Strings(cycle)
+			return nil, fmt.Errorf("dependency cycle between %s", strings.Join(cycle, ", "))
+		}
+		sort.Slice(stage, func(i, j int) bool { return stage[i].name < stage[j].name })
+		for _, s := range stage {
+			placed[s.name] = true
+		}
+		stages = append(stages, stage)
+	}
+	return stages, nil
+}
+
+// startInOrder lists a project's containers, which the tree only has while they
+// run, to start the stopped ones in dependency order
+func (m Model) startInOrder(project string) tea.Cmd {
+	client := m.dockerClient
+	return func() tea.Msg {
+		all, err := client.ListAllContainers()
+		if err != nil {
+			return orderedStartMsg{project: project, err: err}
+		}
+		containers := []docker.ContainerInfo{}
+		for _, c := range all {
+			if c.Labels[model.ComposeProjectLabel] == project {
+				containers = append(containers, c)
+			}
+		}
+		return orderedStartMsg{project: project, containers: containers}
+	}
+}
+
+func (m Model) handleOrderedStart(msg orderedStartMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		debuglog.Error("list project containers", msg.err, "project", msg.project)
+		m.status = fmt.Sprintf("Can't start %s in order: %v", msg.project, msg.err)
+		return m, nil
+	}
+	containers := make([]*docker.ContainerInfo, len(msg.containers))
+	for i := range msg.containers {
+		containers[i] = &msg.containers[i]
+	}
+	return m, m.startStaged(msg.project, containers)
+}
+
+// startStaged starts a project's stopped containers like compose up does: a stage
+// of services at a time, waiting before each stage until the services it depends on
+// are running, healthy or completed as its depends_on asks. Services whose
+// dependencies fail aren't started.
+func (m *Model) startStaged(project string, containers []*docker.ContainerInfo) tea.Cmd {
+	stages, err := startStages(containers)
+	if err != nil {
+		return func() tea.Msg { return statusMsg(fmt.Sprintf("Can't start %s in order: %v", project, err)) }
+	}
+	targets := []string{}
+	for _, stage := range stages {
+		for _, s := range stage {
+			for _, c := range s.containers {
+				if startOp.filter(c) {
+					targets = append(targets, c.ID)
+				}
+			}
+		}
+	}
+
+	client, audit, actions := m.dockerClient, m.audit, m.actions
+	return m.projectSequence(project, startOp, targets, func(step func(string, error), wait func(string)) {
+		failed := make(map[string]bool)
+		for _, stage := range stages {
+			errs := make(map[string]error) // Container ID -> why it didn't start or become ready
+			var mu sync.Mutex
+			var wg sync.WaitGroup
+			for _, s := range stage {
+				for dep := range s.deps {
+					if failed[dep] {
+						failed[s.name] = true
+						for _, c := range s.containers {
+							errs[c.ID] = fmt.Errorf("skipped: %s failed", dep)
+						}
+						break
+					}
+				}
+				if failed[s.name] {
+					continue
+				}
+				for _, c := range s.containers {
+					if !startOp.filter(c) {
+						continue
+					}
+					wg.Add(1)
+					go func(id string) {
+						defer wg.Done()
+						err := errors.New("already starting")
+						done, ok := actions.enqueue(id, startOp.verb, "starting", func() error {
+							return client.StartContainer(id)
+						})
+						if ok {
+							err = <-done
+						}
+						mu.Lock()
+						errs[id] = err
+						mu.Unlock()
+					}(c.ID)
+				}
+			}
+			wg.Wait()
+
+			// Only what later stages depend on is waited for
+			for _, s := range stage {
+				if s.wait == "" || failed[s.name] {
+					continue
+				}
+				wait(s.name + " " + conditionWords[s.wait])
+				for _, c := range s.containers {
+					if errs[c.ID] != nil {
+						continue
+					}
+					err := client.WaitReady(c.ID, s.wait, orderedStartTimeout)
+					if errors.Is(err, docker.ErrNoHealthcheck) {
+						// Without a healthcheck, running has to do
+						err = client.WaitReady(c.ID, docker.ConditionStarted, orderedStartTimeout)
+					}
+					if err != nil {
+						errs[c.ID] = fmt.Errorf("not %s: %w", conditionWords[s.wait], err)
+					}
+				}
+				wait("")
+			}
+
+			for _, s := range stage {
+				for _, c := range s.containers {
+					err := errs[c.ID]
+					if err != nil {
+						failed[s.name] = true
+					}
+					if !startOp.filter(c) {
+						continue
+					}
+					audit.Record(startOp.verb, c.Name, err)
+					step(c.ID, err)
+				}
+			}
+		}
+	})
+}
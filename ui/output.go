@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"encoding/csv"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// outputMsg shows the result of a one-off command in a scrollable view
+type outputMsg struct {
+	title string
+	lines []string
+	back  ViewMode // View to return to; the main view by default
+
+	// table, if set, is the data behind lines, which w saves as CSV to a file
+	// named like tableFile
+	table     [][]string
+	tableFile string
+	saved     string // Result of the last save, shown above the help
+}
+
+// csvSavedMsg reports the result of saving an output table
+type csvSavedMsg struct {
+	path string
+	err  error
+}
+
+// openOutput shows lines in the output view
+func (m *Model) openOutput(msg outputMsg) {
+	m.output = &msg
+	m.pagerScroll = 0
+	m.viewMode = ViewModeOutput
+}
+
+func (m Model) handleOutputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.scrollPager(msg.String()) {
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc", "q":
+		m.viewMode = m.output.back
+		m.output = nil
+	case "w":
+		if m.output.table != nil {
+			f := saveCSVForm(m.output.table, m.output.tableFile)
+			f.back = ViewModeOutput
+			m.openForm(f)
+		}
+	}
+	return m, nil
+}
+
+// saveCSVForm prompts for a path and writes table to it as CSV
+func saveCSVForm(table [][]string, defaultPath string) *form {
+	fields := []formField{
+		{Label: "Path", Value: defaultPath},
+	}
+	return newForm("Save as CSV", fields, func(values []string) tea.Cmd {
+		path := values[0]
+		if path == "" {
+			return nil
+		}
+		return func() tea.Msg {
+			return csvSavedMsg{path: path, err: writeCSV(path, table)}
+		}
+	})
+}
+
+func (m Model) handleCSVSaved(msg csvSavedMsg) (tea.Model, tea.Cmd) {
+	result := "Saved " + msg.path
+	if msg.err != nil {
+		result = "Saving " + msg.path + " failed: " + msg.err.Error()
+	}
+	m.status = result
+	if m.output != nil {
+		output := *m.output
+		output.saved = result
+		m.output = &output
+	}
+	return m, nil
+}
+
+// writeCSV writes rows to a new file at path
+func writeCSV(path string, rows [][]string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(f)
+	w.WriteAll(rows)
+	if err := w.Error(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (m Model) renderOutput() string {
+	if m.output == nil {
+		return ""
+	}
+	help := "↑↓:scroll  q/esc:back"
+	if m.output.table != nil {
+		help = "↑↓:scroll  w:save CSV  q/esc:back"
+	}
+	if m.output.saved != "" {
+		help = m.output.saved + "  " + help
+	}
+	return m.renderPager(m.output.title, m.output.lines, help)
+}
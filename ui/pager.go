@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/ekinertac/dtop/i18n"
+)
+
+// scrollPager handles the scroll keys shared by pager-style views.
+// It returns false for keys it doesn't handle.
+func (m *Model) scrollPager(key string) bool {
+	switch key {
+	case "up", "k":
+		if m.pagerScroll > 0 {
+			m.pagerScroll--
+		}
+	case "down", "j":
+		m.pagerScroll++
+	case "pgup":
+		m.pagerScroll -= m.height - 5
+		if m.pagerScroll < 0 {
+			m.pagerScroll = 0
+		}
+	case "pgdown":
+		m.pagerScroll += m.height - 5
+	case "home", "g":
+		m.pagerScroll = 0
+	default:
+		return false
+	}
+	return true
+}
+
+// renderPager renders a title, a scrollable window of lines and a help footer
+func (m Model) renderPager(title string, lines []string, help string) string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	// Calculate visible height
+	visibleHeight := m.height - 4 // Title + blank + footer + blank
+	if visibleHeight < 1 {
+		visibleHeight = 1
+	}
+
+	// Clamp scroll position
+	scroll := m.pagerScroll
+	if maxScroll := len(lines) - visibleHeight; scroll > maxScroll {
+		scroll = maxScroll
+	}
+	if scroll < 0 {
+		scroll = 0
+	}
+
+	end := scroll + visibleHeight
+	if end > len(lines) {
+		end = len(lines)
+	}
+	for i := scroll; i < end; i++ {
+		b.WriteString(lines[i])
+		b.WriteString("\n")
+	}
+
+	// Fill remaining space
+	for i := end - scroll; i < visibleHeight; i++ {
+		b.WriteString("\n")
+	}
+
+	b.WriteString(helpStyle.Render(i18n.Help(help)))
+
+	return b.String()
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/docker"
+)
+
+// restartPolicyMsg carries a container's restart policy, after a lookup or a change
+type restartPolicyMsg struct {
+	containerID string
+	name        string
+	policy      docker.RestartPolicy
+	changed     bool
+	err         error
+}
+
+// fetchRestartPolicy looks up the restart policy shown in the detail view
+func (m Model) fetchRestartPolicy(containerID string) tea.Cmd {
+	return func() tea.Msg {
+		policy, err := m.dockerClient.GetRestartPolicy(containerID)
+		return restartPolicyMsg{containerID: containerID, policy: policy, err: err}
+	}
+}
+
+// cycleRestartPolicy moves the container to the next restart policy
+// (no → on-failure → unless-stopped → always)
+func (m Model) cycleRestartPolicy(containerID, name string) tea.Cmd {
+	return func() tea.Msg {
+		current, err := m.dockerClient.GetRestartPolicy(containerID)
+		if err != nil {
+			return restartPolicyMsg{containerID: containerID, name: name, changed: true, err: err}
+		}
+		next := docker.RestartPolicy{Name: docker.NextRestartPolicy(current.Name)}
+		err = m.dockerClient.SetRestartPolicy(containerID, next.Name)
+		m.audit.Record("restart policy "+next.Name, name, err)
+		if err != nil {
+			next = current
+		}
+		return restartPolicyMsg{containerID: containerID, name: name, policy: next, changed: true, err: err}
+	}
+}
+
+// handleRestartPolicy updates the detail view and reports changes in the status bar
+func (m Model) handleRestartPolicy(msg restartPolicyMsg) (tea.Model, tea.Cmd) {
+	if msg.containerID == m.detailID {
+		m.detailPolicy = msg.policy.String()
+		if msg.err != nil && !msg.changed {
+			m.detailPolicy = "unknown (" + msg.err.Error() + ")"
+		}
+	}
+	if msg.changed {
+		if msg.err != nil {
+			m.status = "Restart policy of " + msg.name + ": " + msg.err.Error()
+		} else {
+			m.status = msg.name + ": restart policy " + msg.policy.String()
+		}
+	}
+	return m, nil
+}
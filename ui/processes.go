@@ -0,0 +1,183 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/docker"
+)
+
+// processFirstDelay is how soon the second sample is taken after opening the view,
+// so CPU rates show up without waiting for the next tick
+const processFirstDelay = time.Second
+
+// processes is the state of the processes view
+type processes struct {
+	containerID string
+	name        string
+	sample      *docker.ProcessSample // Latest sample
+	previous    *docker.ProcessSample // Sample before it, for CPU rates; nil until the second sample
+	err         error
+	byMemory    bool // Sort by resident memory instead of CPU
+}
+
+// openProcessesMsg asks the model to show the processes view; used by menu actions
+type openProcessesMsg struct {
+	containerID string
+	name        string
+}
+
+// processesMsg carries a fresh /proc sample for the processes view
+type processesMsg struct {
+	containerID string
+	sample      *docker.ProcessSample
+	err         error
+}
+
+// fetchProcesses samples the container's processes in the background after delay
+func (m Model) fetchProcesses(containerID string, delay time.Duration) tea.Cmd {
+	client := m.dockerClient
+	return func() tea.Msg {
+		time.Sleep(delay)
+		sample, err := client.SampleProcesses(containerID)
+		return processesMsg{containerID: containerID, sample: sample, err: err}
+	}
+}
+
+// openProcesses shows the processes view and takes the first sample; it is
+// refreshed on every tick while the view is open
+func (m *Model) openProcesses(containerID, name string) tea.Cmd {
+	m.processes = &processes{containerID: containerID, name: name}
+	m.pagerScroll = 0
+	m.viewMode = ViewModeProcesses
+	return m.fetchProcesses(containerID, 0)
+}
+
+func (m Model) handleProcesses(msg processesMsg) (tea.Model, tea.Cmd) {
+	p := m.processes
+	if p == nil || p.containerID != msg.containerID {
+		return m, nil
+	}
+
+	// Copy so the previous model value keeps its own state
+	next := *p
+	m.processes = &next
+	if msg.err != nil {
+		next.err = msg.err
+		return m, nil
+	}
+	next.err = nil
+	next.previous = p.sample
+	next.sample = msg.sample
+	if next.previous == nil && m.viewMode == ViewModeProcesses {
+		return m, m.fetchProcesses(msg.containerID, processFirstDelay)
+	}
+	return m, nil
+}
+
+func (m Model) handleProcessesKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.scrollPager(msg.String()) {
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc", "q":
+		m.viewMode = ViewModeMain
+		m.processes = nil
+	case "s":
+		next := *m.processes
+		next.byMemory = !next.byMemory
+		m.processes = &next
+	case "r":
+		return m, m.fetchProcesses(m.processes.containerID, 0)
+	}
+	return m, nil
+}
+
+// processRow is a process with its usage between the last two samples
+type processRow struct {
+	docker.ProcessStat
+	cpu    float64
+	hasCPU bool
+}
+
+func (m Model) renderProcesses() string {
+	p := m.processes
+	title := "dtop - Processes of " + p.name
+	help := "↑↓:scroll  s:sort by memory  r:refresh  q/esc:back"
+	if p.byMemory {
+		help = "↑↓:scroll  s:sort by CPU  r:refresh  q/esc:back"
+	}
+
+	lines := []string{}
+	switch {
+	case p.err != nil:
+		lines = append(lines, stoppedStyle.Render(fmt.Sprintf("Failed to read processes: %v", p.err)))
+	case p.sample == nil:
+		lines = append(lines, "Loading…")
+	}
+	if p.sample == nil {
+		return m.renderPager(title, lines, help)
+	}
+
+	rows := make([]processRow, 0, len(p.sample.Processes))
+	var totalCPU float64
+	var totalRSS uint64
+	for _, proc := range p.sample.Processes {
+		cpu, ok := p.sample.CPUPercent(p.previous, proc)
+		rows = append(rows, processRow{ProcessStat: proc, cpu: cpu, hasCPU: ok})
+		totalCPU += cpu
+		totalRSS += proc.RSS
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if !p.byMemory && rows[i].cpu != rows[j].cpu {
+			return rows[i].cpu > rows[j].cpu
+		}
+		if rows[i].RSS != rows[j].RSS {
+			return rows[i].RSS > rows[j].RSS
+		}
+		return rows[i].PID < rows[j].PID
+	})
+
+	// Memory share is relative to the container's limit (host memory when unlimited)
+	var limit uint64
+	if node := m.tree.FindContainer(p.containerID); node != nil && node.Container.Memory.Reported {
+		limit = node.Container.Memory.Limit
+	}
+
+	cpuSummary := "measuring…"
+	if p.previous != nil {
+		cpuSummary = fmt.Sprintf("%.1f%%", totalCPU)
+	}
+	lines = append(lines, headerStyle.Render(fmt.Sprintf("%d processes  CPU %s  RSS %s",
+		len(rows), cpuSummary, formatNetBytes(totalRSS))), "")
+
+	lines = append(lines, headerStyle.Render(fmt.Sprintf("%7s %7s %-2s %4s %7s %6s %8s  %s",
+		"PID", "PPID", "S", "THR", "CPU%", "MEM%", "RSS", "COMMAND")))
+	commandWidth := m.width - 50
+	if commandWidth < 20 {
+		commandWidth = 20
+	}
+	for _, row := range rows {
+		cpu := "…"
+		if row.hasCPU {
+			cpu = fmt.Sprintf("%.1f", row.cpu)
+		}
+		mem := "-"
+		if limit > 0 {
+			mem = fmt.Sprintf("%.1f", float64(row.RSS)/float64(limit)*100)
+		}
+		command := row.Command
+		if command == "" {
+			command = "[" + row.Name + "]"
+		}
+		line := fmt.Sprintf("%7d %7d %-2s %4d %7s %6s %8s  %s", row.PID, row.PPID, row.State, row.Threads,
+			cpu, mem, formatNetBytes(row.RSS), truncateOrPad(command, commandWidth))
+		lines = append(lines, strings.TrimRight(line, " "))
+	}
+
+	return m.renderPager(title, lines, help)
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/config"
+	"github.com/ekinertac/dtop/docker"
+	"github.com/ekinertac/dtop/hooks"
+	"github.com/ekinertac/dtop/model"
+)
+
+// ProfileSwitcher connects using the named config profile
+type ProfileSwitcher func(name string) (*docker.Client, *config.Config, error)
+
+// profileCloseDelay is how long the previous profile's client stays open after a
+// switch, so requests already in flight on it can finish
+const profileCloseDelay = 30 * time.Second
+
+// profileMsg carries the connection for a newly selected profile
+type profileMsg struct {
+	name   string
+	client *docker.Client
+	config *config.Config
+	err    error
+}
+
+// SetProfileSwitcher enables switching profiles at runtime
+func (m *Model) SetProfileSwitcher(connect ProfileSwitcher) {
+	m.connectProfile = connect
+}
+
+// openProfiles shows the configured profiles in the menu view
+func (m *Model) openProfiles() {
+	names := m.config.ProfileNames()
+	if m.connectProfile == nil || len(names) == 0 {
+		m.status = "No profiles configured"
+		return
+	}
+
+	connect := m.connectProfile
+	items := make([]MenuItem, 0, len(names))
+	selected := 0
+	for i, name := range names {
+		label := name
+		if name == m.config.Profile {
+			label += " (current)"
+			selected = i
+		}
+		items = append(items, MenuItem{
+			Label: label,
+			Action: func() tea.Cmd {
+				return func() tea.Msg {
+					client, cfg, err := connect(name)
+					return profileMsg{name: name, client: client, config: cfg, err: err}
+				}
+			},
+		})
+	}
+
+	m.openMenuWith("Switch profile", items)
+	m.menuSelected = selected
+}
+
+// handleProfile swaps in the new profile's connection and settings and starts over
+// with an empty tree
+func (m Model) handleProfile(msg profileMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.status = "Profile " + msg.name + ": " + msg.err.Error()
+		return m, nil
+	}
+
+	previous := m.dockerClient
+	previous.SetLogRates(false)
+	previous.SetStatsStreams(0)
+	go func() {
+		time.Sleep(profileCloseDelay)
+		previous.Close()
+	}()
+
+	m.dockerClient = msg.client
+	m.dockerClient.SetGPUStats(m.showGPU)
+	m.dockerClient.SetLogRates(m.showLogRate)
+	m.dockerClient.SetStatsStreams(msg.config.StatsStreams)
+	m.config = msg.config
+	m.readOnly = msg.config.ReadOnly
+	m.hooks = hooks.New(msg.config.Hooks, m.audit)
+	applyAccent(msg.config.Accent)
+
+	m.tree = &model.Tree{}
+	m.viewportTop = 0
+	m.marked = nil
+	m.projectMarks = make(map[string]bool)
+	m.history = make(map[string][]statsSample)
+	m.flash = make(map[string]time.Time)
+	m.hostInfo = nil
+	m.nodeFilter = ""
+	m.compose = make(map[string]composeCheck)
+	m.audit.Record("switch profile", msg.name, nil)
+	m.status = "Switched to profile " + msg.name
+	return m, tea.Batch(m.refreshContainersWithStats(false), m.fetchHostInfo())
+}
@@ -0,0 +1,203 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/model"
+)
+
+// projectOp tracks a project-wide action while it runs, for the progress shown on
+// the project row and its containers
+type projectOp struct {
+	op      batchOp
+	targets []string         // Container IDs the action applies to
+	results map[string]error // Finished containers and their result
+	waiting string           // What a sequenced action is waiting for, e.g. "db healthy"
+	ch      <-chan tea.Msg   // Progress of a sequenced action
+}
+
+// projectStepMsg reports that a container of a project-wide action finished
+type projectStepMsg struct {
+	project     string
+	containerID string
+	err         error
+}
+
+// projectWaitMsg reports what a sequenced project-wide action is waiting for
+type projectWaitMsg struct {
+	project string
+	waiting string
+}
+
+// waitForProject delivers the next update from a sequenced project-wide action
+func waitForProject(ch <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+// projectSequence runs a project-wide action whose containers don't all go at once:
+// run works through the targets in the background, reporting each finished one
+// with step and what it's waiting for next with wait
+func (m *Model) projectSequence(project string, op batchOp, targets []string, run func(step func(id string, err error), wait func(what string))) tea.Cmd {
+	if _, running := m.projectOps[project]; running {
+		return func() tea.Msg { return statusMsg(project + " is busy with another project action") }
+	}
+	if len(targets) == 0 {
+		return func() tea.Msg { return statusMsg("Nothing to " + op.verb + " in " + project) }
+	}
+
+	ch := make(chan tea.Msg, len(targets)*2+1)
+	m.projectOps[project] = &projectOp{op: op, targets: targets, results: make(map[string]error), ch: ch}
+	m.audit.Record(op.verb+" all", "project "+project, nil)
+	go func() {
+		defer close(ch)
+		run(func(id string, err error) {
+			ch <- projectStepMsg{project: project, containerID: id, err: err}
+		}, func(what string) {
+			ch <- projectWaitMsg{project: project, waiting: what}
+		})
+	}()
+
+	cmds := []tea.Cmd{waitForProject(ch), m.refreshContainers()}
+	if m.actions.claimTicker() {
+		cmds = append(cmds, actionTick())
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m Model) handleProjectWait(msg projectWaitMsg) (tea.Model, tea.Cmd) {
+	tracked, ok := m.projectOps[msg.project]
+	if !ok {
+		return m, nil
+	}
+	tracked.waiting = msg.waiting
+	return m, tea.Batch(waitForProject(tracked.ch), m.refreshContainers())
+}
+
+// projectAction queues the operation on each of a project's containers it applies
+// to and tracks its progress until every container is done
+func (m *Model) projectAction(project string, children []*model.TreeNode, op batchOp) tea.Cmd {
+	label := strings.ToLower(op.label)
+	if _, running := m.projectOps[project]; running {
+		return func() tea.Msg { return statusMsg(project + " is busy with another project action") }
+	}
+
+	tracked := &projectOp{op: op, results: make(map[string]error)}
+	cmds := []tea.Cmd{}
+	for _, child := range children {
+		c := child.Container
+		if c == nil || !actionable(c) || !op.filter(c) {
+			continue
+		}
+		id, name := c.ID, c.Name
+		tracked.targets = append(tracked.targets, id)
+		done, ok := m.actions.enqueue(id, op.verb, label, func() error {
+			return op.run(m.dockerClient, id)
+		})
+		if !ok {
+			tracked.results[id] = fmt.Errorf("already %s", label)
+			continue
+		}
+		audit := m.audit
+		cmds = append(cmds, func() tea.Msg {
+			err := <-done
+			audit.Record(op.verb, name, err)
+			return projectStepMsg{project: project, containerID: id, err: err}
+		})
+	}
+	if len(tracked.targets) == 0 {
+		return func() tea.Msg { return statusMsg("Nothing to " + op.verb + " in " + project) }
+	}
+	if len(cmds) == 0 {
+		return func() tea.Msg { return statusMsg(fmt.Sprintf("%s: already %s", project, label)) }
+	}
+
+	m.audit.Record(op.verb+" all", "project "+project, nil)
+	m.projectOps[project] = tracked
+	cmds = append(cmds, m.refreshContainers())
+	if m.actions.claimTicker() {
+		cmds = append(cmds, actionTick())
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m Model) handleProjectStep(msg projectStepMsg) (tea.Model, tea.Cmd) {
+	tracked, ok := m.projectOps[msg.project]
+	if !ok {
+		return m, nil
+	}
+	tracked.results[msg.containerID] = msg.err
+	if len(tracked.results) < len(tracked.targets) {
+		if tracked.ch != nil {
+			return m, tea.Batch(waitForProject(tracked.ch), m.refreshContainers())
+		}
+		return m, m.refreshContainers()
+	}
+
+	delete(m.projectOps, msg.project)
+	failed := 0
+	for _, err := range tracked.results {
+		if err != nil {
+			failed++
+		}
+	}
+	m.status = fmt.Sprintf("%s all %s: %d/%d containers done", tracked.op.title, msg.project,
+		len(tracked.targets)-failed, len(tracked.targets))
+	if failed > 0 {
+		m.status += fmt.Sprintf(", %d failed (see the audit log)", failed)
+	}
+	return m, m.refreshContainers()
+}
+
+// projectProgressText describes a running project-wide action for the project row,
+// e.g. "⠹ stopping 4/12"; empty when none is running
+func (m Model) projectProgressText(project string) string {
+	tracked, ok := m.projectOps[project]
+	if !ok {
+		return ""
+	}
+	failed := 0
+	for _, err := range tracked.results {
+		if err != nil {
+			failed++
+		}
+	}
+	frame := spinnerFrames[spinnerFrame()]
+	text := fmt.Sprintf("%s %s %d/%d", frame, strings.ToLower(tracked.op.label), len(tracked.results), len(tracked.targets))
+	if failed > 0 {
+		text += fmt.Sprintf(" (%d failed)", failed)
+	}
+	if tracked.waiting != "" {
+		text += ", waiting for " + tracked.waiting
+	}
+	return text
+}
+
+// projectStepMarker marks a container that is part of a running project-wide action:
+// "·" while pending, "✓" once done and "✗" if it failed; empty otherwise
+func (m Model) projectStepMarker(containerID string) string {
+	for _, tracked := range m.projectOps {
+		for _, id := range tracked.targets {
+			if id != containerID {
+				continue
+			}
+			err, finished := tracked.results[id]
+			switch {
+			case !finished:
+				return "·"
+			case err != nil:
+				return "✗"
+			default:
+				return "✓"
+			}
+		}
+	}
+	return ""
+}
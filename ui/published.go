@@ -0,0 +1,160 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/docker"
+)
+
+// publishedPort is a host port published by a container, with the other containers
+// wanting the same port
+type publishedPort struct {
+	docker.PortBinding
+	container string
+	state     string
+	conflicts []string // Containers binding the same address and port; only one can run
+	near      []string // Containers binding the same port on another interface
+}
+
+// findPortConflicts fills in the conflicts and near-conflicts between the ports of
+// different containers
+func findPortConflicts(ports []publishedPort) {
+	for i := range ports {
+		for j := range ports {
+			a, b := &ports[i], ports[j]
+			if i == j || a.container == b.container || a.HostPort != b.HostPort || a.Proto != b.Proto {
+				continue
+			}
+			list, entry := &a.near, b.container+" on "+b.Interface()
+			if a.Overlaps(b.PortBinding) {
+				list, entry = &a.conflicts, fmt.Sprintf("%s (%s)", b.container, b.state)
+			}
+			// A container binding both 0.0.0.0 and :: would be listed twice
+			if !slices.Contains(*list, entry) {
+				*list = append(*list, entry)
+			}
+		}
+	}
+}
+
+// openPortsReport looks up the published ports of every container in the background
+// and lists them by port number, highlighting conflicts
+func (m *Model) openPortsReport() tea.Cmd {
+	if m.replay != nil {
+		m.status = "Not available while replaying a recording"
+		return nil
+	}
+	containers := []*docker.ContainerInfo{}
+	seen := make(map[string]bool)
+	for _, c := range m.tree.Containers() {
+		// Pinned containers appear twice in the tree
+		if seen[c.ID] || isPlaceholder(c) || c.Remote {
+			continue
+		}
+		seen[c.ID] = true
+		containers = append(containers, c)
+	}
+	if len(containers) == 0 {
+		m.status = "No containers to report on"
+		return nil
+	}
+
+	m.status = fmt.Sprintf("Looking up the ports of %d containers…", len(containers))
+	client := m.dockerClient
+	return func() tea.Msg {
+		ports := []publishedPort{}
+		failed := 0
+		for _, c := range containers {
+			bindings, err := client.GetPortBindings(c.ID)
+			if err != nil {
+				failed++
+				continue
+			}
+			for _, b := range bindings {
+				ports = append(ports, publishedPort{PortBinding: b, container: c.Name, state: c.State})
+			}
+		}
+		findPortConflicts(ports)
+		sort.Slice(ports, func(i, j int) bool {
+			a, b := ports[i], ports[j]
+			if a.HostPort != b.HostPort {
+				return a.HostPort < b.HostPort
+			}
+			if a.Proto != b.Proto {
+				return a.Proto < b.Proto
+			}
+			if a.Interface() != b.Interface() {
+				return a.Interface() < b.Interface()
+			}
+			return a.container < b.container
+		})
+		return portsReport(ports, failed)
+	}
+}
+
+// portsReport renders the ports as a table, with the same data as CSV behind it
+func portsReport(ports []publishedPort, failed int) outputMsg {
+	lines := []string{
+		"",
+		"Host ports published by running containers and configured for stopped ones. Two containers conflict when they bind the same port on the same or an all-interfaces address: only one of them can run.",
+		"",
+		headerStyle.Render(fmt.Sprintf("%-6s %-5s %-16s %-30s %-8s %-6s %s",
+			"PORT", "PROTO", "INTERFACE", "CONTAINER", "STATE", "TARGET", "NOTE")),
+	}
+	table := [][]string{{"host_port", "protocol", "interface", "container", "state", "container_port", "conflicts", "same_port_elsewhere"}}
+
+	conflicts, near := 0, 0
+	for _, p := range ports {
+		note, style := "", containerStyle
+		switch {
+		case len(p.conflicts) > 0:
+			conflicts++
+			note, style = "conflicts with "+strings.Join(p.conflicts, ", "), stoppedStyle
+		case len(p.near) > 0:
+			near++
+			note, style = "also bound by "+strings.Join(p.near, ", "), statusStyle
+		}
+		lines = append(lines, style.Render(fmt.Sprintf("%-6d %-5s %-16s %s %-8s %-6d %s",
+			p.HostPort, p.Proto, p.Interface(), truncateOrPad(p.container, 30), p.state, p.ContainerPort, note)))
+
+		table = append(table, []string{
+			strconv.Itoa(p.HostPort),
+			p.Proto,
+			p.Interface(),
+			p.container,
+			p.state,
+			strconv.Itoa(p.ContainerPort),
+			strings.Join(p.conflicts, "; "),
+			strings.Join(p.near, "; "),
+		})
+	}
+	if len(ports) == 0 {
+		lines = append(lines, "No container publishes a host port")
+	}
+
+	summary := fmt.Sprintf("%d published ports: %d conflicting, %d shared with another interface", len(ports), conflicts, near)
+	if failed > 0 {
+		summary += fmt.Sprintf(" (%d containers couldn't be inspected)", failed)
+	}
+	switch {
+	case conflicts > 0:
+		lines[0] = stoppedStyle.Render(summary)
+	case near > 0:
+		lines[0] = statusStyle.Render(summary)
+	default:
+		lines[0] = runningStyle.Render(summary)
+	}
+
+	return outputMsg{
+		title:     "dtop - Published ports",
+		lines:     lines,
+		table:     table,
+		tableFile: "dtop-ports-" + time.Now().Format("20060102-1504") + ".csv",
+	}
+}
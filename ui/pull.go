@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/docker"
+	"github.com/ekinertac/dtop/i18n"
+)
+
+// pullMsg reports the state of an operation that pulls an image
+type pullMsg struct {
+	label    string
+	progress docker.PullProgress
+	started  bool // First message; opens the pull view
+	done     bool
+	err      error
+	ch       <-chan pullMsg
+}
+
+// waitForPull delivers the next update from a pull
+func waitForPull(ch <-chan pullMsg) tea.Cmd {
+	return func() tea.Msg {
+		return <-ch
+	}
+}
+
+// startPull runs op in the background and shows its pull progress in the pull view.
+// op receives the callback to pass to the docker client for progress updates.
+func (m *Model) startPull(label string, op func(progress func(docker.PullProgress)) error) tea.Cmd {
+	ch := make(chan pullMsg, 1)
+
+	go func() {
+		ch <- pullMsg{label: label, started: true, ch: ch}
+
+		var last docker.PullProgress
+		err := op(func(p docker.PullProgress) {
+			last = p
+			// Drop the update if the UI hasn't consumed the previous one yet
+			select {
+			case ch <- pullMsg{label: label, progress: p, ch: ch}:
+			default:
+			}
+		})
+		// Final message always gets through
+		ch <- pullMsg{label: label, progress: last, done: true, err: err, ch: ch}
+	}()
+
+	return waitForPull(ch)
+}
+
+func (m Model) handlePull(msg pullMsg) (tea.Model, tea.Cmd) {
+	if msg.started {
+		m.pull = &msg
+		m.viewMode = ViewModePull
+		return m, waitForPull(msg.ch)
+	}
+	m.pull = &msg
+
+	if msg.done {
+		if m.viewMode == ViewModePull {
+			m.viewMode = ViewModeMain
+		}
+		if msg.err != nil {
+			m.status = fmt.Sprintf("%s failed: %v", msg.label, msg.err)
+		} else {
+			m.status = msg.label + " done"
+		}
+		m.pull = nil
+		return m, m.refreshContainers()
+	}
+
+	// While the view is hidden, keep a summary in the status bar
+	if m.viewMode != ViewModePull {
+		m.status = fmt.Sprintf("%s… %s", msg.label, pullSummary(msg.progress))
+	}
+	return m, waitForPull(msg.ch)
+}
+
+// pullSummary describes overall pull or push progress, e.g. "3/7 layers"
+func pullSummary(p docker.PullProgress) string {
+	done := 0
+	for _, layer := range p.Layers {
+		switch layer.Status {
+		case "Pull complete", "Already exists", "Pushed", "Layer already exists":
+			done++
+		}
+	}
+	return fmt.Sprintf("%d/%d layers", done, len(p.Layers))
+}
+
+func (m Model) handlePullKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		// The pull keeps running; progress moves to the status bar
+		m.viewMode = ViewModeMain
+	}
+	return m, nil
+}
+
+func (m Model) renderPull() string {
+	var b strings.Builder
+
+	// Title
+	b.WriteString(titleStyle.Render("dtop - Image transfer"))
+	b.WriteString("\n\n")
+
+	if m.pull == nil {
+		return b.String()
+	}
+	p := m.pull.progress
+
+	b.WriteString(projectStyle.Render(m.pull.label))
+	b.WriteString("\n")
+	status := p.Status
+	if status == "" {
+		status = "Starting…"
+	}
+	b.WriteString(headerStyle.Render(status + "  " + pullSummary(p)))
+	b.WriteString("\n\n")
+
+	// One row per layer: ID, phase, bar and bytes
+	for _, layer := range p.Layers {
+		row := truncateOrPad(layer.ID, 14) + truncateOrPad(layer.Status, 20)
+		if layer.Total > 0 {
+			perc := float64(layer.Current) / float64(layer.Total) * 100.0
+			row += renderProgressBar(perc, 30) + " " +
+				fmt.Sprintf("%s / %s", formatNetBytes(uint64(layer.Current)), formatNetBytes(uint64(layer.Total)))
+		}
+		b.WriteString(containerStyle.Render(row))
+		b.WriteString("\n")
+	}
+
+	// Help text
+	b.WriteString("\n")
+	helpText := "q/esc:hide (continues in background)"
+	b.WriteString(helpStyle.Render(i18n.Help(helpText)))
+
+	return b.String()
+}
+
+// pullImage pulls the latest version of imageRef with progress; the running container
+// keeps its current image until it is recreated
+func (m *Model) pullImage(imageRef string) tea.Cmd {
+	return m.startPull("Pulling "+imageRef, func(progress func(docker.PullProgress)) error {
+		err := m.dockerClient.PullImage(imageRef, progress)
+		m.audit.Record("pull", imageRef, err)
+		return err
+	})
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/docker"
+)
+
+// pushForm tags an image and pushes it to a registry. Without a username the docker
+// CLI's saved login for the registry is used, if there is one.
+func (m *Model) pushForm(imageRef string) *form {
+	fields := []formField{
+		{Label: "Image", Value: imageRef, Placeholder: "local image"},
+		{Label: "Push as", Value: imageRef, Placeholder: "registry.example.com/team/app:1.0"},
+		{Label: "Username", Placeholder: "saved docker login"},
+		{Label: "Password", Placeholder: "password or access token", Secret: true},
+	}
+
+	return newForm("Tag & push "+imageRef, fields, func(values []string) tea.Cmd {
+		source, target, username := values[0], values[1], values[2]
+		// Passwords may legitimately start or end with spaces
+		password := fields[3].Value
+		if source == "" || target == "" {
+			return func() tea.Msg { return statusMsg("Push needs an image and a target") }
+		}
+
+		return m.startPull("Pushing "+target, func(progress func(docker.PullProgress)) error {
+			err := m.pushImage(source, target, username, password, progress)
+			m.audit.Record("push", target, err)
+			return err
+		})
+	})
+}
+
+// pushImage tags source as target if they differ, logs in and pushes target
+func (m *Model) pushImage(source, target, username, password string, progress func(docker.PullProgress)) error {
+	if target != source {
+		if err := m.dockerClient.TagImage(source, target); err != nil {
+			return err
+		}
+	}
+
+	server, err := docker.RegistryServer(target)
+	if err != nil {
+		return err
+	}
+	auth, ok := docker.StoredRegistryAuth(server)
+	if username != "" {
+		auth, err = m.dockerClient.Login(docker.RegistryAuth{Username: username, Password: password, Server: server})
+		if err != nil {
+			return err
+		}
+	} else if !ok {
+		// Public or anonymous push; the registry decides
+		auth = docker.RegistryAuth{Server: server}
+	}
+	return m.dockerClient.PushImage(target, auth, progress)
+}
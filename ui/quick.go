@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/docker"
+	"github.com/ekinertac/dtop/model"
+)
+
+// maxQuickRows is how many on-screen containers get a number key
+const maxQuickRows = 9
+
+// quickRows returns the first containers in the viewport; the number key n selects
+// the nth and arms a quick action for the next key
+func (m Model) quickRows() []*model.TreeNode {
+	rows := []*model.TreeNode{}
+	end := m.viewportTop + m.treeHeight()
+	if end > len(m.tree.Flat) {
+		end = len(m.tree.Flat)
+	}
+	for i := m.viewportTop; i < end && len(rows) < maxQuickRows; i++ {
+		if node := m.tree.Flat[i]; node.Type == model.NodeTypeContainer && node.Container != nil {
+			rows = append(rows, node)
+		}
+	}
+	return rows
+}
+
+// selectQuickRow selects the container numbered n and, outside of replays, waits for
+// an action key: "3 r" restarts the third container on screen
+func (m *Model) selectQuickRow(n int) {
+	rows := m.quickRows()
+	if n > len(rows) {
+		return
+	}
+	node := rows[n-1]
+	m.tree.Select(node)
+	m.adjustViewport()
+	if m.replay != nil {
+		return
+	}
+	m.quickTarget = node.Container.ID
+	m.status = fmt.Sprintf("%d %s: r restart  s stop/start  l logs", n, model.DisplayName(node.Container))
+}
+
+// quickActionLabel is the container menu item that key runs after a number key
+func quickActionLabel(key string, c *docker.ContainerInfo) string {
+	switch key {
+	case "r":
+		return "Restart"
+	case "s":
+		if c.State == "running" {
+			return "Stop"
+		}
+		return "Start"
+	case "l":
+		return "Logs"
+	}
+	return ""
+}
+
+// handleQuickAction runs the action armed by a number key. Keys that aren't quick
+// actions disarm it and are handled as usual, reported by the last return value.
+func (m Model) handleQuickAction(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	node := m.tree.FindContainer(m.quickTarget)
+	m.quickTarget = ""
+	m.status = ""
+	if node == nil {
+		return m, nil, false
+	}
+	label := quickActionLabel(msg.String(), node.Container)
+	if label == "" {
+		return m, nil, false
+	}
+	if m.remoteContainer(node.Container) {
+		return m, nil, true
+	}
+
+	for _, item := range m.getContainerMenuItems(node) {
+		if item.Label != label {
+			continue
+		}
+		if item.Mutates && m.readOnly {
+			m.status = "Read-only: actions are disabled (R to allow changes)"
+			return m, nil, true
+		}
+		return m, item.Action(), true
+	}
+	m.status = fmt.Sprintf("%s: %s isn't available", node.Container.Name, strings.ToLower(label))
+	return m, nil, true
+}
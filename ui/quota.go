@@ -0,0 +1,202 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/docker"
+	"github.com/ekinertac/dtop/model"
+)
+
+// projectQuota is the sum of the limits of a project's running containers
+type projectQuota struct {
+	name       string
+	containers int
+	cpus       float64 // Cores
+	memory     int64   // Bytes
+	noCPU      int     // Containers without a CPU limit, free to use every core
+	noMemory   int     // Containers without a memory limit, free to use all memory
+	failed     int     // Containers whose limits couldn't be looked up
+}
+
+// add counts a container's limits towards the quota
+func (q *projectQuota) add(limits docker.ResourceLimits) {
+	q.containers++
+	q.cpus += limits.CPUs
+	q.memory += limits.Memory
+	if limits.CPUs == 0 {
+		q.noCPU++
+	}
+	if limits.Memory == 0 {
+		q.noMemory++
+	}
+}
+
+// findings compares the quota to the host; severe is set when the limits add up
+// to more than the host has
+func (q projectQuota) findings(host *docker.HostInfo) (findings []string, severe bool) {
+	if host.NCPU > 0 && q.cpus > float64(host.NCPU) {
+		findings = append(findings, fmt.Sprintf("CPU overcommit (%.0f%% of host)", q.cpus/float64(host.NCPU)*100))
+		severe = true
+	}
+	if host.MemTotal > 0 && uint64(q.memory) > host.MemTotal {
+		findings = append(findings, fmt.Sprintf("memory overcommit (%.0f%% of host)", float64(q.memory)/float64(host.MemTotal)*100))
+		severe = true
+	}
+	if q.noCPU > 0 {
+		findings = append(findings, fmt.Sprintf("%d without CPU limit", q.noCPU))
+	}
+	if q.noMemory > 0 {
+		findings = append(findings, fmt.Sprintf("%d without memory limit", q.noMemory))
+	}
+	if q.failed > 0 {
+		findings = append(findings, fmt.Sprintf("%d limits unknown", q.failed))
+	}
+	return findings, severe
+}
+
+// openQuotaReport looks up the limits of the running containers and the host's
+// capacity in the background, and shows the limits summed per project against it
+func (m *Model) openQuotaReport() tea.Cmd {
+	if m.replay != nil {
+		m.status = "Not available while replaying a recording"
+		return nil
+	}
+	opts := TreeOptions(m.config)
+	ids, projects := []string{}, []string{}
+	seen := make(map[string]bool)
+	for _, c := range m.tree.Containers() {
+		// Pinned containers appear twice in the tree
+		if seen[c.ID] || isPlaceholder(c) || c.Remote || c.State != "running" {
+			continue
+		}
+		seen[c.ID] = true
+		ids = append(ids, c.ID)
+		projects = append(projects, model.ProjectName(c, opts))
+	}
+	if len(ids) == 0 {
+		m.status = "No running containers to report on"
+		return nil
+	}
+
+	m.status = fmt.Sprintf("Looking up the limits of %d containers…", len(ids))
+	client := m.dockerClient
+	return func() tea.Msg {
+		host, err := client.HostInfo()
+		if err != nil {
+			return statusMsg(fmt.Sprintf("Failed to query host capacity: %v", err))
+		}
+		quotas := make(map[string]*projectQuota)
+		for i, id := range ids {
+			q, ok := quotas[projects[i]]
+			if !ok {
+				q = &projectQuota{name: projects[i]}
+				quotas[projects[i]] = q
+			}
+			limits, err := client.GetResourceLimits(id)
+			if err != nil {
+				q.containers++
+				q.failed++
+				continue
+			}
+			q.add(limits)
+		}
+		rows := make([]projectQuota, 0, len(quotas))
+		for _, q := range quotas {
+			rows = append(rows, *q)
+		}
+		sort.Slice(rows, func(i, j int) bool { return rows[i].name < rows[j].name })
+		return quotaReport(rows, host)
+	}
+}
+
+// quotaReport renders the quotas as a table with a total row, with the same data
+// as CSV behind it
+func quotaReport(rows []projectQuota, host *docker.HostInfo) outputMsg {
+	share := func(used, capacity float64) string {
+		if capacity <= 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%.0f%%", used/capacity*100)
+	}
+	total := projectQuota{name: "TOTAL"}
+	for _, q := range rows {
+		total.containers += q.containers
+		total.cpus += q.cpus
+		total.memory += q.memory
+		total.noCPU += q.noCPU
+		total.noMemory += q.noMemory
+		total.failed += q.failed
+	}
+
+	lines := []string{
+		fmt.Sprintf("Limits of running containers summed per project, against the host's %d CPUs and %sB of memory. Containers without a limit can use the whole host.",
+			host.NCPU, formatNetBytes(host.MemTotal)),
+		"",
+		headerStyle.Render(fmt.Sprintf("%-28s %-10s %-10s %-7s %-10s %-7s %s",
+			"PROJECT", "CONTAINERS", "CPU LIMITS", "HOST", "MEM LIMITS", "HOST", "FINDINGS")),
+	}
+	table := [][]string{{"project", "containers", "cpu_limit_cores", "memory_limit_bytes",
+		"host_cpus", "host_memory_bytes", "without_cpu_limit", "without_memory_limit"}}
+
+	overcommitted := 0
+	for i, q := range append(rows, total) {
+		if i == len(rows) {
+			lines = append(lines, "")
+		}
+		findings, severe := q.findings(host)
+		style := runningStyle
+		switch {
+		case severe:
+			style = stoppedStyle
+			if q.name != total.name {
+				overcommitted++
+			}
+		case len(findings) > 0:
+			style = statusStyle
+		}
+		verdict := "ok"
+		if len(findings) > 0 {
+			verdict = strings.Join(findings, ", ")
+		}
+
+		lines = append(lines, fmt.Sprintf("%s %-10d %-10s %-7s %-10s %-7s %s",
+			truncateOrPad(q.name, 28), q.containers,
+			formatCores(q.cpus*100), share(q.cpus, float64(host.NCPU)),
+			formatNetBytes(uint64(q.memory)), share(float64(q.memory), float64(host.MemTotal)),
+			style.Render(verdict)))
+
+		table = append(table, []string{
+			q.name,
+			strconv.Itoa(q.containers),
+			strconv.FormatFloat(q.cpus, 'f', 2, 64),
+			strconv.FormatInt(q.memory, 10),
+			strconv.Itoa(host.NCPU),
+			strconv.FormatUint(host.MemTotal, 10),
+			strconv.Itoa(q.noCPU),
+			strconv.Itoa(q.noMemory),
+		})
+	}
+
+	// A project over the host's capacity takes the total over it too
+	summary := runningStyle.Render("Not overcommitted: the limits of all running containers fit the host")
+	if _, severe := total.findings(host); severe {
+		text := "Overcommitted: the limits of all running containers add up to more than the host"
+		if overcommitted > 0 {
+			text += fmt.Sprintf(", and %d of %d projects exceed it on their own", overcommitted, len(rows))
+		}
+		summary = stoppedStyle.Render(text)
+	}
+	lines = append([]string{summary, ""}, lines...)
+
+	return outputMsg{
+		title:     "dtop - Resource quotas",
+		lines:     lines,
+		table:     table,
+		tableFile: "dtop-quotas-" + time.Now().Format("20060102-1504") + ".csv",
+	}
+}
@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// panicReport is a panic recovered from Update or View. It is shared by every copy
+// of the model, so a panic while rendering, which can't change the model, still
+// brings up the error screen.
+type panicReport struct {
+	value  any    // Value passed to panic; nil while the error screen isn't shown
+	during string // "update" or "render"
+	stack  string
+}
+
+// record keeps a recovered panic and its stack for the error screen
+func (p *panicReport) record(during string, value any) {
+	p.value = value
+	p.during = during
+	p.stack = string(debug.Stack())
+}
+
+// String formats the report as printed after quitting from the error screen
+func (p *panicReport) String() string {
+	return fmt.Sprintf("dtop: panic during %s: %v\n\n%s", p.during, p.value, p.stack)
+}
+
+// PanicReport describes the panic shown on the error screen, or is empty. The CLI
+// prints it once the terminal is restored, so the stack isn't lost on quit.
+func (m Model) PanicReport() string {
+	if m.panicked == nil || m.panicked.value == nil {
+		return ""
+	}
+	return m.panicked.String()
+}
+
+func (m Model) handlePanicKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.scrollPager(msg.String()) {
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	case "c", "esc":
+		// The view that panicked may panic again, so continue from the container list
+		m.panicked.value = nil
+		m.viewMode = ViewModeMain
+		m.pagerScroll = 0
+	}
+	return m, nil
+}
+
+func (m Model) renderPanic() string {
+	lines := []string{
+		stoppedStyle.Render(fmt.Sprintf("panic during %s: %v", m.panicked.during, m.panicked.value)),
+		"",
+		"dtop kept running, but what it was doing when the error hit may be half done.",
+		"Continue to go back to the container list, or quit to print this report.",
+		"",
+	}
+	lines = append(lines, strings.Split(strings.TrimRight(m.panicked.stack, "\n"), "\n")...)
+	return m.renderPager("dtop hit an internal error", lines, "↑↓:scroll  c/esc:continue  q:quit")
+}
@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/config"
+	"github.com/ekinertac/dtop/record"
+)
+
+// replaySpeeds are the playback speeds +/- step through
+var replaySpeeds = []float64{0.25, 0.5, 1, 2, 4, 8, 16, 32}
+
+// replayMaxGap caps the (unscaled) wait between frames, so a recording that was
+// suspended for a while doesn't stall playback
+const replayMaxGap = 10 * time.Second
+
+// replay plays a recording back instead of polling the daemon
+type replay struct {
+	frames []record.Frame
+	next   int       // Index of the next frame to play
+	at     time.Time // Recorded time of the last played frame
+	speed  int       // Index into replaySpeeds
+	paused bool
+	gen    int // Bumped on pause/speed changes to drop frames scheduled before them
+}
+
+// replayFrameMsg plays the next frame of the recording
+type replayFrameMsg struct{ gen int }
+
+// NewReplayModel creates a model that plays back a recording made with --record.
+// Actions that need the daemon are unavailable.
+func NewReplayModel(cfg *config.Config, frames []record.Frame) Model {
+	m := NewModel(nil, cfg)
+	m.replay = &replay{frames: frames, speed: 2}
+	// Replayed events already happened; don't alert on them again
+	m.hooks = nil
+	return m
+}
+
+// SetRecorder records every container list and stats sample the model receives
+func (m *Model) SetRecorder(r *record.Recorder) {
+	m.recorder = r
+}
+
+// now is the current time, or the recorded time of the current frame during replay
+func (m Model) now() time.Time {
+	if m.replay != nil && !m.replay.at.IsZero() {
+		return m.replay.at
+	}
+	return time.Now()
+}
+
+// scheduleFrame waits for the next frame's turn at the current speed
+func (m Model) scheduleFrame() tea.Cmd {
+	r := m.replay
+	if r.paused || r.next >= len(r.frames) {
+		return nil
+	}
+
+	var delay time.Duration
+	if !r.at.IsZero() {
+		delay = r.frames[r.next].Time.Sub(r.at)
+		if delay > replayMaxGap {
+			delay = replayMaxGap
+		}
+		delay = time.Duration(float64(delay) / replaySpeeds[r.speed])
+	}
+	gen := r.gen
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return replayFrameMsg{gen}
+	})
+}
+
+// playFrame applies the next frame as if it had just been fetched from the daemon
+func (m Model) playFrame(msg replayFrameMsg) (tea.Model, tea.Cmd) {
+	r := m.replay
+	if msg.gen != r.gen || r.next >= len(r.frames) {
+		return m, nil
+	}
+
+	frame := r.frames[r.next]
+	r.next++
+	r.at = frame.Time
+
+	var cmd tea.Cmd
+	if frame.Stats != nil {
+		m.applyStats(statsMsg{containerID: frame.ContainerID, stats: *frame.Stats})
+	} else if frame.Event != nil {
+		m.recordEvent(*frame.Event)
+	} else {
+		var updated tea.Model
+		updated, cmd = m.Update(containersMsg(frame.Containers))
+		m = updated.(Model)
+	}
+
+	if r.next >= len(r.frames) {
+		m.status = "Replay finished"
+	}
+	return m, tea.Batch(cmd, m.scheduleFrame())
+}
+
+// handleReplayKey handles playback controls and blocks actions that need the daemon.
+// It reports whether the key was handled.
+func (m Model) handleReplayKey(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	r := m.replay
+	switch msg.String() {
+	case " ":
+		r.paused = !r.paused
+		r.gen++
+		return m, m.scheduleFrame(), true
+
+	case "+", "=":
+		if r.speed < len(replaySpeeds)-1 {
+			r.speed++
+			r.gen++
+			return m, m.scheduleFrame(), true
+		}
+		return m, nil, true
+
+	case "-":
+		if r.speed > 0 {
+			r.speed--
+			r.gen++
+			return m, m.scheduleFrame(), true
+		}
+		return m, nil, true
+
+	case "enter", "n", "b", "i", "G", "L", "D", "t", "e", "ctrl+z":
+		m.status = "Not available while replaying a recording"
+		return m, nil, true
+	}
+	return m, nil, false
+}
+
+// replayTitle describes the playback position for the title bar
+func (m Model) replayTitle() string {
+	r := m.replay
+	title := fmt.Sprintf("REPLAY %s ×%g", r.at.Local().Format("2006-01-02 15:04:05"), replaySpeeds[r.speed])
+	if r.paused {
+		title += " (paused)"
+	}
+	return fmt.Sprintf("%s  %d/%d", title, r.next, len(r.frames))
+}
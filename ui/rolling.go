@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/docker"
+	"github.com/ekinertac/dtop/model"
+)
+
+// rollingHealthTimeout bounds the wait for a restarted container to become healthy
+const rollingHealthTimeout = 2 * time.Minute
+
+var rollingRestartOp = batchOp{
+	verb:   "rolling restart",
+	title:  "Rolling restart",
+	label:  "Restarting",
+	filter: func(c *docker.ContainerInfo) bool { return c.State == "running" },
+	run:    (*docker.Client).RestartContainer,
+}
+
+// rollingRestart restarts a project's running containers one at a time, dependencies
+// first, and waits after each until it's healthy (or, without a healthcheck, for
+// the configured delay) before the next. A container that fails to come back stops
+// the roll, so the rest of the project keeps serving.
+func (m *Model) rollingRestart(project string, children []*model.TreeNode) tea.Cmd {
+	listed := []*docker.ContainerInfo{}
+	for _, child := range children {
+		if child.Container != nil {
+			listed = append(listed, child.Container)
+		}
+	}
+	containers := []*docker.ContainerInfo{}
+	if stages, err := startStages(listed); err == nil {
+		for _, stage := range stages {
+			for _, s := range stage {
+				containers = append(containers, s.containers...)
+			}
+		}
+	} else {
+		// Without a usable dependency order, the tree's order
+		for _, c := range listed {
+			if actionable(c) {
+				containers = append(containers, c)
+			}
+		}
+	}
+	targets, names := []string{}, []string{}
+	for _, c := range containers {
+		if rollingRestartOp.filter(c) {
+			targets = append(targets, c.ID)
+			names = append(names, c.Name)
+		}
+	}
+
+	client, audit, actions := m.dockerClient, m.audit, m.actions
+	delay := time.Duration(m.config.RollingDelay) * time.Second
+	return m.projectSequence(project, rollingRestartOp, targets, func(step func(string, error), wait func(string)) {
+		for i, id := range targets {
+			err := errors.New("already restarting")
+			done, ok := actions.enqueue(id, restartOp.verb, "restarting", func() error {
+				return client.RestartContainer(id)
+			})
+			if ok {
+				err = <-done
+			}
+
+			if err == nil {
+				wait(names[i] + " healthy")
+				err = client.WaitReady(id, docker.ConditionHealthy, rollingHealthTimeout)
+				if errors.Is(err, docker.ErrNoHealthcheck) {
+					err = nil
+					if i < len(targets)-1 && delay > 0 {
+						wait(fmt.Sprintf("%s (%s delay)", names[i], delay))
+						time.Sleep(delay)
+					}
+					// Still running after the delay, or it crashed on startup
+					if err = client.WaitReady(id, docker.ConditionStarted, 0); err != nil {
+						err = fmt.Errorf("not running: %w", err)
+					}
+				} else if err != nil {
+					err = fmt.Errorf("not healthy: %w", err)
+				}
+				wait("")
+			}
+
+			audit.Record(restartOp.verb, names[i], err)
+			step(id, err)
+			if err != nil {
+				for _, rest := range targets[i+1:] {
+					step(rest, fmt.Errorf("skipped: %s failed", names[i]))
+				}
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/docker"
+)
+
+// Severity of a security row; risky rows are marked and colored so they stand out
+const (
+	securityOK = iota
+	securityWarn
+	securityDanger
+)
+
+// securityRow is a line of the security panel
+type securityRow struct {
+	label    string
+	value    string
+	severity int
+}
+
+// showSecurity inspects a container and lists its privileges, confinement and
+// exposure, highlighting risky settings
+func (m Model) showSecurity(containerID, containerName string) tea.Cmd {
+	return func() tea.Msg {
+		title := "dtop - Security: " + containerName
+		info, err := m.dockerClient.GetSecurityInfo(containerID)
+		if err != nil {
+			return outputMsg{title: title, lines: []string{stoppedStyle.Render(err.Error())}}
+		}
+		return outputMsg{title: title, lines: securityLines(info)}
+	}
+}
+
+// securityRows grades the container's settings
+func securityRows(info *docker.SecurityInfo) []securityRow {
+	rows := []securityRow{}
+	add := func(label, value string, severity int) {
+		rows = append(rows, securityRow{label, value, severity})
+	}
+
+	if info.Privileged {
+		add("Privileged", "yes (all capabilities and devices, no confinement)", securityDanger)
+	} else {
+		add("Privileged", "no", securityOK)
+	}
+
+	switch {
+	case info.User == "":
+		add("User", "image default (root unless the image sets USER)", securityWarn)
+	case info.RootUser():
+		add("User", info.User+" (root)", securityWarn)
+	default:
+		add("User", info.User, securityOK)
+	}
+	if info.UsernsMode != "" {
+		add("User namespace", info.UsernsMode, securityOK)
+	}
+
+	if len(info.CapAdd) > 0 {
+		add("Added capabilities", strings.Join(info.CapAdd, ", "), securityWarn)
+	} else {
+		add("Added capabilities", "none", securityOK)
+	}
+	add("Dropped capabilities", orNone(strings.Join(info.CapDrop, ", ")), securityOK)
+
+	if info.Seccomp == "unconfined" {
+		add("Seccomp", "unconfined", securityDanger)
+	} else {
+		add("Seccomp", info.Seccomp, securityOK)
+	}
+	switch info.AppArmor {
+	case "unconfined":
+		add("AppArmor", "unconfined", securityDanger)
+	case "":
+		add("AppArmor", "none (not enabled on this host)", securityOK)
+	default:
+		add("AppArmor", info.AppArmor, securityOK)
+	}
+	add("No new privileges", yesNo(info.NoNewPrivs), securityOK)
+	add("Read-only rootfs", yesNo(info.ReadOnlyRootfs), securityOK)
+
+	for _, ns := range []struct{ label, mode string }{
+		{"PID namespace", info.PIDMode},
+		{"Network mode", info.NetworkMode},
+		{"IPC namespace", info.IPCMode},
+	} {
+		if ns.mode == "host" {
+			add(ns.label, "host (shared with the host)", securityDanger)
+		} else if ns.mode != "" {
+			add(ns.label, ns.mode, securityOK)
+		}
+	}
+
+	if info.DockerSocket != "" {
+		add("Docker socket", info.DockerSocket+" mounted (full control of the daemon)", securityDanger)
+	}
+	if len(info.Devices) > 0 {
+		add("Devices", strings.Join(info.Devices, ", "), securityWarn)
+	}
+
+	if len(info.PublicPorts) > 0 {
+		add("Public ports", strings.Join(info.PublicPorts, ", "), securityWarn)
+	} else {
+		add("Public ports", "none", securityOK)
+	}
+	if len(info.LocalPorts) > 0 {
+		add("Bound ports", strings.Join(info.LocalPorts, ", "), securityOK)
+	}
+	return rows
+}
+
+// securityLines renders the security panel with a summary of the findings first
+func securityLines(info *docker.SecurityInfo) []string {
+	rows := securityRows(info)
+	warnings, dangers := 0, 0
+	for _, row := range rows {
+		switch row.severity {
+		case securityWarn:
+			warnings++
+		case securityDanger:
+			dangers++
+		}
+	}
+
+	summary := runningStyle.Render("No risky settings found")
+	switch {
+	case dangers > 0:
+		summary = stoppedStyle.Render(fmt.Sprintf("High risk: %d, warnings: %d", dangers, warnings))
+	case warnings > 0:
+		summary = statusStyle.Render(fmt.Sprintf("Warnings: %d", warnings))
+	}
+
+	lines := []string{summary, ""}
+	for _, row := range rows {
+		marker, value := "  ", row.value
+		switch row.severity {
+		case securityWarn:
+			marker, value = statusStyle.Render("! "), statusStyle.Render(value)
+		case securityDanger:
+			marker, value = stoppedStyle.Render("‼ "), stoppedStyle.Render(value)
+		}
+		lines = append(lines, marker+headerStyle.Render(truncateOrPad(row.label, 22))+value)
+	}
+	return lines
+}
+
+// yesNo shows a flag as yes or no
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,229 @@
+package ui
+
+import (
+	"context"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/debuglog"
+	"github.com/ekinertac/dtop/docker"
+	"github.com/ekinertac/dtop/model"
+)
+
+// placeholderPrefix starts the ID of placeholder rows for compose services that have
+// no container; such rows never reach the daemon
+const placeholderPrefix = "compose:"
+
+// composeConfigTimeout bounds docker compose config, which only reads the files
+const composeConfigTimeout = 30 * time.Second
+
+// composeCheck compares a compose project's files with its containers
+type composeCheck struct {
+	project composeProject
+	defined []string  // Services in the compose files
+	modTime time.Time // Latest modification of the files when defined was read
+	missing []string  // Defined services without a container
+	loading bool      // A check is running
+}
+
+// composeCheckMsg delivers the result of a compose services check
+type composeCheckMsg struct {
+	services composeCheck
+	err      error
+}
+
+// isPlaceholder reports whether c stands for a compose service that has no container
+func isPlaceholder(c *docker.ContainerInfo) bool {
+	return strings.HasPrefix(c.ID, placeholderPrefix)
+}
+
+// actionable reports whether container actions can reach c: placeholders have no
+// container and containers on other swarm nodes belong to another daemon
+func actionable(c *docker.ContainerInfo) bool {
+	return !isPlaceholder(c) && !c.Remote
+}
+
+// checkComposeServices starts a check of every listed compose project whose files
+// are on this machine, unless one is already running for it
+func (m Model) checkComposeServices(containers []docker.ContainerInfo) tea.Cmd {
+	if m.replay != nil {
+		return nil
+	}
+	cmds := []tea.Cmd{}
+	seen := make(map[string]bool)
+	listed := make(map[string]bool) // "project/service" with a listed container
+	for i := range containers {
+		c := &containers[i]
+		listed[c.Labels[model.ComposeProjectLabel]+"/"+c.Labels[model.ComposeServiceLabel]] = true
+	}
+	for i := range containers {
+		c := &containers[i]
+		name := c.Labels[model.ComposeProjectLabel]
+		files := c.Labels[composeConfigFilesLabel]
+		if name == "" || files == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		previous := m.compose[name]
+		if previous.loading {
+			continue
+		}
+		missing := []string{}
+		for _, service := range previous.missing {
+			if !listed[name+"/"+service] {
+				missing = append(missing, service)
+			}
+		}
+		previous.missing = missing
+		previous.project = composeProject{
+			name:       name,
+			files:      strings.Split(files, ","),
+			workingDir: c.Labels[composeWorkingDirLabel],
+		}
+		previous.loading = true
+		m.compose[name] = previous
+		cmds = append(cmds, m.fetchComposeServices(previous))
+	}
+
+	// Forget projects that are gone
+	for name, services := range m.compose {
+		if !seen[name] && !services.loading {
+			delete(m.compose, name)
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// fetchComposeServices reads the services the compose files define and lists the
+// ones with a container, both only when the files changed since the last check.
+// In between, checkComposeServices drops services from the missing list as their
+// containers show up.
+func (m Model) fetchComposeServices(previous composeCheck) tea.Cmd {
+	return func() tea.Msg {
+		result := previous
+		result.loading = false
+
+		var modTime time.Time
+		for _, file := range previous.project.files {
+			info, err := os.Stat(file)
+			if err != nil {
+				// Started on another machine, or the file was moved
+				return composeCheckMsg{services: composeCheck{project: previous.project}, err: err}
+			}
+			if info.ModTime().After(modTime) {
+				modTime = info.ModTime()
+			}
+		}
+		if modTime.Equal(previous.modTime) {
+			return composeCheckMsg{services: result}
+		}
+
+		defined, err := m.composeConfigServices(previous.project)
+		if err != nil {
+			// Keep the modification time so compose isn't run again until the files change
+			return composeCheckMsg{services: composeCheck{project: previous.project, modTime: modTime}, err: err}
+		}
+		result.defined, result.modTime = defined, modTime
+
+		existing, err := m.dockerClient.ComposeServices(previous.project.name)
+		if err != nil {
+			// Without the modification time, the next check tries again
+			result.modTime = time.Time{}
+			return composeCheckMsg{services: result, err: err}
+		}
+		result.missing = nil
+		for _, service := range result.defined {
+			if !existing[service] {
+				result.missing = append(result.missing, service)
+			}
+		}
+		return composeCheckMsg{services: result}
+	}
+}
+
+// composeConfigServices asks docker compose for the services the project's files
+// define, so includes, extends and profiles are resolved like compose does
+func (m *Model) composeConfigServices(project composeProject) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), composeConfigTimeout)
+	defer cancel()
+
+	cmd := m.dockerCommand(ctx, append(composeArgs(project), "config", "--services")...)
+	cmd.Dir = project.workingDir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+	services := strings.Fields(string(output))
+	sort.Strings(services)
+	return services, nil
+}
+
+func (m Model) handleComposeServices(msg composeCheckMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		// No placeholders rather than wrong ones; the next refresh checks again
+		debuglog.Error("compose services", msg.err, "project", msg.services.project.name)
+		msg.services.missing = nil
+	}
+	m.compose[msg.services.project.name] = msg.services
+	return m, nil
+}
+
+// placeholders returns a row for each missing service of the listed compose projects
+func (m Model) placeholders(containers []docker.ContainerInfo) []docker.ContainerInfo {
+	// Missing services only make sense next to the rest of their project
+	if m.grouping != model.GroupByProject {
+		return nil
+	}
+	listed := make(map[string]bool)
+	for i := range containers {
+		listed[containers[i].Labels[model.ComposeProjectLabel]] = true
+	}
+
+	rows := []docker.ContainerInfo{}
+	for name, services := range m.compose {
+		if !listed[name] {
+			continue
+		}
+		for _, service := range services.missing {
+			rows = append(rows, docker.ContainerInfo{
+				ID:     placeholderPrefix + name + "/" + service,
+				Name:   service,
+				State:  "missing",
+				Status: "not created",
+				Labels: map[string]string{
+					model.ComposeProjectLabel: name,
+					model.ComposeServiceLabel: service,
+					composeConfigFilesLabel:   strings.Join(services.project.files, ","),
+					composeWorkingDirLabel:    services.project.workingDir,
+				},
+				ContainerStats: docker.ContainerStats{MemUsage: "N/A"},
+			})
+		}
+	}
+	return rows
+}
+
+// placeholderMenuItems offers to create the missing service of a placeholder row
+func (m *Model) placeholderMenuItems(c *docker.ContainerInfo) []MenuItem {
+	project, ok := m.compose[c.Labels[model.ComposeProjectLabel]]
+	if !ok {
+		return nil
+	}
+	service := c.Name
+	return []MenuItem{
+		{
+			Label:   "Create & start (docker compose up -d " + service + ")",
+			Mutates: true,
+			Action: func() tea.Cmd {
+				status := func() tea.Msg {
+					return statusMsg("Creating " + service + "…")
+				}
+				return tea.Batch(status, m.composeUp(project.project, service))
+			},
+		},
+	}
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// signals are the signals offered by "Send signal…", with what applications commonly do on them
+var signals = [][2]string{
+	{"SIGHUP", "reload configuration"},
+	{"SIGUSR1", "application defined (e.g. reopen logs)"},
+	{"SIGUSR2", "application defined"},
+	{"SIGINT", "interrupt"},
+	{"SIGTERM", "graceful shutdown"},
+	{"SIGQUIT", "quit (some runtimes dump stacks)"},
+	{"SIGWINCH", "terminal resized (graceful stop for Apache httpd)"},
+	{"SIGKILL", "kill immediately"},
+}
+
+// openMenuMsg asks the model to show a menu that isn't about the selected row's
+// actions, e.g. a submenu opened by a menu action
+type openMenuMsg struct {
+	title string
+	items []MenuItem
+}
+
+// openMenuWith shows a titled menu
+func (m *Model) openMenuWith(title string, items []MenuItem) {
+	m.menuItems = items
+	m.menuSelected = 0
+	m.menuTitle = title
+	m.viewMode = ViewModeMenu
+}
+
+// signalMenu lists signals to send to the container's main process
+func (m *Model) signalMenu(containerID, containerName string) tea.Cmd {
+	items := make([]MenuItem, 0, len(signals))
+	for _, signal := range signals {
+		name := signal[0]
+		items = append(items, MenuItem{
+			Label:   fmt.Sprintf("%-9s %s", name, signal[1]),
+			Mutates: true,
+			Action: func() tea.Cmd {
+				return func() tea.Msg {
+					err := m.dockerClient.SignalContainer(containerID, name)
+					m.audit.Record("signal "+name, containerName, err)
+					if err != nil {
+						return statusMsg(fmt.Sprintf("%s to %s: %v", name, containerName, err))
+					}
+					return statusMsg(fmt.Sprintf("Sent %s to %s", name, containerName))
+				}
+			},
+		})
+	}
+	return func() tea.Msg {
+		return openMenuMsg{title: "Send signal to " + containerName, items: items}
+	}
+}
@@ -0,0 +1,183 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/config"
+	"github.com/ekinertac/dtop/docker"
+	"github.com/ekinertac/dtop/i18n"
+)
+
+// StatsModel is the live view of a single container behind `dtop stats <container>`,
+// fed by the daemon's stats stream instead of the monitor's refresh
+type StatsModel struct {
+	client    *docker.Client
+	graphs    graphStyle
+	container docker.ContainerInfo
+	stats     docker.ContainerStats
+	samples   []statsSample
+	readRate  []float64
+	writeRate []float64
+	sampled   bool
+	ended     bool
+	err       error
+	width     int
+}
+
+// streamStatsMsg delivers a stats sample, or the end of the stream when done
+type streamStatsMsg struct {
+	stats docker.ContainerStats
+	done  bool
+	err   error
+	ch    <-chan streamStatsMsg
+}
+
+// NewStatsModel creates the single-container view
+func NewStatsModel(client *docker.Client, cfg *config.Config, c docker.ContainerInfo) StatsModel {
+	applyAccent(cfg.Accent)
+	applyAccessible(cfg.Accessible)
+	i18n.Set(cfg.Language)
+	return StatsModel{client: client, graphs: resolveGraphStyle(cfg.Graphs), container: c, width: 80}
+}
+
+// waitForSample delivers the next sample of the stats stream
+func waitForSample(ch <-chan streamStatsMsg) tea.Cmd {
+	return func() tea.Msg {
+		return <-ch
+	}
+}
+
+func (m StatsModel) Init() tea.Cmd {
+	client, id := m.client, m.container.ID
+	ch := make(chan streamStatsMsg, 4)
+	go func() {
+		err := client.StreamContainerStats(id, func(stats docker.ContainerStats) {
+			ch <- streamStatsMsg{stats: stats, ch: ch}
+		})
+		ch <- streamStatsMsg{done: true, err: err, ch: ch}
+	}()
+	return waitForSample(ch)
+}
+
+func (m StatsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		}
+	case streamStatsMsg:
+		if msg.done {
+			m.ended, m.err = true, msg.err
+			return m, nil
+		}
+		m.record(msg.stats)
+		return m, waitForSample(msg.ch)
+	}
+	return m, nil
+}
+
+// record keeps the sample for the graphs, dropping the oldest beyond historyLength
+func (m *StatsModel) record(stats docker.ContainerStats) {
+	m.stats, m.sampled = stats, true
+	m.samples = append(m.samples, statsSample{
+		at:    time.Now(),
+		cpu:   stats.CPUPerc,
+		mem:   stats.MemPerc,
+		netRx: stats.NetRx,
+		netTx: stats.NetTx,
+	})
+	m.readRate = append(m.readRate, stats.Block.ReadRate)
+	m.writeRate = append(m.writeRate, stats.Block.WriteRate)
+	if len(m.samples) > historyLength {
+		m.samples = m.samples[len(m.samples)-historyLength:]
+		m.readRate = m.readRate[len(m.readRate)-historyLength:]
+		m.writeRate = m.writeRate[len(m.writeRate)-historyLength:]
+	}
+}
+
+func (m StatsModel) View() string {
+	var b strings.Builder
+	c := m.container
+	b.WriteString(titleStyle.Render("dtop - " + c.Name))
+	b.WriteString("\n")
+	b.WriteString(headerStyle.Render(fmt.Sprintf("%s  %s  %s", c.ID, c.Image, c.Status)))
+	b.WriteString("\n\n")
+
+	if !m.sampled && !m.ended {
+		b.WriteString(statusStyle.Render("Waiting for the first sample…"))
+	}
+	if m.sampled {
+		b.WriteString(strings.Join(m.statsLines(), "\n"))
+	}
+	if m.ended {
+		ended := "Stats stream ended (container stopped?)"
+		if m.err != nil {
+			ended = "Stats stream failed: " + m.err.Error()
+		}
+		b.WriteString("\n\n")
+		b.WriteString(stoppedStyle.Render(ended))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render(fmt.Sprintf("Sampled every second, graphs cover the last %d samples  q:quit", historyLength)))
+	return b.String()
+}
+
+// statsLines renders a gauge or rates per resource, each followed by its graph
+func (m StatsModel) statsLines() []string {
+	s := m.stats
+	gauge := max(m.width/4, 10)
+	graph := max(min(m.width-gauge-40, historyLength), 10)
+	cpu, mem := []float64{}, []float64{}
+	for _, sample := range m.samples {
+		cpu = append(cpu, sample.cpu)
+		mem = append(mem, sample.mem)
+	}
+	rx, tx := netRates(m.samples)
+	lastRate := func(rates []float64) float64 {
+		if len(rates) == 0 {
+			return 0
+		}
+		return rates[len(rates)-1]
+	}
+
+	cores := max(len(s.PerCPUPerc), 1)
+	memText := "--"
+	if s.Memory.Reported {
+		memText = fmt.Sprintf("%.1f%% %s", s.MemPerc, s.MemUsage)
+	}
+	lines := []string{
+		fmt.Sprintf("%s %s %-28s %s", headerStyle.Render("CPU  "),
+			renderGauge(s.CPUPerc/float64(cores), gauge),
+			fmt.Sprintf("%.1f%% (%s)", s.CPUPerc, formatCores(s.CPUPerc)),
+			m.graphs.graph(cpu, graph, 0, "stats/cpu")),
+		fmt.Sprintf("%s %s %-28s %s", headerStyle.Render("MEM  "),
+			renderGauge(s.MemPerc, gauge), memText, m.graphs.graph(mem, graph, 100, "stats/mem")),
+		"",
+		fmt.Sprintf("%s %-*s %s", headerStyle.Render("NET ↓"), gauge+29,
+			fmt.Sprintf("%s/s (total %s)", formatNetBytes(uint64(lastRate(rx))), formatNetBytes(s.NetRx)),
+			m.graphs.graph(rx, graph, 0, "stats/rx")),
+		fmt.Sprintf("%s %-*s %s", headerStyle.Render("NET ↑"), gauge+29,
+			fmt.Sprintf("%s/s (total %s)", formatNetBytes(uint64(lastRate(tx))), formatNetBytes(s.NetTx)),
+			m.graphs.graph(tx, graph, 0, "stats/tx")),
+		fmt.Sprintf("%s %-*s %s", headerStyle.Render("READ "), gauge+29,
+			fmt.Sprintf("%s/s (total %s)", formatNetBytes(uint64(s.Block.ReadRate)), formatNetBytes(s.Block.Read)),
+			m.graphs.graph(m.readRate, graph, 0, "stats/read")),
+		fmt.Sprintf("%s %-*s %s", headerStyle.Render("WRITE"), gauge+29,
+			fmt.Sprintf("%s/s (total %s)", formatNetBytes(uint64(s.Block.WriteRate)), formatNetBytes(s.Block.Write)),
+			m.graphs.graph(m.writeRate, graph, 0, "stats/write")),
+		"",
+		fmt.Sprintf("%s %s", headerStyle.Render("PIDS "), formatPIDs(s.PIDs, s.PIDsLimit)),
+	}
+	if s.Throttling.ThrottledPeriods > 0 {
+		lines = append(lines, fmt.Sprintf("%s %s", headerStyle.Render("THROT"),
+			statusStyle.Render(fmt.Sprintf("%d of %d periods throttled", s.Throttling.ThrottledPeriods, s.Throttling.Periods))))
+	}
+	return lines
+}
@@ -0,0 +1,211 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/docker"
+)
+
+const (
+	// sizingMinSamples is how many stats samples a container needs before its limits
+	// are judged; a minute at the default refresh rate
+	sizingMinSamples = 30
+
+	// overProvisioned is the share of a limit below which peak usage flags the limit
+	// as too generous, and nearLimit the share above which it flags it as too tight
+	overProvisioned = 0.25
+	nearLimit       = 0.9
+)
+
+// usagePeak is the highest usage seen for a container this session
+type usagePeak struct {
+	cpu     float64 // Docker-style percentage, 100 per core
+	memory  uint64  // Bytes
+	samples int
+}
+
+// recordPeak raises the container's peaks to the sample's usage
+func (m *Model) recordPeak(containerID string, stats docker.ContainerStats) {
+	peak, ok := m.peaks[containerID]
+	if !ok {
+		peak = &usagePeak{}
+		m.peaks[containerID] = peak
+	}
+	peak.samples++
+	peak.cpu = max(peak.cpu, stats.CPUPerc)
+	if stats.Memory.Reported {
+		peak.memory = max(peak.memory, stats.Memory.Usage)
+	}
+}
+
+// sizingRow is a container's configured limits next to its observed peaks
+type sizingRow struct {
+	name   string
+	limits docker.ResourceLimits
+	peak   usagePeak
+	err    error
+}
+
+// findings judges the limits against the peaks; severe is set when usage came
+// close to a limit, which matters more than a generous one
+func (r sizingRow) findings() (findings []string, severe bool) {
+	if r.err != nil {
+		return []string{"limits unknown: " + r.err.Error()}, false
+	}
+	if r.limits.Memory == 0 {
+		findings = append(findings, "no memory limit")
+	}
+	if r.limits.CPUs == 0 {
+		findings = append(findings, "no CPU limit")
+	}
+	if r.peak.samples < sizingMinSamples {
+		return append(findings, fmt.Sprintf("too few samples (%d)", r.peak.samples)), false
+	}
+
+	judge := func(what string, peak, limit float64, soft bool) {
+		if limit <= 0 {
+			return
+		}
+		share := peak / limit
+		switch {
+		case share >= nearLimit && !soft:
+			findings = append(findings, fmt.Sprintf("%s near limit (peak %.0f%%)", what, share*100))
+			severe = true
+		case share < overProvisioned:
+			findings = append(findings, fmt.Sprintf("%s over-provisioned (peak %.0f%%)", what, share*100))
+		}
+	}
+	judge("memory", float64(r.peak.memory), float64(r.limits.Memory), false)
+	// Usage above a reservation is normal; it only applies when memory runs short
+	judge("memory reservation", float64(r.peak.memory), float64(r.limits.MemoryReservation), true)
+	judge("CPU", r.peak.cpu/100, r.limits.CPUs, false)
+	return findings, severe
+}
+
+// openSizingReport looks up the limits of the running and sampled containers in
+// the background and shows them next to their peak usage this session
+func (m *Model) openSizingReport() tea.Cmd {
+	if m.replay != nil {
+		m.status = "Not available while replaying a recording"
+		return nil
+	}
+	rows := []sizingRow{}
+	ids := []string{}
+	for _, c := range m.tree.Containers() {
+		peak, sampled := m.peaks[c.ID]
+		if isPlaceholder(c) || c.Remote || (c.State != "running" && !sampled) {
+			continue
+		}
+		row := sizingRow{name: c.Name}
+		if sampled {
+			row.peak = *peak
+		}
+		rows = append(rows, row)
+		ids = append(ids, c.ID)
+	}
+	if len(rows) == 0 {
+		m.status = "No running containers to report on"
+		return nil
+	}
+
+	m.status = fmt.Sprintf("Looking up the limits of %d containers…", len(rows))
+	client := m.dockerClient
+	return func() tea.Msg {
+		for i, id := range ids {
+			rows[i].limits, rows[i].err = client.GetResourceLimits(id)
+		}
+		sort.Slice(rows, func(i, j int) bool { return rows[i].name < rows[j].name })
+		return sizingReport(rows)
+	}
+}
+
+// sizingReport renders the rows as a table, with the same data as CSV behind it
+func sizingReport(rows []sizingRow) outputMsg {
+	lines := []string{
+		fmt.Sprintf("Peak usage this session against configured limits; judged after %d samples, flagged when the peak is below %.0f%% or above %.0f%% of a limit",
+			sizingMinSamples, overProvisioned*100, nearLimit*100),
+		"",
+		headerStyle.Render(fmt.Sprintf("%-28s %-9s %-9s %-9s %-9s %-9s %s",
+			"NAME", "CPU LIMIT", "CPU PEAK", "MEM LIMIT", "MEM RESV", "MEM PEAK", "FINDINGS")),
+	}
+	table := [][]string{{"container", "cpu_limit_cores", "cpu_peak_cores", "memory_limit_bytes",
+		"memory_reservation_bytes", "memory_peak_bytes", "samples", "findings"}}
+
+	flagged := 0
+	for _, r := range rows {
+		findings, severe := r.findings()
+		style := runningStyle
+		switch {
+		case severe:
+			style = stoppedStyle
+		case len(findings) > 0:
+			style = statusStyle
+		}
+		if len(findings) > 0 {
+			flagged++
+		}
+		verdict := "ok"
+		if len(findings) > 0 {
+			verdict = strings.Join(findings, ", ")
+		}
+
+		cpuLimit, memLimit, memResv := "none", "none", "none"
+		if r.limits.CPUs > 0 {
+			cpuLimit = formatCores(r.limits.CPUs * 100)
+		}
+		if r.limits.Memory > 0 {
+			memLimit = formatNetBytes(uint64(r.limits.Memory))
+		}
+		if r.limits.MemoryReservation > 0 {
+			memResv = formatNetBytes(uint64(r.limits.MemoryReservation))
+		}
+		cpuPeak, memPeak := "-", "-"
+		if r.peak.samples > 0 {
+			cpuPeak = formatCores(r.peak.cpu)
+			memPeak = formatNetBytes(r.peak.memory)
+		}
+		lines = append(lines, fmt.Sprintf("%s %-9s %-9s %-9s %-9s %-9s %s",
+			truncateOrPad(r.name, 28), cpuLimit, cpuPeak, memLimit, memResv, memPeak,
+			style.Render(verdict)))
+
+		table = append(table, []string{
+			r.name,
+			csvFloat(r.limits.CPUs),
+			strconv.FormatFloat(r.peak.cpu/100, 'f', 2, 64),
+			csvInt(r.limits.Memory),
+			csvInt(r.limits.MemoryReservation),
+			strconv.FormatUint(r.peak.memory, 10),
+			strconv.Itoa(r.peak.samples),
+			strings.Join(findings, "; "),
+		})
+	}
+	lines[0] = fmt.Sprintf("%d of %d containers flagged. ", flagged, len(rows)) + lines[0]
+
+	return outputMsg{
+		title:     "dtop - Resource sizing",
+		lines:     lines,
+		table:     table,
+		tableFile: "dtop-sizing-" + time.Now().Format("20060102-1504") + ".csv",
+	}
+}
+
+// csvFloat formats a limit for CSV, leaving unlimited values empty
+func csvFloat(v float64) string {
+	if v <= 0 {
+		return ""
+	}
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+// csvInt formats a limit for CSV, leaving unlimited values empty
+func csvInt(v int64) string {
+	if v <= 0 {
+		return ""
+	}
+	return strconv.FormatInt(v, 10)
+}
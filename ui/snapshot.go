@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/ekinertac/dtop/model"
+	"github.com/mattn/go-runewidth"
 )
 
 // PrintSnapshot prints a non-interactive snapshot of the container tree
@@ -14,10 +15,10 @@ func PrintSnapshot(tree *model.Tree) {
 	fmt.Println()
 
 	// Header
-	header := fmt.Sprintf("%-40s %-25s %-12s %-12s %-14s %s",
-		"NAME", "STATUS", "CPU", "MEMORY", "NET RX/TX", "UPTIME")
+	header := fmt.Sprintf("%-40s %-25s %-12s %-12s %-14s %-10s %s",
+		"NAME", "STATUS", "CPU", "MEMORY", "NET RX/TX", "PIDS", "UPTIME")
 	fmt.Println(header)
-	fmt.Println(strings.Repeat("-", 130))
+	fmt.Println(strings.Repeat("-", 141))
 
 	if tree == nil || len(tree.Flat) == 0 {
 		fmt.Println("No containers found")
@@ -49,7 +50,7 @@ func printNode(tree *model.Tree, node *model.TreeNode) {
 		}
 
 		c := node.Container
-		name := truncateOrPadPlain(indent+"  "+c.Name, 40)
+		name := truncateOrPadPlain(indent+"  "+model.DisplayName(c), 40)
 		status := truncateOrPadPlain(c.Status, 25)
 		
 		// CPU with bar
@@ -60,6 +61,9 @@ func printNode(tree *model.Tree, node *model.TreeNode) {
 		// Memory with bar
 		memBar := renderProgressBarPlain(c.MemPerc, 5)
 		memText := fmt.Sprintf("%3.0f%% %s", c.MemPerc, memBar)
+		if c.MemUsage == "N/A" {
+			memText = " N/A"
+		}
 		mem := truncateOrPadPlain(memText, 12)
 		
 		// Network
@@ -68,19 +72,20 @@ func printNode(tree *model.Tree, node *model.TreeNode) {
 		netText := fmt.Sprintf("%s/%s", netRx, netTx)
 		net := truncateOrPadPlain(netText, 14)
 		
-		uptime := model.FormatUptime(c.CreatedAt)
+		pids := truncateOrPadPlain(formatPIDs(c.PIDs, c.PIDsLimit), 10)
+		
+		uptime := model.ContainerUptime(c)
 
-		fmt.Printf("%s %s %s %s %s %s\n", name, status, cpu, mem, net, uptime)
+		fmt.Printf("%s %s %s %s %s %s %s\n", name, status, cpu, mem, net, pids, uptime)
 	}
 }
 
 // truncateOrPadPlain truncates or pads a string to a fixed width (plain text, no ANSI)
 func truncateOrPadPlain(s string, width int) string {
-	runes := []rune(s)
-	if len(runes) > width {
-		return string(runes[:width-3]) + "..."
+	if runewidth.StringWidth(s) > width {
+		s = runewidth.Truncate(s, width, "...")
 	}
-	return s + strings.Repeat(" ", width-len(runes))
+	return runewidth.FillRight(s, width)
 }
 
 // renderProgressBarPlain creates a simple progress bar (plain text)
@@ -101,28 +106,8 @@ func renderProgressBarPlain(percent float64, width int) string {
 	return bar
 }
 
-// formatNetBytesPlain formats network bytes with units
+// formatNetBytesPlain formats network bytes with units, without localizing the number
 func formatNetBytesPlain(bytes uint64) string {
-	const unit = 1024
-	if bytes < unit {
-		return "0"
-	}
-	
-	div := uint64(unit)
-	exp := 0
-	for n := bytes / unit; n >= unit && exp < 4; n /= unit {
-		div *= unit
-		exp++
-	}
-	
-	value := float64(bytes) / float64(div)
-	units := []string{"B", "K", "M", "G", "T"}
-	
-	if value >= 100 {
-		return fmt.Sprintf("%.0f%s", value, units[exp])
-	} else if value >= 10 {
-		return fmt.Sprintf("%.1f%s", value, units[exp])
-	}
-	return fmt.Sprintf("%.1f%s", value, units[exp])
+	return compactBytes(bytes, fmt.Sprintf)
 }
 
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"math/rand"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/docker"
+)
+
+// statsMsg carries a stats sample for a single container
+type statsMsg struct {
+	containerID string
+	stats       docker.ContainerStats
+}
+
+// fetchStats fetches stats for one container in the background after delay
+func (m Model) fetchStats(containerID string, delay time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		time.Sleep(delay)
+		start := time.Now()
+		stats := m.dockerClient.GetContainerStats(containerID)
+		m.metrics.recordStats(time.Since(start))
+		return statsMsg{containerID: containerID, stats: stats}
+	}
+}
+
+// fetchAllStats requests stats for every running container. Each result arrives as
+// its own message, so one slow container doesn't hold up the rest. Requests are
+// spread randomly over spread so the daemon doesn't get them all at once.
+func (m Model) fetchAllStats(containers []docker.ContainerInfo, spread time.Duration) tea.Cmd {
+	cmds := []tea.Cmd{}
+	for _, c := range containers {
+		// Containers on other swarm nodes can't be sampled from this daemon
+		if c.State == "running" && !c.Remote {
+			var delay time.Duration
+			if spread > 0 {
+				delay = time.Duration(rand.Int63n(int64(spread)))
+			}
+			cmds = append(cmds, m.fetchStats(c.ID, delay))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// applyStats patches a stats sample onto the matching container in the tree and
+// reports whether its row shows anything different
+func (m *Model) applyStats(msg statsMsg) bool {
+	node := m.tree.FindContainer(msg.containerID)
+	if node == nil {
+		return false
+	}
+	before := m.statsSignature(node.Container)
+	previous := node.Container.ContainerStats
+	node.Container.ContainerStats = msg.stats
+	m.recordHistory(msg.containerID, msg.stats)
+	m.recordPeak(msg.containerID, msg.stats)
+	m.checkThresholds(node.Container, previous)
+	return m.statsSignature(node.Container) != before
+}
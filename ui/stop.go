@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/docker"
+)
+
+// stopCountdownInterval is how often the countdown of graceful stops is redrawn
+const stopCountdownInterval = time.Second
+
+// startStopMsg asks the model to stop a container with a custom grace period
+type startStopMsg struct {
+	containerID string
+	name        string
+	timeout     time.Duration
+}
+
+// stopDoneMsg reports that a graceful stop finished
+type stopDoneMsg struct {
+	containerID string
+	name        string
+	err         error
+}
+
+// stopTickMsg redraws the countdown of running graceful stops
+type stopTickMsg struct{}
+
+// parseStopTimeout parses a grace period like 60s or 2m; a bare number is seconds
+// and empty means the default
+func parseStopTimeout(s string) (time.Duration, error) {
+	if s == "" {
+		return docker.DefaultStopTimeout, nil
+	}
+	if _, err := strconv.Atoi(s); err == nil {
+		s += "s"
+	}
+	timeout, err := time.ParseDuration(s)
+	if err != nil || timeout < 0 {
+		return 0, fmt.Errorf("invalid timeout %q (e.g. 60s, 2m)", s)
+	}
+	return timeout, nil
+}
+
+// stopForm asks for the grace period before stopping a container, for services
+// like databases that need longer than the default to shut down cleanly
+func (m *Model) stopForm(containerID, containerName string) *form {
+	fields := []formField{
+		{Label: "Grace period", Placeholder: fmt.Sprintf("%s (e.g. 60s, 2m)", docker.DefaultStopTimeout)},
+	}
+	return newForm("Stop "+containerName, fields, func(values []string) tea.Cmd {
+		return func() tea.Msg {
+			timeout, err := parseStopTimeout(values[0])
+			if err != nil {
+				return statusMsg(err.Error())
+			}
+			return startStopMsg{containerID: containerID, name: containerName, timeout: timeout}
+		}
+	})
+}
+
+// startStop stops the container in the background and counts down its grace
+// period on its row until it is done
+func (m *Model) startStop(msg startStopMsg) tea.Cmd {
+	client, audit := m.dockerClient, m.audit
+	done, ok := m.actions.enqueue(msg.containerID, "stop", "stopping", func() error {
+		return client.StopContainerTimeout(msg.containerID, msg.timeout)
+	})
+	if !ok {
+		m.status = msg.name + " is already stopping"
+		return nil
+	}
+	first := len(m.stopping) == 0
+	m.stopping[msg.containerID] = time.Now().Add(msg.timeout)
+	m.status = fmt.Sprintf("Stopping %s (SIGTERM, killed after %s)", msg.name, msg.timeout)
+
+	stop := func() tea.Msg {
+		err := <-done
+		audit.Record(fmt.Sprintf("stop (%s timeout)", msg.timeout), msg.name, err)
+		return stopDoneMsg{containerID: msg.containerID, name: msg.name, err: err}
+	}
+	if first {
+		return tea.Batch(stop, stopCountdown())
+	}
+	return stop
+}
+
+// stopCountdown schedules the next countdown redraw
+func stopCountdown() tea.Cmd {
+	return tea.Tick(stopCountdownInterval, func(time.Time) tea.Msg {
+		return stopTickMsg{}
+	})
+}
+
+func (m Model) handleStopDone(msg stopDoneMsg) (tea.Model, tea.Cmd) {
+	delete(m.stopping, msg.containerID)
+	if msg.err != nil {
+		m.status = fmt.Sprintf("Stopping %s failed: %v", msg.name, msg.err)
+	} else {
+		undo := undoAction{name: msg.name, containerID: msg.containerID}
+		m.pushUndo(undo)
+		m.status = "Stopped " + msg.name + " — " + undo.hint()
+	}
+	return m, m.refreshContainers()
+}
+
+// stopCountdownText describes a graceful stop in progress for the status column,
+// e.g. "stopping… 42s"; empty when the container isn't being stopped
+func (m Model) stopCountdownText(containerID string) string {
+	deadline, ok := m.stopping[containerID]
+	if !ok {
+		return ""
+	}
+	left := time.Until(deadline).Round(time.Second)
+	if left <= 0 {
+		return "stopping… killing"
+	}
+	return "stopping… " + left.String()
+}
@@ -0,0 +1,32 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ekinertac/dtop/docker"
+)
+
+func TestParseStopTimeout(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"", docker.DefaultStopTimeout, false},
+		{"30", 30 * time.Second, false},
+		{"0", 0, false},
+		{"60s", time.Minute, false},
+		{"2m", 2 * time.Minute, false},
+		{"1m30s", 90 * time.Second, false},
+		{"-5s", 0, true},
+		{"-5", 0, true},
+		{"soon", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseStopTimeout(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseStopTimeout(%q) = %v, %v; want %v, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
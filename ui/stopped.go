@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/debuglog"
+	"github.com/ekinertac/dtop/model"
+)
+
+// stoppedCheckInterval is how often every container is listed to count the stopped
+// ones per project; the tree only holds running containers
+const stoppedCheckInterval = 10 * time.Second
+
+// stoppedMsg delivers the number of stopped containers per project
+type stoppedMsg struct {
+	counts map[string]int
+	err    error
+}
+
+// checkStopped lists running and stopped containers in the background and counts
+// the stopped ones by the project the tree would put them in
+func (m Model) checkStopped() tea.Cmd {
+	client, opts := m.dockerClient, m.treeOptions()
+	return func() tea.Msg {
+		containers, err := client.ListAllContainers()
+		if err != nil {
+			return stoppedMsg{err: err}
+		}
+		counts := make(map[string]int)
+		for i := range containers {
+			c := &containers[i]
+			if c.State == "exited" || c.State == "dead" || c.State == "created" {
+				counts[model.ProjectName(c, opts)]++
+			}
+		}
+		return stoppedMsg{counts: counts}
+	}
+}
+
+// stoppedCheckDue starts a count of stopped containers once stoppedCheckInterval has
+// passed since the last one; nil unless grouping by project, and during replays
+func (m *Model) stoppedCheckDue() tea.Cmd {
+	if m.grouping != model.GroupByProject || m.replay != nil || m.dockerClient == nil || time.Since(m.stoppedAt) < stoppedCheckInterval {
+		return nil
+	}
+	m.stoppedAt = time.Now()
+	return m.checkStopped()
+}
+
+func (m Model) handleStopped(msg stoppedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		// Keep the last counts; the next check tries again
+		debuglog.Error("stopped containers check", msg.err)
+		return m, nil
+	}
+	m.stopped = msg.counts
+	return m, nil
+}
+
+// projectSummary sums up a project node, with the stopped containers from the last
+// count since the tree doesn't hold them
+func (m Model) projectSummary(node *model.TreeNode) model.ProjectSummary {
+	summary := node.Summary()
+	if m.grouping == model.GroupByProject {
+		summary.Stopped = m.stopped[node.Name]
+	}
+	return summary
+}
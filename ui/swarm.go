@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/docker"
+)
+
+// swarmNodes returns the sorted names of the swarm nodes the containers run on; empty
+// unless connected to a swarm manager
+func swarmNodes(containers []docker.ContainerInfo) []string {
+	seen := make(map[string]bool)
+	nodes := []string{}
+	for _, c := range containers {
+		if c.Node != "" && !seen[c.Node] {
+			seen[c.Node] = true
+			nodes = append(nodes, c.Node)
+		}
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// onNode keeps the containers running on the node selected with N, or all of them
+// when no node is selected
+func (m Model) onNode(containers containersMsg) containersMsg {
+	if m.nodeFilter == "" {
+		return containers
+	}
+	filtered := make(containersMsg, 0, len(containers))
+	for _, c := range containers {
+		if c.Node == m.nodeFilter {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
+
+// cycleNodeFilter selects the next swarm node to filter by, then all nodes again
+func (m *Model) cycleNodeFilter() tea.Cmd {
+	if len(m.nodes) == 0 {
+		m.status = "Not connected to a swarm manager"
+		return nil
+	}
+	next := m.nodes[0]
+	for i, node := range m.nodes {
+		if node == m.nodeFilter {
+			next = ""
+			if i+1 < len(m.nodes) {
+				next = m.nodes[i+1]
+			}
+		}
+	}
+	m.nodeFilter = next
+	if next == "" {
+		m.status = "Showing all nodes"
+	} else {
+		m.status = "Showing node " + next
+	}
+	return m.refreshContainers()
+}
+
+// remoteContainer reports whether c runs on another swarm node, setting the status
+// to say so: the connected daemon can't inspect it or act on it
+func (m *Model) remoteContainer(c *docker.ContainerInfo) bool {
+	if !c.Remote {
+		return false
+	}
+	m.status = c.Name + " runs on node " + c.Node + "; connect to that node to manage it"
+	return true
+}
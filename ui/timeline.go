@@ -0,0 +1,172 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/debuglog"
+	"github.com/ekinertac/dtop/docker"
+	"github.com/ekinertac/dtop/model"
+)
+
+const (
+	// timelineLength is how many events are kept per container
+	timelineLength = 50
+
+	// timelineShown is how many of the latest events the detail view lists
+	timelineShown = 10
+
+	// eventsRetry is how long to wait before resubscribing after the event stream fails
+	eventsRetry = 5 * time.Second
+
+	// stopWindow is how soon after a die its stop event arrives when the container
+	// was stopped on purpose; a die without one is counted as a death
+	stopWindow = 15 * time.Second
+)
+
+// containerEvents holds each container's daemon events, oldest first, by container ID
+type containerEvents map[string][]docker.ContainerEvent
+
+// containerEventMsg delivers a daemon event, or the end of the event stream when done
+type containerEventMsg struct {
+	event docker.ContainerEvent
+	done  bool
+	err   error
+	ch    <-chan containerEventMsg
+}
+
+// watchEventsMsg asks the model to subscribe to daemon events again
+type watchEventsMsg struct{}
+
+// waitForEvent delivers the next daemon event
+func waitForEvent(ch <-chan containerEventMsg) tea.Cmd {
+	return func() tea.Msg {
+		return <-ch
+	}
+}
+
+// watchEvents subscribes to the daemon's container events in the background
+func (m Model) watchEvents() tea.Cmd {
+	if m.replay != nil {
+		return nil
+	}
+	client := m.dockerClient
+	ch := make(chan containerEventMsg, 16)
+	go func() {
+		err := client.WatchEvents(func(e docker.ContainerEvent) {
+			ch <- containerEventMsg{event: e, ch: ch}
+		})
+		ch <- containerEventMsg{done: true, err: err, ch: ch}
+	}()
+	return waitForEvent(ch)
+}
+
+func (m Model) handleContainerEvent(msg containerEventMsg) (tea.Model, tea.Cmd) {
+	if msg.done {
+		// The timeline just misses events until the daemon is reachable again
+		debuglog.Error("events", msg.err)
+		return m, tea.Tick(eventsRetry, func(time.Time) tea.Msg {
+			return watchEventsMsg{}
+		})
+	}
+	m.recorder.Event(msg.event)
+	m.recordEvent(msg.event)
+	return m, waitForEvent(msg.ch)
+}
+
+// recordEvent adds an event to its container's timeline, dropping the oldest beyond
+// timelineLength
+func (m *Model) recordEvent(e docker.ContainerEvent) {
+	events := append(m.events[e.ContainerID], e)
+	if len(events) > timelineLength {
+		events = events[len(events)-timelineLength:]
+	}
+	m.events[e.ContainerID] = events
+}
+
+// died reports whether the die event at i wasn't followed by a stop, i.e. the
+// container exited on its own
+func died(events []docker.ContainerEvent, i int) bool {
+	for _, e := range events[i+1:] {
+		if e.Time.Sub(events[i].Time) > stopWindow {
+			break
+		}
+		if e.Action == docker.EventStop {
+			return false
+		}
+	}
+	return true
+}
+
+// eventText describes an event for the timeline, e.g. "died (exit code 137)"
+func eventText(events []docker.ContainerEvent, i int) string {
+	e := events[i]
+	switch e.Action {
+	case docker.EventStart:
+		return "started"
+	case docker.EventStop:
+		return "stopped"
+	case docker.EventDie:
+		if died(events, i) {
+			return "died (exit code " + e.Detail + ")"
+		}
+		return "exited (exit code " + e.Detail + ")"
+	case docker.EventOOM:
+		return "out of memory"
+	case docker.EventHealth:
+		return "health: " + e.Detail
+	}
+	return e.Action
+}
+
+// eventSummary counts the troubling events since a cutoff, e.g. "died 3×, unhealthy 1×"
+func eventSummary(events []docker.ContainerEvent, since time.Time) string {
+	deaths, ooms, unhealthy, starts := 0, 0, 0, 0
+	for i, e := range events {
+		if e.Time.Before(since) {
+			continue
+		}
+		switch {
+		case e.Action == docker.EventDie && died(events, i):
+			deaths++
+		case e.Action == docker.EventOOM:
+			ooms++
+		case e.Action == docker.EventHealth && e.Detail == "unhealthy":
+			unhealthy++
+		case e.Action == docker.EventStart:
+			starts++
+		}
+	}
+
+	parts := []string{}
+	for _, count := range []struct {
+		label string
+		n     int
+	}{{"died", deaths}, {"out of memory", ooms}, {"unhealthy", unhealthy}, {"started", starts}} {
+		if count.n > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d×", count.label, count.n))
+		}
+	}
+	if len(parts) == 0 {
+		return "quiet"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// eventDetailLines shows the container's recent daemon events, newest first, after
+// a summary of the last hour
+func (m Model) eventDetailLines(containerID string) []string {
+	events := m.events[containerID]
+	now := m.now()
+	rows := [][2]string{{"Last hour", eventSummary(events, now.Add(-time.Hour))}}
+	if len(events) == 0 {
+		rows[0][1] = "no events since dtop started"
+	}
+	for i := len(events) - 1; i >= 0 && i >= len(events)-timelineShown; i-- {
+		ago := model.HumanizeDuration(now.Sub(events[i].Time))
+		rows = append(rows, [2]string{events[i].Time.Format("Jan 02 15:04:05"), eventText(events, i) + ", " + ago + " ago"})
+	}
+	return detailSection("Events", rows)
+}
@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/docker"
+)
+
+// maxUndo is how many stops and removals ctrl+z can revert, most recent first
+const maxUndo = 10
+
+// undoAction reverts a stop or a removal
+type undoAction struct {
+	name        string
+	containerID string                    // Stopped container to start again
+	snapshot    *docker.ContainerSnapshot // Removed container to create again
+}
+
+// hint is the status bar prompt offering the undo
+func (u undoAction) hint() string {
+	if u.snapshot != nil {
+		return "ctrl+z recreates it"
+	}
+	return "ctrl+z starts it back"
+}
+
+// pushUndo makes u the next action ctrl+z reverts
+func (m *Model) pushUndo(u undoAction) {
+	m.undo = append(m.undo, u)
+	if len(m.undo) > maxUndo {
+		m.undo = m.undo[len(m.undo)-maxUndo:]
+	}
+}
+
+// stopContainer stops a container through the action queue; ctrl+z starts it back
+func (m *Model) stopContainer(containerID, name string) tea.Cmd {
+	return m.queueAction(containerID, name, "stop", "stopping", func() error {
+		return m.dockerClient.StopContainer(containerID)
+	}, func() *undoAction {
+		return &undoAction{name: name, containerID: containerID}
+	})
+}
+
+// removeContainer removes a container through the action queue, keeping its
+// configuration so ctrl+z can create it again
+func (m *Model) removeContainer(containerID, name string) tea.Cmd {
+	var snapshot *docker.ContainerSnapshot
+	return m.queueAction(containerID, name, "remove", "removing", func() error {
+		// Without a snapshot the removal still goes ahead; it just can't be undone
+		snapshot, _ = m.dockerClient.SnapshotContainer(containerID)
+		return m.dockerClient.RemoveContainer(containerID)
+	}, func() *undoAction {
+		if snapshot == nil {
+			return nil
+		}
+		return &undoAction{name: name, snapshot: snapshot}
+	})
+}
+
+// undoLast reverts the most recent stop or removal: a stopped container is started,
+// a removed one is created again from its snapshot
+func (m Model) undoLast() (tea.Model, tea.Cmd) {
+	if len(m.undo) == 0 {
+		m.status = "Nothing to undo"
+		return m, nil
+	}
+	if m.readOnly {
+		m.status = "Read-only: undo is disabled (R to allow changes)"
+		return m, nil
+	}
+	u := m.undo[len(m.undo)-1]
+	m.undo = m.undo[:len(m.undo)-1]
+
+	if u.snapshot == nil {
+		return m, m.containerAction(u.containerID, u.name, "start", "starting", func() error {
+			return m.dockerClient.StartContainer(u.containerID)
+		})
+	}
+
+	client, audit := m.dockerClient, m.audit
+	m.status = "Recreating " + u.name + "…"
+	recreate := func() tea.Msg {
+		_, err := client.RestoreContainer(u.snapshot)
+		audit.Record("recreate", u.name, err)
+		if err != nil {
+			return statusMsg(fmt.Sprintf("recreate %s failed: %v", u.name, err))
+		}
+		return statusMsg(u.name + ": recreate done")
+	}
+	return m, tea.Sequence(recreate, m.refreshContainers())
+}
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/debuglog"
+	"github.com/ekinertac/dtop/update"
+)
+
+// updateMsg carries a newer dtop version found by the update check
+type updateMsg string
+
+// SetUpdateCheck makes the monitor check on start whether a release newer than
+// version exists; without it, dtop never contacts GitHub
+func (m *Model) SetUpdateCheck(version string) {
+	m.version = version
+}
+
+// checkUpdate looks for a newer release when the update check is on. Failures
+// stay quiet: being offline shouldn't put an error in the status bar.
+func (m Model) checkUpdate() tea.Cmd {
+	if m.version == "" {
+		return nil
+	}
+	version := m.version
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		latest, err := update.Check(ctx, version)
+		if err != nil {
+			debuglog.Error("update check", err)
+			return nil
+		}
+		if latest == "" {
+			return nil
+		}
+		return updateMsg(latest)
+	}
+}
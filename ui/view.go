@@ -5,7 +5,10 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/ekinertac/dtop/docker"
+	"github.com/ekinertac/dtop/i18n"
 	"github.com/ekinertac/dtop/model"
+	"github.com/mattn/go-runewidth"
 )
 
 // renderProgressBar creates a simple progress bar
@@ -22,33 +25,76 @@ func renderProgressBar(percent float64, width int) string {
 		filled = width
 	}
 
+	// Screen readers would read every block
+	if accessibleMode {
+		return strings.Repeat(" ", width)
+	}
 	bar := strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
 	return bar
 }
 
+// Usage percentages at which gauges outside the main table turn yellow/red; the
+// main table uses the configured thresholds
+const (
+	gaugeWarnPerc   = 60.0
+	gaugeDangerPerc = 85.0
+)
+
+// gaugeColor picks the gauge color for a usage percentage
+func gaugeColor(percent float64) lipgloss.Color {
+	return usageColor(percent, gaugeWarnPerc, gaugeDangerPerc)
+}
+
+// usageColor picks the color for a usage percentage given its warn/danger thresholds
+func usageColor(percent, warn, danger float64) lipgloss.Color {
+	switch {
+	case percent >= danger:
+		return dangerColor
+	case percent >= warn:
+		return warningColor
+	}
+	return successColor
+}
+
+// renderGauge renders a progress bar whose filled part is colored by threshold
+func renderGauge(percent float64, width int) string {
+	return renderColoredGauge(percent, width, gaugeColor(percent))
+}
+
+// renderColoredGauge renders a progress bar whose filled part has the given color
+func renderColoredGauge(percent float64, width int, color lipgloss.Color) string {
+	bar := renderProgressBar(percent, width)
+	filled := strings.Count(bar, "█")
+	return lipgloss.NewStyle().Foreground(color).Render(strings.Repeat("█", filled)) +
+		lipgloss.NewStyle().Foreground(mutedColor).Render(strings.Repeat("░", width-filled))
+}
+
 // formatNetBytes formats network bytes with units
 func formatNetBytes(bytes uint64) string {
+	return compactBytes(bytes, i18n.Number)
+}
+
+// compactBytes formats a byte count for a narrow column, e.g. "1.5M", with format
+// printing the number
+func compactBytes(bytes uint64, format func(string, ...any) string) string {
 	const unit = 1024
 	if bytes < unit {
 		return "0"
 	}
-	
+
 	div := uint64(unit)
 	exp := 0
 	for n := bytes / unit; n >= unit && exp < 4; n /= unit {
 		div *= unit
 		exp++
 	}
-	
+
 	value := float64(bytes) / float64(div)
-	units := []string{"B", "K", "M", "G", "T"}
-	
+	units := []string{"K", "M", "G", "T", "P"}
 	if value >= 100 {
-		return fmt.Sprintf("%.0f%s", value, units[exp])
-	} else if value >= 10 {
-		return fmt.Sprintf("%.1f%s", value, units[exp])
+		return format("%.0f%s", value, units[exp])
 	}
-	return fmt.Sprintf("%.1f%s", value, units[exp])
+	return format("%.1f%s", value, units[exp])
 }
 
 const (
@@ -58,12 +104,76 @@ const (
 	colCPUWidth    = 12 // Wider for progress bar
 	colMemWidth    = 12 // Wider for progress bar
 	colNetWidth    = 14 // RX/TX column
+	colDiskWidth   = 14 // Block I/O read/write per second
+	colPIDsWidth   = 10 // Current/limit process count
+	colNodeWidth   = 16 // Swarm node column, shown on swarm managers
+	colImageWidth  = 20 // Optional image name:tag column
+	colGPUWidth    = 16 // Optional GPU util + memory column
+	colLogsWidth   = 13 // Optional log lines + bytes per second column
 	colUptimeWidth = 10
+
+	maxGaugeWidth = 32 // CPU/MEM columns grow up to this width on wide terminals
 )
 
+// renderGaugeColumn renders "NN% bar" (or another label before the bar) padded to
+// width, plain for the selected row or in the given color otherwise; the label keeps
+// the normal text color while usage is below the thresholds
+func renderGaugeColumn(text string, percent float64, width int, selected bool, color lipgloss.Color) string {
+	barWidth := width - len(text) - 2
+	pad := "  "
+	if selected {
+		return text + renderProgressBar(percent, barWidth) + pad
+	}
+	label := containerStyle.Render(text)
+	if color != successColor {
+		label = lipgloss.NewStyle().Foreground(color).Render(text)
+	}
+	return label + renderColoredGauge(percent, barWidth, color) + pad
+}
+
+// usageColors picks the CPU and memory colors of a container from the configured thresholds
+func (m Model) usageColors(c *docker.ContainerInfo) (cpu, mem lipgloss.Color) {
+	t := m.config.Thresholds
+	return usageColor(c.CPUPerc, t.CPUWarn, t.CPUDanger), usageColor(c.MemPerc, t.MemoryWarn, t.MemoryDanger)
+}
+
+// rowColor is the color of a container row when whole-row thresholds are on: the
+// more severe of the CPU and memory colors, or false while both are below the thresholds
+func (m Model) rowColor(c *docker.ContainerInfo) (lipgloss.Color, bool) {
+	if !m.config.Thresholds.Row {
+		return "", false
+	}
+	cpu, mem := m.usageColors(c)
+	switch {
+	case cpu == dangerColor || mem == dangerColor:
+		return dangerColor, true
+	case cpu == warningColor || mem == warningColor:
+		return warningColor, true
+	}
+	return "", false
+}
+
+// gaugeTexts returns the labels before the CPU and memory gauges: "NN% " of the
+// limit, or cores and bytes used when absolute units are on
+func (m Model) gaugeTexts(c *docker.ContainerInfo) (cpu, mem string) {
+	if m.absoluteUnits {
+		return fmt.Sprintf("%5s ", formatCores(c.CPUPerc)), fmt.Sprintf("%5s ", formatNetBytes(c.Memory.Usage))
+	}
+	return fmt.Sprintf("%3.0f%% ", c.CPUPerc), fmt.Sprintf("%3.0f%% ", c.MemPerc)
+}
+
+// formatCores formats a docker-style CPU percentage (100% per core) as cores used
+func formatCores(percent float64) string {
+	cores := percent / 100
+	if cores >= 10 {
+		return i18n.Number("%.1fc", cores)
+	}
+	return i18n.Number("%.2fc", cores)
+}
+
 var (
 	// Colors
-	primaryColor    = lipgloss.Color("#00D9FF")
+	primaryColor    = defaultAccent
 	successColor    = lipgloss.Color("#00FF87")
 	warningColor    = lipgloss.Color("#FFAF00")
 	dangerColor     = lipgloss.Color("#FF5555")
@@ -98,6 +208,11 @@ var (
 	stoppedStyle = lipgloss.NewStyle().
 			Foreground(dangerColor)
 
+	flashStyle = lipgloss.NewStyle().
+			Bold(true).
+			Background(dangerColor).
+			Foreground(backgroundColor)
+
 	modalStyle = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
 			BorderForeground(primaryColor).
@@ -113,19 +228,92 @@ var (
 				Background(primaryColor).
 				PaddingLeft(2)
 
+	statusStyle = lipgloss.NewStyle().
+			Foreground(warningColor)
+
 	helpStyle = lipgloss.NewStyle().
 			Foreground(mutedColor).
 			MarginTop(1)
+
+	// tagStyle marks the active profile and read-only mode in the title
+	tagStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(backgroundColor).
+			Background(primaryColor).
+			Padding(0, 1)
 )
 
+// defaultAccent is the highlight color when the config doesn't set one
+const defaultAccent = lipgloss.Color("#00D9FF")
+
+// applyAccent recolors the accent-colored styles; an empty color restores the default
+func applyAccent(color string) {
+	primaryColor = defaultAccent
+	if color != "" {
+		primaryColor = lipgloss.Color(color)
+	}
+	titleStyle = titleStyle.Foreground(primaryColor)
+	projectStyle = projectStyle.Foreground(primaryColor)
+	modalStyle = modalStyle.BorderForeground(primaryColor)
+	menuSelectedStyle = menuSelectedStyle.Background(primaryColor)
+	tagStyle = tagStyle.Background(primaryColor)
+}
+
+// renderTitle renders a view title followed by the profile and read-only tags
+func (m Model) renderTitle(title string) string {
+	s := titleStyle.Render(title)
+	if m.config.Profile != "" {
+		s += " " + tagStyle.Render(m.config.Profile)
+	}
+	if m.readOnly {
+		s += " " + tagStyle.Render("read-only")
+	}
+	if m.nodeFilter != "" {
+		s += " " + tagStyle.Render("node "+m.nodeFilter)
+	}
+	if m.hiddenCount > 0 && m.showHidden {
+		s += " " + tagStyle.Render(fmt.Sprintf("showing %d hidden", m.hiddenCount))
+	} else if m.hiddenCount > 0 {
+		s += " " + tagStyle.Render(fmt.Sprintf("%d hidden", m.hiddenCount))
+	}
+	if m.showLogRate {
+		count := 0
+		for _, c := range m.tree.Containers() {
+			if chatty(c) {
+				count++
+			}
+		}
+		if count > 0 {
+			s += " " + tagStyle.Render(fmt.Sprintf("%d chatty", count))
+		}
+	}
+	if stale := len(m.stale); stale > 0 && !m.config.Cleanup.AutoRemove {
+		s += " " + tagStyle.Render(fmt.Sprintf("%d to clean up", stale))
+	}
+	return s
+}
+
+// printableASCII reports whether every byte of s is a printable ASCII character
+func printableASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < ' ' || s[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
 // truncateOrPad truncates or pads a string to a fixed width
 func truncateOrPad(s string, width int) string {
-	// Use rune count for proper Unicode handling
-	runes := []rune(s)
-	if len(runes) > width {
-		return string(runes[:width-3]) + "..."
+	// Printable ASCII is one column per byte, which spares measuring most cells
+	if len(s) <= width && printableASCII(s) {
+		return s + strings.Repeat(" ", width-len(s))
+	}
+	// Measure display width so wide characters (CJK, emoji) keep columns aligned
+	if runewidth.StringWidth(s) > width {
+		s = runewidth.Truncate(s, width, "...")
 	}
-	return s + strings.Repeat(" ", width-len(runes))
+	return runewidth.FillRight(s, width)
 }
 
 func (m Model) renderView() string {
@@ -139,33 +327,115 @@ func (m Model) renderView() string {
 		return m.renderLogs()
 	case ViewModeMenu:
 		return m.renderMenu()
+	case ViewModeForm:
+		return m.renderForm()
+	case ViewModeDetail:
+		return m.renderDetail()
+	case ViewModeAudit:
+		return m.renderAudit()
+	case ViewModeJump:
+		return m.renderJump()
+	case ViewModeCompare:
+		return m.renderCompare()
+	case ViewModeOutput:
+		return m.renderOutput()
+	case ViewModeHost:
+		return m.renderHost()
+	case ViewModePull:
+		return m.renderPull()
+	case ViewModeProcesses:
+		return m.renderProcesses()
+	case ViewModeConnections:
+		return m.renderConnections()
+	case ViewModeBuild:
+		return m.renderBuild()
+	case ViewModeCleanup:
+		return m.renderCleanup()
+	case ViewModeBatch:
+		return m.renderBatch()
+	case ViewModeLogSearch:
+		return m.renderLogSearch()
 	}
 
 	var content strings.Builder
 	var footer strings.Builder
 
-	// Title
-	content.WriteString(titleStyle.Render("dtop - Docker Container Monitor"))
+	// Title, with a breadcrumb while zoomed into a project
+	title := i18n.T("dtop - Docker Container Monitor")
+	switch m.grouping {
+	case model.GroupByProject:
+	case model.GroupByNone:
+		title += " · ungrouped"
+	default:
+		title += " · by " + string(m.grouping)
+	}
+	if m.order != model.OrderByName {
+		title += " · sorted by " + string(m.order)
+	}
+	if m.tree.Zoom != "" {
+		title += " › " + m.tree.Zoom
+	}
+	if m.replay != nil {
+		title += "  " + m.replayTitle()
+	}
+	content.WriteString(m.renderTitle(title))
 	content.WriteString("\n\n")
+	if m.hostBarHeight() > 0 {
+		content.WriteString(m.renderHostBar())
+		content.WriteString("\n")
+	}
 
-	// Header with fixed column widths
-	header := truncateOrPad("NAME", colNameWidth) + " " +
-		truncateOrPad("STATUS", colStatusWidth) + " " +
-		truncateOrPad("CPU", colCPUWidth) + " " +
-		truncateOrPad("MEMORY", colMemWidth) + " " +
-		truncateOrPad("NET RX/TX", colNetWidth) + " " +
-		"UPTIME"
+	// Header; the columns follow the terminal width. Accessible rows are sentences
+	// rather than columns, so they get a hint instead.
+	l := m.layout()
+	cpuHeader, memHeader := "CPU", "MEMORY"
+	if m.absoluteUnits {
+		cpuHeader, memHeader = "CPU cores", "MEMORY used"
+	}
+	header := truncateOrPad(i18n.T("NAME"), l.name) + " " +
+		truncateOrPad(i18n.T("STATUS"), l.status) + " " +
+		truncateOrPad(i18n.T(cpuHeader), l.gauge)
+	if !l.narrow {
+		header += " " + truncateOrPad(i18n.T(memHeader), l.gauge) + " " +
+			truncateOrPad(i18n.T("NET RX/TX"), colNetWidth) + " "
+		if !l.compact {
+			header += truncateOrPad(i18n.T("DISK R/W /s"), colDiskWidth) + " " +
+				truncateOrPad(i18n.T("PIDS"), colPIDsWidth) + " "
+		}
+		if l.node {
+			header += truncateOrPad(i18n.T("NODE"), colNodeWidth) + " "
+		}
+		if l.id {
+			header += truncateOrPad(i18n.T("ID"), colIDWidth) + " "
+		}
+		if l.image {
+			header += truncateOrPad(i18n.T("IMAGE"), colImageWidth) + " "
+		}
+		if l.ports {
+			header += truncateOrPad(i18n.T("PORTS"), colPortsWidth) + " "
+		}
+		if l.gpu {
+			header += truncateOrPad(i18n.T("GPU"), colGPUWidth) + " "
+		}
+		if l.logs {
+			header += truncateOrPad(i18n.T("LOGS/s"), colLogsWidth) + " "
+		}
+		if m.absoluteTimes {
+			header += i18n.T("STARTED")
+		} else {
+			header += i18n.T("UPTIME")
+		}
+	}
+	if accessibleMode {
+		header = "Projects and containers, one per line. Press ? to describe the selected one."
+	}
 	content.WriteString(headerStyle.Render(header))
 	content.WriteString("\n")
 
-	// Calculate visible height (total - title/header - footer)
-	// Title + blank = 2, Header = 1, Footer + blank = 2, Total overhead = 5
-	visibleHeight := m.height - 5
-	if visibleHeight < 1 {
-		visibleHeight = 1
-	}
+	visibleHeight := m.treeHeight()
 
-	// Tree view with viewport
+	// Tree view with viewport; rows unchanged since the last frame come from the row cache
+	m.rows.next()
 	if m.tree != nil && len(m.tree.Flat) > 0 {
 		// Calculate viewport boundaries
 		viewportEnd := m.viewportTop + visibleHeight
@@ -173,11 +443,15 @@ func (m Model) renderView() string {
 			viewportEnd = len(m.tree.Flat)
 		}
 
-		// Render only visible items
+		// Render only visible items; the first containers get their number key
+		quick := make(map[*model.TreeNode]int, maxQuickRows)
+		for i, node := range m.quickRows() {
+			quick[node] = i + 1
+		}
 		renderedLines := 0
 		for i := m.viewportTop; i < viewportEnd; i++ {
 			node := m.tree.Flat[i]
-			line := m.renderNode(node, i == m.tree.Selected)
+			line := m.renderNode(node, i == m.tree.Selected, quick[node])
 			content.WriteString(line)
 			content.WriteString("\n")
 			renderedLines++
@@ -197,21 +471,47 @@ func (m Model) renderView() string {
 			footer.WriteString(" ")
 		}
 	} else {
-		content.WriteString("No containers found\n")
+		content.WriteString(i18n.T("No containers found") + "\n")
 		// Fill space
 		for i := 0; i < visibleHeight-1; i++ {
 			content.WriteString("\n")
 		}
 	}
 
+	if m.showLogStrip {
+		content.WriteString(m.renderLogStrip())
+	}
+
+	// Status bar message (action results, progress)
+	if m.status != "" {
+		footer.WriteString(statusStyle.Render(m.status))
+		footer.WriteString("  ")
+	}
+	if m.newVersion != "" {
+		footer.WriteString(lipgloss.NewStyle().Foreground(mutedColor).Render("dtop v" + m.newVersion + " is available: dtop self-update"))
+		footer.WriteString("  ")
+	}
+
 	// Help text (sticky footer)
-	helpText := "↑↓/PgUp/PgDn:navigate  ←→:collapse/expand  enter:menu  q:quit"
+	helpText := "↑↓/PgUp/PgDn:navigate  ←→:collapse/expand  E/C:all  enter:menu  1-9 r/s/l:quick action  ::jump  m/c:mark/compare  f:pin  x/X:hide/reveal  z:zoom  g:group by  o:sort  G:gpu  I:image  u:start times  L:log rate  U:units  H:host bar  N:node  e:note  d:details  i:host  n:new  b:build  D:cleanup  t:log strip  a:audit  S:sizing  Q:quotas  p:ports  /:search logs  ctrl+z:undo  P:profile  R:read-only  ?:describe  q:quit"
+	if m.replay != nil {
+		helpText = "↑↓/PgUp/PgDn:navigate  ←→:collapse/expand  E/C:all  space:pause  +/-:speed  ::jump  m/c:mark/compare  z:zoom  d:details  a:audit  ?:describe  q:quit"
+	}
+	helpText = i18n.Help(helpText)
+	if accessibleMode {
+		// Arrow glyphs are read out as their Unicode names
+		helpText = strings.NewReplacer("↑↓", "up/down", "←→", "left/right").Replace(helpText)
+	}
 	footer.WriteString(helpStyle.Render(helpText))
 
 	return content.String() + "\n" + footer.String()
 }
 
-func (m Model) renderNode(node *model.TreeNode, selected bool) string {
+// renderNode renders a tree row; number is the row's number key, or 0
+func (m Model) renderNode(node *model.TreeNode, selected bool, number int) string {
+	if accessibleMode {
+		return m.accessibleRow(node, selected, number)
+	}
 	depth := m.tree.GetDepth(node)
 	indent := strings.Repeat("  ", depth)
 
@@ -223,14 +523,39 @@ func (m Model) renderNode(node *model.TreeNode, selected bool) string {
 		if !node.Expanded {
 			icon = "▶"
 		}
+		if m.projectMarks[node.Name] {
+			icon += " ●"
+		}
 		projectName := fmt.Sprintf("%s %s (%d)", icon, node.Name, len(node.Children))
+		if m.isFavorite(node) {
+			projectName = fmt.Sprintf("%s ★ %s (%d)", icon, node.Name, len(node.Children))
+		}
+		if m.noteOf(node) != "" {
+			projectName += noteMarker
+		}
 		fullText := indent + projectName
 		
 		// Pad to full row width for consistent selection highlight
-		totalWidth := colNameWidth + 1 + colStatusWidth + 1 + colCPUWidth + 1 + colMemWidth + 1 + colNetWidth + 1 + colUptimeWidth
+		l := m.layout()
+		totalWidth := l.rowWidth()
 		paddedText := truncateOrPad(fullText, totalWidth)
 		
-		if selected {
+		// Collapsed projects show a health summary and aggregate usage in the stat columns
+		if m.flashing(node.Name) && !selected {
+			// A container of this project crashed and is no longer listed
+			line = flashStyle.Render(paddedText)
+		} else if progress := m.projectProgressText(node.Name); progress != "" {
+			// A project-wide action is running; its progress replaces the summary
+			name := truncateOrPad(fullText, l.name)
+			progressText := truncateOrPad(progress, totalWidth-l.name-1)
+			if selected {
+				line = selectedStyle.Render(name + " " + progressText)
+			} else {
+				line = projectStyle.Render(name) + " " + statusStyle.Render(progressText)
+			}
+		} else if !node.Expanded {
+			line = m.renderProjectSummary(node, fullText, totalWidth, selected)
+		} else if selected {
 			line = selectedStyle.Render(paddedText)
 		} else {
 			line = projectStyle.Render(paddedText)
@@ -240,73 +565,353 @@ func (m Model) renderNode(node *model.TreeNode, selected bool) string {
 		if node.Container == nil {
 			return ""
 		}
+		if number > 0 {
+			// The number takes the place of the indent, keeping names aligned
+			indent = fmt.Sprintf("%-*d", len(indent), number)
+		}
+		line = m.rows.render(m.containerRow(node, indent, selected))
+	}
 
-		c := node.Container
-		
-		// Prepare each column with fixed width
-		nameText := indent + "  " + c.Name
-		name := truncateOrPad(nameText, colNameWidth)
-		
-		// Status column (apply color after padding)
-		statusText := truncateOrPad(c.Status, colStatusWidth)
-		var status string
-		if c.State == "running" {
-			status = runningStyle.Render(statusText)
-		} else {
-			status = stoppedStyle.Render(statusText)
+	return line
+}
+
+// containerRow is what a container row shows before styling: its padded column texts
+// and the choices that pick their styles. Rows that compare equal render the same.
+type containerRow struct {
+	selected    bool
+	flashing    bool           // Just crashed or turned unhealthy
+	placeholder bool           // A compose service without a container
+	rowColor    lipgloss.Color // Whole-row threshold color, empty below the thresholds
+	remote      bool           // Runs on another swarm node
+	narrow      bool           // Name, status and CPU only
+
+	name, status, net, disk, pids string
+	node, id, image, ports, gpu   string
+	logs, uptime                  string
+
+	statusStyle, pidsStyle, imageStyle, logsStyle *lipgloss.Style
+
+	gauge              int
+	cpuLabel, memLabel string
+	cpuPerc, memPerc   float64
+	cpuColor, memColor lipgloss.Color
+	noMemory           bool // Memory isn't reported; N/A replaces its gauge
+}
+
+// containerRow collects what the row of a container node shows
+func (m Model) containerRow(node *model.TreeNode, indent string, selected bool) containerRow {
+	c := node.Container
+	l := m.layout()
+	r := containerRow{
+		selected:    selected,
+		flashing:    m.flashing(c.ID),
+		placeholder: isPlaceholder(c),
+		remote:      c.Remote,
+		narrow:      l.narrow,
+		gauge:       l.gauge,
+		cpuPerc:     c.CPUPerc,
+		memPerc:     c.MemPerc,
+		noMemory:    c.MemUsage == "N/A",
+	}
+	r.rowColor, _ = m.rowColor(c)
+
+	// Prepare each column with fixed width
+	displayName := model.DisplayName(c)
+	if node.Parent != nil && node.Parent.Name == model.FavoritesProject {
+		// Favorites mix projects, so "web #1" alone would be ambiguous
+		displayName = c.Name
+	}
+	if m.noteOf(node) != "" {
+		displayName += noteMarker
+	}
+	prefix := indent + "  "
+	if marker := m.projectStepMarker(c.ID); marker != "" {
+		prefix = indent + marker + " "
+	} else if m.isMarked(c.ID) {
+		prefix = indent + "● "
+	} else if m.showHidden && m.isHidden(c) {
+		prefix = indent + "○ "
+	}
+	// Long names lose their middle, so replicas stay distinguishable in narrow columns
+	r.name = truncateOrPad(prefix+truncateMiddle(displayName, l.name-runewidth.StringWidth(prefix)), l.name)
+
+	// Status column (apply color after padding)
+	r.status = truncateOrPad(c.Status, l.status)
+	if countdown := m.stopCountdownText(c.ID); countdown != "" {
+		r.status = truncateOrPad(countdown, l.status)
+		r.statusStyle = &statusStyle
+	} else if action := m.actionText(c.ID); action != "" {
+		r.status = truncateOrPad(action, l.status)
+		r.statusStyle = &statusStyle
+	} else if c.State == "running" {
+		r.statusStyle = &runningStyle
+	} else {
+		r.statusStyle = &stoppedStyle
+	}
+
+	// CPU and memory with bars colored by usage
+	r.cpuLabel, r.memLabel = m.gaugeTexts(c)
+	r.cpuColor, r.memColor = m.usageColors(c)
+
+	// Network RX/TX and, unless the table is compact, block I/O rates
+	r.net = truncateOrPad(formatNetBytes(c.NetRx)+"/"+formatNetBytes(c.NetTx), colNetWidth)
+	if !l.compact {
+		r.disk = truncateOrPad(formatNetBytes(uint64(c.Block.ReadRate))+"/"+formatNetBytes(uint64(c.Block.WriteRate)), colDiskWidth) + " "
+		r.pids = truncateOrPad(formatPIDs(c.PIDs, c.PIDsLimit), colPIDsWidth) + " "
+	}
+
+	// PIDs, highlighted when approaching the pids limit
+	r.pidsStyle = &containerStyle
+	if ratio := pidsRatio(c.PIDs, c.PIDsLimit); ratio >= pidsDangerRatio {
+		r.pidsStyle = &stoppedStyle
+	} else if ratio >= pidsWarnRatio {
+		r.pidsStyle = &statusStyle
+	}
+
+	uptimeText := model.ContainerUptime(c)
+	if m.absoluteTimes {
+		uptimeText = model.ContainerStarted(c)
+	}
+	r.uptime = truncateOrPad(uptimeText, colUptimeWidth)
+
+	// Node column appears on swarm managers
+	if l.node {
+		r.node = truncateOrPad(c.Node, colNodeWidth) + " "
+	}
+
+	// ID and ports columns appear on wide terminals
+	if l.id {
+		r.id = truncateOrPad(c.ID, colIDWidth) + " "
+	}
+	if l.ports {
+		r.ports = truncateOrPad(formatPorts(c.Ports, colPortsWidth), colPortsWidth) + " "
+	}
+
+	// Image column is optional; images that lost their tag are red, pinned digests yellow
+	if l.image {
+		r.image = truncateOrPad(docker.ShortImage(c.Image), colImageWidth) + " "
+		switch {
+		case c.ImageDangling:
+			r.imageStyle = &stoppedStyle
+		case c.ImageByDigest():
+			r.imageStyle = &statusStyle
+		default:
+			r.imageStyle = &containerStyle
 		}
-		
-		// CPU with progress bar
-		cpuBar := renderProgressBar(c.CPUPerc, 5)
-		cpuText := fmt.Sprintf("%3.0f%% %s", c.CPUPerc, cpuBar)
-		cpu := truncateOrPad(cpuText, colCPUWidth)
-		
-		// Memory with progress bar
-		memBar := renderProgressBar(c.MemPerc, 5)
-		memText := fmt.Sprintf("%3.0f%% %s", c.MemPerc, memBar)
-		mem := truncateOrPad(memText, colMemWidth)
-		
-		// Network RX/TX
-		netRxText := formatNetBytes(c.NetRx)
-		netTxText := formatNetBytes(c.NetTx)
-		netText := fmt.Sprintf("%s/%s", netRxText, netTxText)
-		net := truncateOrPad(netText, colNetWidth)
-		
-		uptime := truncateOrPad(model.FormatUptime(c.CreatedAt), colUptimeWidth)
+	}
 
-		// Build the full line
-		if selected {
-			// For selected rows, apply background to entire row using padded columns
-			fullText := name + " " + statusText + " " + cpu + " " + mem + " " + net + " " + uptime
-			line = selectedStyle.Render(fullText)
-		} else {
-			// For unselected rows, apply colors per column
-			line = containerStyle.Render(name) + " " + status + " " + 
-				containerStyle.Render(cpu) + " " + 
-				containerStyle.Render(mem) + " " + 
-				containerStyle.Render(net) + " " + 
-				containerStyle.Render(uptime)
+	// GPU column is optional; rendered as an extra segment before uptime
+	if l.gpu {
+		r.gpu = truncateOrPad(formatGPU(c), colGPUWidth) + " "
+	}
+
+	// Log rate column is optional; chatty containers are highlighted
+	if l.logs {
+		r.logs = truncateOrPad(formatLogRate(c), colLogsWidth) + " "
+		r.logsStyle = &containerStyle
+		if chatty(c) {
+			r.logsStyle = &statusStyle
 		}
 	}
+	return r
+}
 
-	return line
+// render styles the row
+func (r containerRow) render() string {
+	// Rows drawn in a single style (selection, flash, thresholds) use plain gauges
+	plainRow := func() string {
+		if r.narrow {
+			return r.name + " " + r.status + " " + renderGaugeColumn(r.cpuLabel, r.cpuPerc, r.gauge, true, r.cpuColor)
+		}
+		plainMem := renderGaugeColumn(r.memLabel, r.memPerc, r.gauge, true, r.memColor)
+		if r.noMemory {
+			plainMem = truncateOrPad(" N/A", r.gauge)
+		}
+		return r.name + " " + r.status + " " + renderGaugeColumn(r.cpuLabel, r.cpuPerc, r.gauge, true, r.cpuColor) + " " + plainMem + " " + r.net + " " + r.disk + r.pids + r.node + r.id + r.image + r.ports + r.gpu + r.logs + r.uptime
+	}
+
+	switch {
+	case r.selected:
+		// For selected rows, apply background to entire row using padded columns
+		return selectedStyle.Render(plainRow())
+	case r.flashing:
+		// Just crashed or turned unhealthy; gauges are drawn plain under the highlight
+		return flashStyle.Render(plainRow())
+	case r.placeholder:
+		// A compose service without a container; greyed out
+		return lipgloss.NewStyle().Foreground(mutedColor).Render(plainRow())
+	case r.rowColor != "":
+		// Over a threshold with whole-row coloring; gauges are drawn plain in the row color
+		return lipgloss.NewStyle().Foreground(r.rowColor).Render(plainRow())
+	}
+
+	// For unselected rows, apply colors per column
+	cpu := renderGaugeColumn(r.cpuLabel, r.cpuPerc, r.gauge, false, r.cpuColor)
+	if r.narrow {
+		return containerStyle.Render(r.name) + " " + r.statusStyle.Render(r.status) + " " + cpu
+	}
+	mem := renderGaugeColumn(r.memLabel, r.memPerc, r.gauge, false, r.memColor)
+	if r.noMemory {
+		// Not reported by the daemon, or stats not fetched yet
+		mem = containerStyle.Render(truncateOrPad(" N/A", r.gauge))
+	}
+	// Optional columns; containers on other nodes are dimmed
+	node, image, logs := "", "", ""
+	if r.node != "" {
+		node = containerStyle.Render(r.node)
+		if r.remote {
+			node = lipgloss.NewStyle().Foreground(mutedColor).Render(r.node)
+		}
+	}
+	if r.imageStyle != nil {
+		image = r.imageStyle.Render(r.image)
+	}
+	if r.logsStyle != nil {
+		logs = r.logsStyle.Render(r.logs)
+	}
+	return containerStyle.Render(r.name) + " " + r.statusStyle.Render(r.status) + " " +
+		cpu + " " +
+		mem + " " +
+		containerStyle.Render(r.net) + " " +
+		containerStyle.Render(r.disk) +
+		r.pidsStyle.Render(r.pids) +
+		node +
+		containerStyle.Render(r.id) +
+		image +
+		containerStyle.Render(r.ports) +
+		containerStyle.Render(r.gpu) +
+		logs +
+		containerStyle.Render(r.uptime)
+}
+
+// renderProjectSummary renders a collapsed project row with "5 ▲ 1 ■ 1 ✖" counts
+// in the status column and summed CPU/memory
+func (m Model) renderProjectSummary(node *model.TreeNode, nameText string, totalWidth int, selected bool) string {
+	summary := m.projectSummary(node)
+	l := m.layout()
+
+	name := truncateOrPad(nameText, l.name)
+
+	// Plain text first so the column can be padded before colors are applied
+	parts := []string{fmt.Sprintf("%d ▲", summary.Running)}
+	styled := []string{runningStyle.Render(parts[0])}
+	if summary.Stopped > 0 {
+		part := fmt.Sprintf("%d ■", summary.Stopped)
+		parts = append(parts, part)
+		styled = append(styled, headerStyle.Render(part))
+	}
+	if summary.Unhealthy > 0 {
+		part := fmt.Sprintf("%d ✖", summary.Unhealthy)
+		parts = append(parts, part)
+		styled = append(styled, stoppedStyle.Render(part))
+	}
+	plain := strings.Join(parts, " ")
+	statusText := truncateOrPad(plain, l.status)
+	statusPad := ""
+	if strings.HasPrefix(statusText, plain) {
+		statusPad = statusText[len(plain):]
+	} else {
+		// Cut short in a narrow status column; colors would split the ellipsis
+		styled = []string{statusText}
+	}
+
+	gauge := l.gauge
+	cpuText := fmt.Sprintf("%3.0f%%", summary.CPUPerc)
+	if m.absoluteUnits {
+		cpuText = fmt.Sprintf("%5s", formatCores(summary.CPUPerc))
+	}
+	cpu := truncateOrPad(cpuText, gauge)
+	// Memory follows unless the table is narrow
+	mem := ""
+	if !l.narrow {
+		mem = " " + truncateOrPad(formatNetBytes(summary.MemUsage), gauge)
+	}
+
+	used := l.name + 1 + l.status + 1 + gauge + len(mem)
+	rest := strings.Repeat(" ", max(totalWidth-used, 0))
+
+	if selected {
+		return selectedStyle.Render(name + " " + statusText + " " + cpu + mem + rest)
+	}
+	return projectStyle.Render(name) + " " + strings.Join(styled, " ") + statusPad + " " +
+		projectStyle.Render(cpu) + projectStyle.Render(mem) + rest
+}
+
+// Fractions of the pids limit at which the PIDS column turns yellow/red
+const (
+	pidsWarnRatio   = 0.8
+	pidsDangerRatio = 0.95
+)
+
+// formatPIDs formats the process count as "current/limit", or just "current" when unlimited
+func formatPIDs(current, limit uint64) string {
+	if limit == 0 {
+		return fmt.Sprintf("%d", current)
+	}
+	return fmt.Sprintf("%d/%d", current, limit)
+}
+
+// pidsRatio returns how close the container is to its pids limit (0 when unlimited)
+func pidsRatio(current, limit uint64) float64 {
+	if limit == 0 {
+		return 0
+	}
+	return float64(current) / float64(limit)
+}
+
+// Log output rates above which a container is flagged as chatty
+const (
+	chattyLinesPerSec = 100
+	chattyBytesPerSec = 100 * 1024
+)
+
+// chatty reports whether a container writes logs fast enough to be worth a look
+func chatty(c *docker.ContainerInfo) bool {
+	return c.LogRate != nil && (c.LogRate.LinesPerSec >= chattyLinesPerSec || c.LogRate.BytesPerSec >= chattyBytesPerSec)
+}
+
+// formatLogRate formats log lines and bytes per second, e.g. "85 ln 12.3K", with
+// "…" while the first interval is measured and "-" for stopped containers
+func formatLogRate(c *docker.ContainerInfo) string {
+	if c.State != "running" {
+		return "-"
+	}
+	if c.LogRate == nil {
+		return "…"
+	}
+	text := fmt.Sprintf("%.0f ln %s", c.LogRate.LinesPerSec, formatNetBytes(uint64(c.LogRate.BytesPerSec)))
+	if chatty(c) {
+		text += " !"
+	}
+	return text
+}
+
+// formatGPU formats GPU utilization and memory, or "-" for containers without GPUs
+func formatGPU(c *docker.ContainerInfo) string {
+	if c.GPU == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%3.0f%% %s/%s", c.GPU.UtilPerc, formatNetBytes(c.GPU.MemUsed), formatNetBytes(c.GPU.MemTotal))
 }
 
 func (m Model) renderMenu() string {
 	var b strings.Builder
 
 	// Title
-	b.WriteString(titleStyle.Render("dtop - Docker Container Monitor"))
+	b.WriteString(m.renderTitle(i18n.T("dtop - Docker Container Monitor")))
 	b.WriteString("\n\n")
 
 	// Get selected node info for context
 	node := m.tree.GetSelected()
-	if node != nil {
+	if m.menuTitle != "" {
+		b.WriteString(projectStyle.Render(m.menuTitle))
+		b.WriteString("\n\n")
+	} else if node != nil {
 		contextInfo := ""
 		if node.Type == model.NodeTypeProject {
-			contextInfo = fmt.Sprintf("Actions for project: %s", node.Name)
+			contextInfo = i18n.Sprintf("Actions for project: %s", node.Name)
 		} else if node.Container != nil {
-			contextInfo = fmt.Sprintf("Actions for container: %s", node.Container.Name)
+			contextInfo = i18n.Sprintf("Actions for container: %s", node.Container.Name)
 		}
 		b.WriteString(projectStyle.Render(contextInfo))
 		b.WriteString("\n\n")
@@ -317,9 +922,9 @@ func (m Model) renderMenu() string {
 		prefix := "  "
 		if i == m.menuSelected {
 			prefix = "> "
-			b.WriteString(menuSelectedStyle.Render(prefix + item.Label))
+			b.WriteString(menuSelectedStyle.Render(prefix + i18n.T(item.Label)))
 		} else {
-			b.WriteString(menuItemStyle.Render(prefix + item.Label))
+			b.WriteString(menuItemStyle.Render(prefix + i18n.T(item.Label)))
 		}
 		b.WriteString("\n")
 	}
@@ -327,7 +932,7 @@ func (m Model) renderMenu() string {
 	// Help text
 	b.WriteString("\n")
 	helpText := "↑↓:select  enter:execute  esc:back"
-	b.WriteString(helpStyle.Render(helpText))
+	b.WriteString(helpStyle.Render(i18n.Help(helpText)))
 
 	return b.String()
 }
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ekinertac/dtop/config"
+	"github.com/ekinertac/dtop/docker"
+	"github.com/ekinertac/dtop/model"
+)
+
+// benchModel returns a model listing n containers in expanded projects of 20 on a
+// 200x60 terminal
+func benchModel(b *testing.B, n int) Model {
+	containers := make([]docker.ContainerInfo, n)
+	for i := range containers {
+		project := "project-" + string(rune('a'+i/20%26)) + string(rune('a'+i/520))
+		service := "service-" + string(rune('a'+i%20))
+		containers[i] = docker.ContainerInfo{
+			ID:     string(rune('a'+i%26)) + string(rune('a'+i/26%26)) + string(rune('a'+i/676)) + "0123456789abcdef",
+			Name:   project + "-" + service + "-1",
+			Image:  "example/" + service + ":latest",
+			State:  "running",
+			Status: "Up 2 hours",
+			Labels: map[string]string{
+				model.ComposeProjectLabel: project,
+				model.ComposeServiceLabel: service,
+				model.ComposeNumberLabel:  "1",
+			},
+			ContainerStats: docker.ContainerStats{CPUPerc: float64(i % 100), MemPerc: 12.5, MemUsage: "64MiB / 512MiB"},
+		}
+	}
+	// Keep the benchmark away from the user's state file
+	cfg := config.Default()
+	cfg.StateFile = filepath.Join(b.TempDir(), "state.json")
+	var m tea.Model = NewModel(nil, cfg)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 200, Height: 60})
+	m, _ = m.Update(containersMsg(containers))
+	mm := m.(Model)
+	mm.tree.SetAllExpanded(true)
+	if len(mm.tree.Containers()) != n {
+		b.Fatalf("tree has %d containers, want %d", len(mm.tree.Containers()), n)
+	}
+	return mm
+}
+
+// BenchmarkRenderView renders a frame of 500 containers whose stats don't change
+func BenchmarkRenderView(b *testing.B) {
+	m := benchModel(b, 500)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = m.renderView()
+	}
+}
+
+// BenchmarkRenderViewStats renders a frame of 500 containers after each gets a new
+// stats sample
+func BenchmarkRenderViewStats(b *testing.B) {
+	m := benchModel(b, 500)
+	containers := m.tree.Containers()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j, c := range containers {
+			c.CPUPerc = float64((i + j) % 100)
+		}
+		_ = m.renderView()
+	}
+}
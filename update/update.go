@@ -0,0 +1,275 @@
+// Package update looks up dtop's latest release on GitHub and replaces the running
+// binary with it. Nothing here runs unless the user turns on update_check or runs
+// dtop self-update.
+package update
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ekinertac/dtop/config"
+)
+
+// releasesURL is the GitHub API endpoint of the latest release
+const releasesURL = "https://api.github.com/repos/ekinertac/dtop/releases/latest"
+
+// checkInterval is how long a check result is reused before GitHub is asked again
+const checkInterval = 24 * time.Hour
+
+// checksumsAsset is the release asset listing the SHA-256 of the binaries, as
+// "<hex>  <name>" lines
+const checksumsAsset = "checksums.txt"
+
+// Release is a published dtop release
+type Release struct {
+	Version string // Without the leading "v", e.g. "0.4.0"
+	URL     string // Release page
+	Assets  []Asset
+}
+
+// Asset is a file attached to a release
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Latest asks GitHub for the latest release
+func Latest(ctx context.Context) (*Release, error) {
+	resp, err := get(ctx, releasesURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var v struct {
+		TagName string  `json:"tag_name"`
+		HTMLURL string  `json:"html_url"`
+		Assets  []Asset `json:"assets"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
+		return nil, fmt.Errorf("latest release: %w", err)
+	}
+	return &Release{Version: strings.TrimPrefix(v.TagName, "v"), URL: v.HTMLURL, Assets: v.Assets}, nil
+}
+
+// get requests a URL, failing on non-2xx responses
+func get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	// GitHub's API refuses requests without a user agent
+	req.Header.Set("User-Agent", "dtop")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s: %s", url, resp.Status)
+	}
+	return resp, nil
+}
+
+// Newer reports whether version a is newer than b; both are dotted numbers with
+// an optional leading "v", and anything after a "-" is ignored
+func Newer(a, b string) bool {
+	pa, pb := parts(a), parts(b)
+	for i := range max(len(pa), len(pb)) {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x != y {
+			return x > y
+		}
+	}
+	return false
+}
+
+func parts(version string) []int {
+	version = strings.TrimPrefix(version, "v")
+	version, _, _ = strings.Cut(version, "-")
+	var nums []int
+	for _, field := range strings.Split(version, ".") {
+		n, _ := strconv.Atoi(field)
+		nums = append(nums, n)
+	}
+	return nums
+}
+
+// cache is the last check's result, kept in update.json next to the config
+type cache struct {
+	Checked time.Time `json:"checked"`
+	Version string    `json:"version"`
+}
+
+func cachePath() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "update.json"), nil
+}
+
+// Check returns the latest version when it is newer than current, or "" when
+// current is up to date. GitHub is asked at most once a day; in between the last
+// answer is reused.
+func Check(ctx context.Context, current string) (string, error) {
+	path, err := cachePath()
+	if err != nil {
+		return "", err
+	}
+
+	var c cache
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+	if err != nil || json.Unmarshal(data, &c) != nil || time.Since(c.Checked) > checkInterval {
+		release, err := Latest(ctx)
+		if err != nil {
+			return "", err
+		}
+		c = cache{Checked: time.Now(), Version: release.Version}
+		if data, err := json.Marshal(c); err == nil {
+			// Failing to cache only means asking again next time
+			_ = os.MkdirAll(filepath.Dir(path), 0o755)
+			_ = os.WriteFile(path, data, 0o644)
+		}
+	}
+
+	if Newer(c.Version, current) {
+		return c.Version, nil
+	}
+	return "", nil
+}
+
+// AssetName is the name of the release binary for this platform, as built by
+// make build-all, e.g. dtop-linux-amd64
+func AssetName() string {
+	name := "dtop-" + runtime.GOOS + "-" + runtime.GOARCH
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// asset finds a release asset by name
+func (r *Release) asset(name string) (Asset, bool) {
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return Asset{}, false
+}
+
+// Install downloads the release's binary for this platform and replaces the
+// running executable with it, returning the executable's path. The download must
+// match its checksum in the release's checksums asset.
+func Install(ctx context.Context, r *Release) (string, error) {
+	binary, ok := r.asset(AssetName())
+	if !ok {
+		return "", fmt.Errorf("release v%s has no %s binary", r.Version, AssetName())
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return "", err
+	}
+
+	checksums, ok := r.asset(checksumsAsset)
+	if !ok {
+		return "", fmt.Errorf("release v%s has no %s to verify the binary with", r.Version, checksumsAsset)
+	}
+	want, err := checksum(ctx, checksums.URL, binary.Name)
+	if err != nil {
+		return "", err
+	}
+
+	// Download next to the executable so the final rename stays on one filesystem
+	tmp, err := os.CreateTemp(filepath.Dir(exe), ".dtop-update-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	resp, err := get(ctx, binary.URL)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, hash), resp.Body); err != nil {
+		return "", fmt.Errorf("download %s: %w", binary.Name, err)
+	}
+	if got := hex.EncodeToString(hash.Sum(nil)); got != want {
+		return "", fmt.Errorf("%s: checksum mismatch (got %s, want %s)", binary.Name, got, want)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Chmod(tmp.Name(), 0o755); err != nil {
+		return "", err
+	}
+
+	// Windows can't replace a running executable, but can rename it out of the way
+	old := ""
+	if runtime.GOOS == "windows" {
+		old = exe + ".old"
+		os.Remove(old)
+		if err := os.Rename(exe, old); err != nil {
+			return "", err
+		}
+	}
+	if err := os.Rename(tmp.Name(), exe); err != nil {
+		if old != "" {
+			// Put the running executable back rather than leave none
+			os.Rename(old, exe)
+		}
+		return "", err
+	}
+	return exe, nil
+}
+
+// checksum looks up a file's SHA-256 in a checksums asset
+func checksum(ctx context.Context, url, name string) (string, error) {
+	resp, err := get(ctx, url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("%s lists no checksum for %s", checksumsAsset, name)
+}
@@ -0,0 +1,57 @@
+package update
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewer(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"1.2.0", "1.1.9", true},
+		{"1.1.9", "1.2.0", false},
+		{"1.2.0", "1.2.0", false},
+		{"v1.10.0", "1.9.0", true},
+		{"1.2", "1.2.0", false},
+		{"1.2.1", "1.2", true},
+		{"2.0.0-rc1", "1.9.9", true},
+		{"1.0.0-rc1", "1.0.0", false},
+	}
+	for _, tt := range tests {
+		if got := Newer(tt.a, tt.b); got != tt.want {
+			t.Errorf("Newer(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/"+checksumsAsset {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("0a1b2c  dtop_linux_amd64.tar.gz\nDEADBEEF *dtop_darwin_arm64.tar.gz\nmalformed line here\n"))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		path, name string
+		want       string
+		wantErr    bool
+	}{
+		{"/" + checksumsAsset, "dtop_linux_amd64.tar.gz", "0a1b2c", false},
+		{"/" + checksumsAsset, "dtop_darwin_arm64.tar.gz", "deadbeef", false},
+		{"/" + checksumsAsset, "dtop_windows_amd64.zip", "", true},
+		{"/missing.txt", "dtop_linux_amd64.tar.gz", "", true},
+	}
+	for _, tt := range tests {
+		got, err := checksum(context.Background(), srv.URL+tt.path, tt.name)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("checksum(%s, %q) = %q, %v; want %q, error %v", tt.path, tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}